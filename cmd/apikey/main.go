@@ -0,0 +1,148 @@
+// Command apikey mints, lists and revokes API keys directly in Postgres, which is how the first key with
+// the keys:admin scope is created. Unlike /api-keys, it lists and revokes the keys of every tenant and grants
+// the cross-tenant scopes, such as catalog:write.
+//
+//	apikey create -name incident-bot -tenant acme -scopes timestamps:read,timestamps:write
+//	apikey list
+//	apikey revoke <id>
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/config"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository/postgres"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+var errUsage = errors.New(`usage:
+  apikey create -name NAME [-tenant TENANT] -scopes SCOPE[,SCOPE...]
+  apikey list
+  apikey revoke ID`)
+
+func main() {
+	log := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, errUsage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Error("config load failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	postgresClient, err := pgdb.New(cfg.Postgres, log)
+	if err != nil {
+		log.Error("create postgres client failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer postgresClient.Close()
+
+	svc := service.NewAPIKeyService(postgres.NewAPIKeyStorage(postgresClient), service.NewValidator())
+
+	if err = run(context.Background(), svc, os.Args[1], os.Args[2:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		postgresClient.Close()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, svc service.APIKeyService, command string, args []string, out io.Writer) error {
+	switch command {
+	case "create":
+		return create(ctx, svc, args, out)
+	case "list":
+		return list(ctx, svc, out)
+	case "revoke":
+		return revoke(ctx, svc, args, out)
+	default:
+		return errUsage
+	}
+}
+
+func create(ctx context.Context, svc service.APIKeyService, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	name := fs.String("name", "", "name of the key, recorded as the actor of its changes")
+	tenant := fs.String("tenant", entity.DefaultTenant, "tenant the key belongs to")
+	scopes := fs.String("scopes", "", "comma-separated scopes: "+joinScopes(entity.Scopes))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	k := &entity.APIKey{Name: *name, Tenant: *tenant}
+	for _, scope := range strings.Split(*scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			k.Scopes = append(k.Scopes, entity.Scope(scope))
+		}
+	}
+
+	created, err := svc.CreateAny(ctx, k)
+	if err != nil {
+		return fmt.Errorf("create api key: %w", err)
+	}
+
+	_, err = fmt.Fprintf(out, "id:  %s\nkey: %s\n\nThe key is not shown again.\n", created.ID, created.Key)
+
+	return err
+}
+
+func list(ctx context.Context, svc service.APIKeyService, out io.Writer) error {
+	keys, err := svc.ListAll(ctx)
+	if err != nil {
+		return fmt.Errorf("list api keys: %w", err)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTENANT\tPREFIX\tSCOPES\tCREATED\tREVOKED")
+	for _, k := range keys {
+		revoked := "-"
+		if k.RevokedAt != nil {
+			revoked = k.RevokedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			k.ID, k.Name, k.Tenant, k.Prefix, joinScopes(k.Scopes), k.CreatedAt.Format(time.RFC3339), revoked)
+	}
+
+	return w.Flush()
+}
+
+func revoke(ctx context.Context, svc service.APIKeyService, args []string, out io.Writer) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid ID %q", args[0])
+	}
+
+	if err = svc.RevokeAny(ctx, id); err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+
+	_, err = fmt.Fprintf(out, "revoked %s\n", id)
+
+	return err
+}
+
+func joinScopes(scopes []entity.Scope) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return strings.Join(names, ",")
+}
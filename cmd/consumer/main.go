@@ -9,14 +9,17 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/config"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository/postgres"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
 	"github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/rabbitmq"
 	"github.com/sdvaanyaa/sla-timestamp-api/pkg/cache"
 	"github.com/sdvaanyaa/sla-timestamp-api/pkg/cache/rdscache"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 func main() {
@@ -25,11 +28,27 @@ func main() {
 	cfg := loadConfig(log)
 	cache := initCache(cfg, log)
 	broker := initBroker(cfg, log)
+	postgresClient := initPostgres(cfg, log)
+	defer postgresClient.Close()
+
+	dispatcher := service.NewWebhookDispatcher(
+		postgres.NewWebhookStorage(postgresClient),
+		service.NewWebhookClient(cfg.Webhook.Timeout, cfg.Webhook.AllowPrivateTargets),
+		service.WebhookRetryPolicy{
+			MaxAttempts: cfg.Webhook.MaxAttempts,
+			BaseDelay:   cfg.Webhook.BackoffBase,
+			MaxDelay:    cfg.Webhook.BackoffMax,
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	ch, q := initChannelAndQueue(broker, cfg, log)
 	msgs := initConsume(ch, q, log)
 
-	go consumeMessages(cache, msgs, log)
+	go consumeMessages(cache, dispatcher, msgs, log)
+	go deliverWebhooks(ctx, dispatcher, cfg.Webhook.PollInterval, log)
 
 	waitForSignal(log, broker, ch)
 }
@@ -54,7 +73,7 @@ func initCache(cfg *config.Config, log *slog.Logger) cache.Cache {
 }
 
 func initBroker(cfg *config.Config, log *slog.Logger) *rabbitmq.Client {
-	broker, err := rabbitmq.New(cfg.RabbitMQ.URL(), cfg.RabbitMQ.Queue, log)
+	broker, err := rabbitmq.New(cfg.RabbitMQ.URL(), cfg.RabbitMQ.Queue, cfg.RabbitMQ.Exchange, log)
 	if err != nil {
 		log.Error("create rabbitmq broker failed", slog.Any("error", err))
 		os.Exit(1)
@@ -62,6 +81,15 @@ func initBroker(cfg *config.Config, log *slog.Logger) *rabbitmq.Client {
 	return broker
 }
 
+func initPostgres(cfg *config.Config, log *slog.Logger) *pgdb.Client {
+	postgresClient, err := pgdb.New(cfg.Postgres, log)
+	if err != nil {
+		log.Error("create postgres client failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	return postgresClient
+}
+
 func initChannelAndQueue(
 	broker *rabbitmq.Client,
 	cfg *config.Config,
@@ -90,8 +118,17 @@ func initConsume(ch *amqp091.Channel, q amqp091.Queue, log *slog.Logger) <-chan
 	return msgs
 }
 
-func consumeMessages(cache cache.Cache, msgs <-chan amqp091.Delivery, log *slog.Logger) {
+func consumeMessages(
+	cache cache.Cache,
+	dispatcher service.WebhookDispatcher,
+	msgs <-chan amqp091.Delivery,
+	log *slog.Logger,
+) {
 	for d := range msgs {
+		if err := dispatcher.Enqueue(context.Background(), d.Body); err != nil {
+			log.Error("enqueue webhook deliveries failed", slog.Any("error", err))
+		}
+
 		var event map[string]any
 		if err := json.Unmarshal(d.Body, &event); err != nil {
 			log.Error("unmarshal failed", slog.Any("error", err))
@@ -106,6 +143,10 @@ func consumeMessages(cache cache.Cache, msgs <-chan amqp091.Delivery, log *slog.
 		switch action {
 		case "create":
 			handleCreate(event, cache, log)
+		case "update", "restore":
+			handleUpdate(event, cache, log)
+		case "bulk_create":
+			handleBulkCreate(event, cache, log)
 		case "delete":
 			handleDelete(event, cache, log)
 		}
@@ -126,10 +167,37 @@ func handleCreate(event map[string]any, cache cache.Cache, log *slog.Logger) {
 	}
 
 	ctx := context.Background()
-	key := fmt.Sprintf(service.TimestampCachePrefix, ts.ID.String())
+	key := fmt.Sprintf(service.TimestampCachePrefix, eventTenant(event), ts.ID.String())
 	_ = cache.Set(ctx, key, &ts, service.CacheTTL)
 }
 
+func handleUpdate(event map[string]any, cache cache.Cache, log *slog.Logger) {
+	handleCreate(event, cache, log)
+	_ = cache.DeletePrefix(context.Background(), fmt.Sprintf(service.ListCachePrefix, eventTenant(event)))
+}
+
+func handleBulkCreate(event map[string]any, cache cache.Cache, log *slog.Logger) {
+	dataJSON, err := json.Marshal(event["data"])
+	if err != nil {
+		log.Error("marshal data failed", slog.Any("error", err))
+		return
+	}
+
+	var tss []*entity.Timestamp
+	if err := json.Unmarshal(dataJSON, &tss); err != nil {
+		log.Error("unmarshal timestamps failed", slog.Any("error", err))
+		return
+	}
+
+	ctx := context.Background()
+	tenant := eventTenant(event)
+	for _, ts := range tss {
+		key := fmt.Sprintf(service.TimestampCachePrefix, tenant, ts.ID.String())
+		_ = cache.Set(ctx, key, ts, service.CacheTTL)
+	}
+	_ = cache.DeletePrefix(ctx, fmt.Sprintf(service.ListCachePrefix, tenant))
+}
+
 func handleDelete(event map[string]any, cache cache.Cache, log *slog.Logger) {
 	idStr, ok := event["id"].(string)
 	if !ok {
@@ -143,9 +211,47 @@ func handleDelete(event map[string]any, cache cache.Cache, log *slog.Logger) {
 	}
 
 	ctx := context.Background()
-	key := fmt.Sprintf(service.TimestampCachePrefix, id.String())
+	tenant := eventTenant(event)
+	key := fmt.Sprintf(service.TimestampCachePrefix, tenant, id.String())
 	_ = cache.Delete(ctx, key)
-	_ = cache.Delete(ctx, service.ListCachePrefix)
+	_ = cache.DeletePrefix(ctx, fmt.Sprintf(service.ListCachePrefix, tenant))
+}
+
+// eventTenant returns the tenant an event was published in. Events published before tenants were
+// introduced belong to the default tenant.
+func eventTenant(event map[string]any) string {
+	if tenant, ok := event["tenant_id"].(string); ok && tenant != "" {
+		return tenant
+	}
+	return entity.DefaultTenant
+}
+
+// deliverWebhooks sends due webhook deliveries every interval, and again at once after a full batch.
+func deliverWebhooks(
+	ctx context.Context,
+	dispatcher service.WebhookDispatcher,
+	interval time.Duration,
+	log *slog.Logger,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := dispatcher.DeliverDue(ctx)
+				if err != nil {
+					log.Error("deliver webhooks failed", slog.Any("error", err))
+				}
+				if err != nil || n < service.WebhookBatchSize {
+					break
+				}
+			}
+		}
+	}
 }
 
 func waitForSignal(log *slog.Logger, broker *rabbitmq.Client, ch *amqp091.Channel) {
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memCache is a cache.Cache kept in memory, with the prefix semantics of rdscache.
+type memCache struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+func newMemCache() *memCache {
+	return &memCache{items: make(map[string][]byte)}
+}
+
+func (c *memCache) Get(_ context.Context, key string, dest any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.items[key]
+	if !ok {
+		return cache.ErrCacheMiss
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (c *memCache) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[key] = data
+	return nil
+}
+
+func (c *memCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.items, key)
+	return nil
+}
+
+func (c *memCache) DeletePrefix(_ context.Context, prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.items, key)
+		}
+	}
+	return nil
+}
+
+func (c *memCache) has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.items[key]
+	return ok
+}
+
+func TestListPagesEvicted(t *testing.T) {
+	t.Parallel()
+
+	ts := &entity.Timestamp{
+		ID:         uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+		ExternalID: "INC-1",
+		Timestamp:  time.Date(2025, 7, 13, 15, 0, 0, 0, time.UTC),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+		Version:    1,
+	}
+	hash := sha256.Sum256([]byte(`{"limit":10}`))
+	page := fmt.Sprintf(service.ListCacheKey, "acme", hash)
+	otherPage := fmt.Sprintf(service.ListCacheKey, "globex", hash)
+
+	tests := []struct {
+		name   string
+		handle func(event map[string]any, c cache.Cache, log *slog.Logger)
+		event  map[string]any
+	}{
+		{
+			name:   "Restore",
+			handle: handleUpdate,
+			event:  map[string]any{"action": "restore", "tenant_id": "acme", "data": ts},
+		},
+		{
+			name:   "Delete",
+			handle: handleDelete,
+			event:  map[string]any{"action": "delete", "tenant_id": "acme", "id": ts.ID.String(), "data": ts},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newMemCache()
+			require.NoError(t, c.Set(t.Context(), page, &entity.ListPage{}, service.CacheTTL))
+			require.NoError(t, c.Set(t.Context(), otherPage, &entity.ListPage{}, service.CacheTTL))
+
+			// The event is decoded from JSON, as it is when it arrives from the broker.
+			data, err := json.Marshal(tt.event)
+			require.NoError(t, err)
+			var event map[string]any
+			require.NoError(t, json.Unmarshal(data, &event))
+
+			tt.handle(event, c, slog.Default())
+
+			assert.False(t, c.has(page), "the list pages of the tenant are evicted")
+			assert.True(t, c.has(otherPage), "the list pages of other tenants are kept")
+		})
+	}
+}
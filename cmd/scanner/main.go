@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/config"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository/postgres"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/rabbitmq"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	log := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	cfg := loadConfig(log)
+	postgresClient := initPostgres(cfg, log)
+	broker := initBroker(cfg, log)
+
+	scanner := service.NewBreachScanner(
+		postgres.NewBreachStorage(postgresClient), broker, cfg.Scanner.Threshold, cfg.Scanner.Lookback,
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("scanner started",
+		slog.Duration("interval", cfg.Scanner.Interval),
+		slog.Duration("threshold", cfg.Scanner.Threshold),
+		slog.Duration("lookback", cfg.Scanner.Lookback),
+	)
+
+	runScans(ctx, scanner, cfg.Scanner.Interval, log)
+
+	if err := broker.Close(); err != nil {
+		log.Error("close rabbitmq broker failed", slog.Any("error", err))
+	}
+	postgresClient.Close()
+
+	log.Info("shutdown")
+}
+
+func loadConfig(log *slog.Logger) *config.Config {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Error("config load failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	return cfg
+}
+
+func initPostgres(cfg *config.Config, log *slog.Logger) *pgdb.Client {
+	postgresClient, err := pgdb.New(cfg.Postgres, log)
+	if err != nil {
+		log.Error("create postgres client failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	return postgresClient
+}
+
+func initBroker(cfg *config.Config, log *slog.Logger) *rabbitmq.Client {
+	broker, err := rabbitmq.New(cfg.RabbitMQ.URL(), cfg.RabbitMQ.Queue, cfg.RabbitMQ.Exchange, log)
+	if err != nil {
+		log.Error("create rabbitmq broker failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	return broker
+}
+
+func runScans(ctx context.Context, scanner service.BreachScanner, interval time.Duration, log *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		emitted, err := scanner.Scan(ctx)
+		if err != nil {
+			log.Error("scan failed", slog.Any("error", err))
+		} else if emitted > 0 {
+			log.Info("sla breaches emitted", slog.Int("count", emitted))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,25 +1,42 @@
 package main
 
 import (
-	"github.com/go-playground/validator/v10"
+	"context"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
 	"github.com/redis/go-redis/v9"
 	_ "github.com/sdvaanyaa/sla-timestamp-api/docs"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/config"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/grpcserver"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/handler"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/middleware"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository/postgres"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
 	"github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/rabbitmq"
 	"github.com/sdvaanyaa/sla-timestamp-api/pkg/cache/rdscache"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/jwt"
 	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"google.golang.org/grpc"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
+const (
+	IdempotencyPurgeInterval = time.Hour
+	DeletedPurgeInterval     = time.Hour
+	JWKSFetchTimeout         = 10 * time.Second
+)
+
+// @securityDefinitions.apikey	ApiKeyAuth
+// @in							header
+// @name						X-API-Key
+// @description				An API key, also accepted as "Authorization: Bearer <key>", or a JWT.
 func main() {
 	log := slog.New(slog.NewJSONHandler(
 		os.Stderr,
@@ -32,6 +49,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	transitionGraphs, err := cfg.Transitions.Graphs()
+	if err != nil {
+		log.Error("load transition graphs failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+
 	postgresClient, err := pgdb.New(cfg.Postgres, log)
 	if err != nil {
 		log.Error("create postgres client failed", slog.Any("error", err))
@@ -39,6 +62,9 @@ func main() {
 	}
 	defer postgresClient.Close()
 	storage := postgres.New(postgresClient)
+	policyStorage := postgres.NewPolicyStorage(postgresClient)
+	calendarStorage := postgres.NewCalendarStorage(postgresClient)
+	metricsStorage := postgres.NewMetricsStorage(postgresClient)
 
 	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr()})
 	cache, err := rdscache.New(redisClient, log)
@@ -47,7 +73,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	broker, err := rabbitmq.New(cfg.RabbitMQ.URL(), cfg.RabbitMQ.Queue, log) // New
+	broker, err := rabbitmq.New(cfg.RabbitMQ.URL(), cfg.RabbitMQ.Queue, cfg.RabbitMQ.Exchange, log)
 	if err != nil {
 		log.Error("create rabbitmq broker failed", slog.Any("error", err))
 		os.Exit(1)
@@ -58,16 +84,78 @@ func main() {
 		}
 	}()
 
-	val := validator.New()
-	svc := service.New(storage, val, cache, broker)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	app := fiber.New()
+	val := service.NewValidator()
+	catalogSvc := service.NewCatalogService(postgres.NewCatalogStorage(postgresClient), val)
+	if err = catalogSvc.Refresh(ctx); err != nil {
+		log.Error("load tag and stage catalog failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	go refreshCatalog(ctx, catalogSvc, cfg.Catalog.RefreshInterval, log)
+
+	transitions := service.TransitionRules{Mode: cfg.Transitions.Mode, Graphs: transitionGraphs}
+	svc := service.New(storage, val, cache, broker, calendarStorage, transitions)
+	go purgeDeletedTimestamps(ctx, svc, cfg.Retention.Deleted, log)
+	policySvc := service.NewPolicyService(policyStorage, storage, calendarStorage, val)
+	calendarSvc := service.NewCalendarService(calendarStorage, val)
+	metricsSvc := service.NewMetricsService(metricsStorage)
+	idempotencySvc := service.NewIdempotencyService(postgres.NewIdempotencyStorage(postgresClient), cfg.Idempotency.TTL)
+	go purgeIdempotencyKeys(ctx, idempotencySvc, log)
+
+	auditSvc := service.NewAuditService(postgres.NewAuditStorage(postgresClient), val)
+
+	webhookSvc := service.NewWebhookService(postgres.NewWebhookStorage(postgresClient), val)
+
+	apiKeySvc := service.NewAPIKeyService(postgres.NewAPIKeyStorage(postgresClient), val)
+	authenticator := service.Authenticators{apiKeySvc}
+	if cfg.JWT.JWKS != "" {
+		tokenAuth, tokenErr := newTokenAuthenticator(ctx, cfg.JWT, log)
+		if tokenErr != nil {
+			log.Error("create jwt authenticator failed", slog.Any("error", tokenErr))
+			os.Exit(1)
+		}
+		authenticator = append(authenticator, tokenAuth)
+	}
+
+	streamSvc := service.NewStreamService(broker, val, cfg.Stream.ReplaySize)
+	go func() {
+		if err := streamSvc.Run(ctx); err != nil {
+			log.Error("timestamp event stream stopped", slog.Any("error", err))
+		}
+	}()
+
+	app := fiber.New(fiber.Config{ErrorHandler: handler.ErrorHandler(log)})
+	app.Use(middleware.RequestInfo())
 	app.Use(middleware.Logging(log))
 	app.Use(middleware.RateLimiter())
-	handler.New(app, svc)
 
+	// The docs are registered before Authenticate, which every later route is behind.
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
+	app.Use(middleware.Authenticate(authenticator))
+	app.Use("/timestamps", middleware.Idempotency(idempotencySvc, log))
+	handler.New(
+		app,
+		middleware.RequireScope,
+		svc,
+		policySvc,
+		calendarSvc,
+		metricsSvc,
+		catalogSvc,
+		auditSvc,
+		streamSvc,
+		webhookSvc,
+		apiKeySvc,
+	)
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcserver.UnaryInterceptor(authenticator, log)),
+		grpc.ChainStreamInterceptor(grpcserver.StreamInterceptor(authenticator, log)),
+	)
+	grpcserver.New(grpcServer, svc, streamSvc)
+
 	go func() {
 		if err = app.Listen(":" + cfg.HTTP.Address); err != nil {
 			log.Error("server failed", slog.Any("error", err))
@@ -75,11 +163,124 @@ func main() {
 		}
 	}()
 
+	go func() {
+		lis, listenErr := net.Listen("tcp", ":"+cfg.GRPC.Address)
+		if listenErr != nil {
+			log.Error("grpc listen failed", slog.Any("error", listenErr))
+			os.Exit(1)
+		}
+		if serveErr := grpcServer.Serve(lis); serveErr != nil {
+			log.Error("grpc server failed", slog.Any("error", serveErr))
+			os.Exit(1)
+		}
+	}()
+
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 	<-sig
 
+	// Stopping the stream closes its open responses and Watch calls, which Shutdown and GracefulStop
+	// would otherwise wait for.
+	cancel()
 	if err = app.Shutdown(); err != nil {
 		log.Error("shutdown failed", slog.Any("error", err))
 	}
+	grpcServer.GracefulStop()
+}
+
+func refreshCatalog(ctx context.Context, catalog service.CatalogService, interval time.Duration, log *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := catalog.Refresh(ctx); err != nil {
+				log.Error("refresh tag and stage catalog failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// newTokenAuthenticator loads the JWKS and keeps refreshing it until ctx is done.
+func newTokenAuthenticator(ctx context.Context, cfg config.JWTConfig, log *slog.Logger) (service.Authenticator, error) {
+	permissions, err := cfg.Permissions()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := jwt.NewKeySet(cfg.JWKS, &http.Client{Timeout: JWKSFetchTimeout})
+	if err = keys.Refresh(ctx); err != nil {
+		return nil, fmt.Errorf("load jwks: %w", err)
+	}
+	go refreshJWKS(ctx, keys, cfg.RefreshInterval, log)
+
+	verifier := &jwt.Verifier{Keys: keys, Issuer: cfg.Issuer, Audience: cfg.Audience, Leeway: cfg.Leeway}
+
+	return service.NewTokenAuthenticator(verifier, service.TokenClaims{
+		Name:        cfg.NameClaim,
+		Tenant:      cfg.TenantClaim,
+		Scope:       cfg.ScopeClaim,
+		Permissions: permissions,
+	}), nil
+}
+
+func refreshJWKS(ctx context.Context, keys *jwt.KeySet, interval time.Duration, log *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := keys.Refresh(ctx); err != nil {
+				log.Error("refresh jwks failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+func purgeIdempotencyKeys(ctx context.Context, idempotency service.IdempotencyService, log *slog.Logger) {
+	ticker := time.NewTicker(IdempotencyPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := idempotency.Purge(ctx); err != nil {
+				log.Error("purge idempotency keys failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+func purgeDeletedTimestamps(
+	ctx context.Context,
+	svc service.TimestampService,
+	retention time.Duration,
+	log *slog.Logger,
+) {
+	ticker := time.NewTicker(DeletedPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := svc.PurgeDeleted(ctx, retention)
+			if err != nil {
+				log.Error("purge deleted timestamps failed", slog.Any("error", err))
+				continue
+			}
+			if purged > 0 {
+				log.Info("purged deleted timestamps", slog.Int64("count", purged))
+			}
+		}
+	}
 }
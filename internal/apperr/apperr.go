@@ -0,0 +1,133 @@
+// Package apperr defines the errors the API reports to its clients. Every error has a Kind, which selects
+// the HTTP status and gRPC code, and a stable Code that clients can match on. Errors without a Kind are
+// internal: they are logged and reported without details.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"strings"
+)
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalid
+	KindNotFound
+	KindConflict
+	KindPreconditionFailed
+	KindUnprocessable
+	KindTooManyRequests
+	KindUnavailable
+	KindUnauthenticated
+	KindForbidden
+)
+
+// CodeInternal is the code of every error without a Kind.
+const CodeInternal = "internal"
+
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	// Err is the cause, if any. Its message is not part of Message.
+	Err error
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap gives err a kind and code, keeping its message. An err that already has a kind is returned as is.
+func Wrap(kind Kind, code string, err error) error {
+	if _, ok := As(err); ok {
+		return err
+	}
+	return &Error{Kind: kind, Code: code, Message: err.Error(), Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// As returns the first Error in the chain of err.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// KindOf returns the kind of err and its code, or KindInternal and CodeInternal for an error without one.
+func KindOf(err error) (Kind, string) {
+	if e, ok := As(err); ok {
+		return e.Kind, e.Code
+	}
+	return KindInternal, CodeInternal
+}
+
+// FieldError is a field that failed validation. Field is the JSON name of the field, prefixed with the
+// names of the enclosing fields, and Rule the validation tag it failed.
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
+
+// ValidationError is Err together with the fields that caused it.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+// Validation returns err with the fields of cause, a validator.ValidationErrors. Any other cause leaves err
+// unchanged.
+func Validation(err, cause error) error {
+	var errs validator.ValidationErrors
+	if !errors.As(cause, &errs) {
+		return err
+	}
+
+	fields := make([]FieldError, len(errs))
+	for i, fe := range errs {
+		fields[i] = FieldError{Field: fieldPath(fe), Rule: fe.Tag(), Param: fe.Param()}
+	}
+
+	return &ValidationError{Err: err, Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	names := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		names[i] = f.Field
+	}
+	return fmt.Sprintf("%s: %s", e.Err.Error(), strings.Join(names, ", "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// FieldsOf returns the fields that failed validation in the chain of err.
+func FieldsOf(err error) []FieldError {
+	var e *ValidationError
+	if errors.As(err, &e) {
+		return e.Fields
+	}
+	return nil
+}
+
+// fieldPath drops the name of the validated struct from the namespace of fe.
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
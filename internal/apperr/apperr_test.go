@@ -0,0 +1,76 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestKindOf(t *testing.T) {
+	t.Parallel()
+
+	errNotFound := New(KindNotFound, "thing_not_found", "thing not found")
+
+	tests := []struct {
+		name     string
+		err      error
+		wantKind Kind
+		wantCode string
+	}{
+		{name: "Sentinel", err: errNotFound, wantKind: KindNotFound, wantCode: "thing_not_found"},
+		{name: "Wrapped", err: fmt.Errorf("get: %w", errNotFound), wantKind: KindNotFound, wantCode: "thing_not_found"},
+		{name: "Plain", err: errors.New("query failed"), wantKind: KindInternal, wantCode: CodeInternal},
+		{
+			name:     "Wrap",
+			err:      Wrap(KindInvalid, "invalid_parameter", errors.New("invalid limit")),
+			wantKind: KindInvalid,
+			wantCode: "invalid_parameter",
+		},
+		{
+			name:     "Wrap Keeps Kind",
+			err:      Wrap(KindInvalid, "invalid_parameter", fmt.Errorf("get: %w", errNotFound)),
+			wantKind: KindNotFound,
+			wantCode: "thing_not_found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kind, code := KindOf(tt.err)
+			assert.Equal(t, tt.wantKind, kind)
+			assert.Equal(t, tt.wantCode, code)
+		})
+	}
+}
+
+func TestValidation(t *testing.T) {
+	t.Parallel()
+
+	type window struct {
+		From int `validate:"min=1"`
+	}
+	type request struct {
+		Name   string `validate:"required"`
+		Window window
+	}
+
+	errInvalid := New(KindInvalid, "invalid_input", "invalid input")
+	cause := validator.New().Struct(request{})
+	require.Error(t, cause)
+
+	err := Validation(errInvalid, cause)
+
+	assert.ErrorIs(t, err, errInvalid)
+	assert.Equal(t, "invalid input: Name, Window.From", err.Error())
+	assert.Equal(t, []FieldError{
+		{Field: "Name", Rule: "required"},
+		{Field: "Window.From", Rule: "min", Param: "1"},
+	}, FieldsOf(err))
+
+	assert.Equal(t, errInvalid, Validation(errInvalid, errors.New("not a validation error")))
+	assert.Nil(t, FieldsOf(errInvalid))
+}
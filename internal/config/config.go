@@ -1,17 +1,31 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
 	"log/slog"
+	"os"
+	"time"
 )
 
 type Config struct {
-	Postgres PostgresConfig
-	HTTP     HTTPConfig
-	Redis    RedisConfig
-	RabbitMQ RabbitMQConfig
+	Postgres    PostgresConfig
+	HTTP        HTTPConfig
+	GRPC        GRPCConfig
+	Redis       RedisConfig
+	RabbitMQ    RabbitMQConfig
+	Scanner     ScannerConfig
+	Transitions TransitionConfig
+	Catalog     CatalogConfig
+	Idempotency IdempotencyConfig
+	Retention   RetentionConfig
+	Stream      StreamConfig
+	Webhook     WebhookConfig
+	JWT         JWTConfig
 }
 
 type PostgresConfig struct {
@@ -34,6 +48,10 @@ type HTTPConfig struct {
 	Address string `env:"HTTP_PORT" envDefault:"8080"`
 }
 
+type GRPCConfig struct {
+	Address string `env:"GRPC_PORT" envDefault:"9090"`
+}
+
 type RedisConfig struct {
 	Host string `env:"REDIS_HOST" envDefault:"localhost"`
 	Port string `env:"REDIS_PORT" envDefault:"6379"`
@@ -49,12 +67,125 @@ type RabbitMQConfig struct {
 	Username string `env:"RABBITMQ_USER" envDefault:"guest"`
 	Password string `env:"RABBITMQ_PASSWORD" envDefault:"guest"`
 	Queue    string `env:"RABBITMQ_QUEUE" envDefault:"timestamp_events"`
+	// Exchange fans events out to Queue and to the stream subscription of every API replica.
+	Exchange string `env:"RABBITMQ_EXCHANGE" envDefault:"timestamp_events"`
 }
 
 func (c RabbitMQConfig) URL() string {
 	return fmt.Sprintf("amqp://%s:%s@%s:%s/", c.Username, c.Password, c.Host, c.Port)
 }
 
+type ScannerConfig struct {
+	Interval  time.Duration `env:"SCANNER_INTERVAL" envDefault:"1m"`
+	Threshold time.Duration `env:"SCANNER_THRESHOLD" envDefault:"4h"`
+	// Lookback is how long after crossing the threshold a stage is still flagged, e.g. after downtime.
+	Lookback time.Duration `env:"SCANNER_LOOKBACK" envDefault:"168h"`
+}
+
+type CatalogConfig struct {
+	// RefreshInterval bounds how long a tag or stage change made on another instance takes to apply here.
+	RefreshInterval time.Duration `env:"CATALOG_REFRESH_INTERVAL" envDefault:"1m"`
+}
+
+type IdempotencyConfig struct {
+	// TTL is how long a stored response is replayed for a retry with the same Idempotency-Key.
+	TTL time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+}
+
+type RetentionConfig struct {
+	// Deleted is how long a soft-deleted timestamp can still be restored before it is purged.
+	Deleted time.Duration `env:"DELETED_RETENTION" envDefault:"720h"`
+}
+
+type StreamConfig struct {
+	// ReplaySize is the number of recent events kept for clients resuming with Last-Event-ID.
+	ReplaySize int `env:"STREAM_REPLAY_SIZE" envDefault:"1000"`
+}
+
+type WebhookConfig struct {
+	Timeout      time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	PollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" envDefault:"5s"`
+	// A failed delivery is retried after BackoffBase, doubling up to BackoffMax, and dead-lettered once
+	// MaxAttempts attempts have failed.
+	MaxAttempts int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	BackoffBase time.Duration `env:"WEBHOOK_BACKOFF_BASE" envDefault:"10s"`
+	BackoffMax  time.Duration `env:"WEBHOOK_BACKOFF_MAX" envDefault:"1h"`
+	// AllowPrivateTargets lets webhooks be delivered to loopback, private and link-local addresses, for
+	// receivers running next to the service in development.
+	AllowPrivateTargets bool `env:"WEBHOOK_ALLOW_PRIVATE_TARGETS" envDefault:"false"`
+}
+
+type JWTConfig struct {
+	// JWKS is the path or http(s) URL of the JSON Web Key Set that bearer tokens are verified against.
+	// Tokens are only accepted when it is set; Issuer and Audience are then required.
+	JWKS            string        `env:"JWT_JWKS"`
+	RefreshInterval time.Duration `env:"JWT_JWKS_REFRESH_INTERVAL" envDefault:"15m"`
+	Issuer          string        `env:"JWT_ISSUER"`
+	Audience        string        `env:"JWT_AUDIENCE"`
+	// Leeway is the clock skew tolerated when checking the expiry of a token.
+	Leeway    time.Duration `env:"JWT_LEEWAY" envDefault:"1m"`
+	NameClaim string        `env:"JWT_NAME_CLAIM" envDefault:"sub"`
+	// TenantClaim names the tenant of the caller; tokens without it are rejected.
+	TenantClaim string `env:"JWT_TENANT_CLAIM" envDefault:"tenant_id"`
+	// ScopeClaim lists the scopes of the caller, or values such as roles that PermissionsFile maps to scopes.
+	ScopeClaim string `env:"JWT_SCOPE_CLAIM" envDefault:"scope"`
+	// PermissionsFile is an optional JSON object mapping values of ScopeClaim to the scopes they grant.
+	PermissionsFile string `env:"JWT_PERMISSIONS_FILE"`
+}
+
+// Permissions reads the claim to scope mapping from PermissionsFile, or returns nil if none is configured.
+func (c JWTConfig) Permissions() (map[string][]entity.Scope, error) {
+	if c.PermissionsFile == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(c.PermissionsFile)
+	if err != nil {
+		return nil, fmt.Errorf("read jwt permissions file: %w", err)
+	}
+
+	var permissions map[string][]entity.Scope
+	if err = json.Unmarshal(data, &permissions); err != nil {
+		return nil, fmt.Errorf("parse jwt permissions file: %w", err)
+	}
+
+	for value, scopes := range permissions {
+		for _, scope := range scopes {
+			if !scope.Valid() {
+				return nil, fmt.Errorf("jwt permissions file: unknown scope %q for %q", scope, value)
+			}
+		}
+	}
+
+	return permissions, nil
+}
+
+type TransitionConfig struct {
+	Mode entity.TransitionMode `env:"TRANSITION_MODE" envDefault:"strict"`
+	// GraphFile is an optional JSON object keyed by tag, each value mapping a stage to the stages allowed
+	// to follow it. Tags missing from the file use the default graph.
+	GraphFile string `env:"TRANSITION_GRAPH_FILE"`
+}
+
+// Graphs reads the per-tag transition graphs from GraphFile, or returns nil if none is configured.
+func (c TransitionConfig) Graphs() (map[entity.Tag]entity.TransitionGraph, error) {
+	if c.GraphFile == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(c.GraphFile)
+	if err != nil {
+		return nil, fmt.Errorf("read transition graph file: %w", err)
+	}
+
+	var graphs map[entity.Tag]entity.TransitionGraph
+	if err = json.Unmarshal(data, &graphs); err != nil {
+		return nil, fmt.Errorf("parse transition graph file: %w", err)
+	}
+
+	return graphs, nil
+}
+
 func LoadConfig() (*Config, error) {
 	if err := godotenv.Load(); err != nil {
 		slog.Warn("No .env file found", "err", err)
@@ -65,5 +196,13 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if !cfg.Transitions.Mode.Valid() {
+		return nil, fmt.Errorf("invalid TRANSITION_MODE %q", cfg.Transitions.Mode)
+	}
+
+	if cfg.JWT.JWKS != "" && (cfg.JWT.Issuer == "" || cfg.JWT.Audience == "") {
+		return nil, errors.New("JWT_ISSUER and JWT_AUDIENCE are required with JWT_JWKS")
+	}
+
 	return &cfg, nil
 }
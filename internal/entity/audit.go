@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AuditOperation string
+
+const (
+	AuditCreate  AuditOperation = "create"
+	AuditUpdate  AuditOperation = "update"
+	AuditDelete  AuditOperation = "delete"
+	AuditRestore AuditOperation = "restore"
+)
+
+// AuditEntry records one mutation of a timestamp. Before is omitted for a create; After holds the timestamp
+// as it was stored by the mutation.
+type AuditEntry struct {
+	ID          int64           `json:"id"`
+	TimestampID uuid.UUID       `json:"timestamp_id"`
+	Operation   AuditOperation  `json:"operation"`
+	Actor       string          `json:"actor"`
+	SourceIP    string          `json:"source_ip,omitempty"`
+	RequestID   string          `json:"request_id,omitempty"`
+	Before      json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After       json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type AuditQuery struct {
+	Limit  int `validate:"gte=1,lte=1000"`
+	Offset int `validate:"gte=0"`
+	Actor  string
+	From   *time.Time
+	To     *time.Time
+}
+
+// AnonymousActor is recorded for mutations made without an identified caller.
+const AnonymousActor = "anonymous"
+
+// RequestInfo identifies the caller of a request for the audit log. PrincipalID is the ID of the API key or
+// the token subject the request was authenticated with, if any.
+type RequestInfo struct {
+	Actor       string
+	IP          string
+	RequestID   string
+	PrincipalID string
+}
+
+type requestInfoKey struct{}
+
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the RequestInfo stored in ctx, or one with AnonymousActor outside of a
+// request, such as in background jobs.
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	if !ok || info.Actor == "" {
+		info.Actor = AnonymousActor
+	}
+	return info
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// Breach is an entity that has stayed in its current stage longer than the scanner threshold.
+type Breach struct {
+	Tenant         string    `json:"tenant_id"`
+	ExternalID     string    `json:"external_id"`
+	Tag            Tag       `json:"tag"`
+	Stage          Stage     `json:"stage"`
+	StageStartedAt time.Time `json:"stage_started_at"`
+	OpenFor        Duration  `json:"open_for"`
+	Threshold      Duration  `json:"threshold"`
+	DetectedAt     time.Time `json:"detected_at"`
+}
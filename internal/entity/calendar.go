@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	DateLayout         = "2006-01-02"
+	WorkingHoursLayout = "15:04"
+)
+
+// WorkingHours is the working window of a single weekday in the calendar's timezone.
+type WorkingHours struct {
+	Start string `json:"start" validate:"required,datetime=15:04" example:"09:00"`
+	End   string `json:"end" validate:"required,datetime=15:04" example:"18:00"`
+}
+
+type Holiday struct {
+	Date string `json:"date" validate:"required,datetime=2006-01-02" example:"2025-12-25"`
+	Name string `json:"name,omitempty" validate:"max=255" example:"Christmas Day"`
+}
+
+// Calendar describes business hours used to measure SLA time. Weekdays missing from
+// WorkingHours and holidays are non-working days.
+type Calendar struct {
+	ID           uuid.UUID               `json:"id,omitempty"`
+	Name         string                  `json:"name" validate:"required,max=255"`
+	Timezone     string                  `json:"timezone" validate:"required,timezone" example:"Europe/Berlin"`
+	WorkingHours map[string]WorkingHours `json:"working_hours"`
+	Holidays     []Holiday               `json:"holidays,omitempty"`
+}
+
+type CalendarRequest struct {
+	Name         string                  `json:"name" validate:"required,max=255" example:"eu-support"`
+	Timezone     string                  `json:"timezone" validate:"required,timezone" example:"Europe/Berlin"`
+	WorkingHours map[string]WorkingHours `json:"working_hours"`
+}
+
+func (r *CalendarRequest) ToCalendar() *Calendar {
+	return &Calendar{
+		Name:         r.Name,
+		Timezone:     r.Timezone,
+		WorkingHours: r.WorkingHours,
+	}
+}
+
+// WeekdayKey is the WorkingHours key of a weekday, e.g. "monday".
+func WeekdayKey(day time.Weekday) string {
+	return strings.ToLower(day.String())
+}
+
+// BusinessDuration returns how much of the interval [from, to) falls within working hours.
+func (c *Calendar) BusinessDuration(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	holidays := make(map[string]struct{}, len(c.Holidays))
+	for _, h := range c.Holidays {
+		holidays[h.Date] = struct{}{}
+	}
+
+	from, to = from.In(loc), to.In(loc)
+
+	var total time.Duration
+
+	for day := startOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if _, ok := holidays[day.Format(DateLayout)]; ok {
+			continue
+		}
+
+		start, end, ok := c.workingWindow(day)
+		if !ok {
+			continue
+		}
+
+		total += overlap(start, end, from, to)
+	}
+
+	return total
+}
+
+func (c *Calendar) workingWindow(day time.Time) (time.Time, time.Time, bool) {
+	wh, ok := c.WorkingHours[WeekdayKey(day.Weekday())]
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start, err := time.Parse(WorkingHoursLayout, wh.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	end, err := time.Parse(WorkingHoursLayout, wh.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	y, m, d := day.Date()
+
+	return time.Date(y, m, d, start.Hour(), start.Minute(), 0, 0, day.Location()),
+		time.Date(y, m, d, end.Hour(), end.Minute(), 0, 0, day.Location()),
+		true
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func overlap(aStart, aEnd, bStart, bEnd time.Time) time.Duration {
+	start, end := aStart, aEnd
+	if bStart.After(start) {
+		start = bStart
+	}
+	if bEnd.Before(end) {
+		end = bEnd
+	}
+
+	if !end.After(start) {
+		return 0
+	}
+
+	return end.Sub(start)
+}
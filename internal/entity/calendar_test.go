@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCalendar_BusinessDuration(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+
+	nineToSix := WorkingHours{Start: "09:00", End: "18:00"}
+	cal := &Calendar{
+		Timezone: "Europe/Berlin",
+		WorkingHours: map[string]WorkingHours{
+			"monday":    nineToSix,
+			"tuesday":   nineToSix,
+			"wednesday": nineToSix,
+			"thursday":  nineToSix,
+			"friday":    nineToSix,
+		},
+		Holidays: []Holiday{{Date: "2025-12-25", Name: "Christmas Day"}},
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     time.Duration
+	}{
+		{
+			name: "Within One Working Day",
+			from: time.Date(2025, 7, 14, 10, 0, 0, 0, berlin),
+			to:   time.Date(2025, 7, 14, 12, 30, 0, 0, berlin),
+			want: 150 * time.Minute,
+		},
+		{
+			name: "Outside Working Hours",
+			from: time.Date(2025, 7, 14, 19, 0, 0, 0, berlin),
+			to:   time.Date(2025, 7, 15, 8, 0, 0, 0, berlin),
+			want: 0,
+		},
+		{
+			name: "Across Weekend",
+			from: time.Date(2025, 7, 11, 17, 0, 0, 0, berlin),
+			to:   time.Date(2025, 7, 14, 10, 0, 0, 0, berlin),
+			want: 2 * time.Hour,
+		},
+		{
+			name: "Skips Holiday",
+			from: time.Date(2025, 12, 24, 17, 0, 0, 0, berlin),
+			to:   time.Date(2025, 12, 26, 10, 0, 0, 0, berlin),
+			want: 2 * time.Hour,
+		},
+		{
+			name: "Input In Another Timezone",
+			from: time.Date(2025, 7, 14, 7, 0, 0, 0, time.UTC),
+			to:   time.Date(2025, 7, 14, 9, 0, 0, 0, time.UTC),
+			want: 2 * time.Hour,
+		},
+		{
+			name: "Reversed Interval",
+			from: time.Date(2025, 7, 14, 12, 0, 0, 0, berlin),
+			to:   time.Date(2025, 7, 14, 10, 0, 0, 0, berlin),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, cal.BusinessDuration(tt.from, tt.to))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// CatalogKind selects the tag or the stage catalog.
+type CatalogKind string
+
+const (
+	CatalogTags   CatalogKind = "tags"
+	CatalogStages CatalogKind = "stages"
+)
+
+// CatalogEntry is a tag or stage that timestamps may use. Deprecated entries stay valid for existing
+// data and list filters but are rejected for new timestamps and policies.
+type CatalogEntry struct {
+	Name        string    `json:"name" validate:"required,max=64"`
+	Description string    `json:"description" validate:"max=1024"`
+	Deprecated  bool      `json:"deprecated"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CatalogEntryRequest struct {
+	Name        string `json:"name" validate:"required,max=64" example:"security"`
+	Description string `json:"description" validate:"max=1024" example:"Security incidents handled by the SOC"`
+}
+
+func (r *CatalogEntryRequest) ToEntry() *CatalogEntry {
+	return &CatalogEntry{
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+// CatalogEntryUpdate replaces the description and deprecation flag of an entry; names cannot change.
+type CatalogEntryUpdate struct {
+	Description string `json:"description" validate:"max=1024" example:"Security incidents handled by the SOC"`
+	Deprecated  bool   `json:"deprecated" example:"false"`
+}
+
+func (u *CatalogEntryUpdate) ToEntry(name string) *CatalogEntry {
+	return &CatalogEntry{
+		Name:        name,
+		Description: u.Description,
+		Deprecated:  u.Deprecated,
+	}
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"encoding/base64"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = apperr.New(apperr.KindInvalid, "invalid_cursor", "invalid cursor")
+
+// Cursor is the position of the last timestamp of a List page; the next page starts right after it.
+// Clients see it only as an opaque token.
+type Cursor struct {
+	Timestamp time.Time
+	ID        uuid.UUID
+}
+
+func (c Cursor) Encode() string {
+	raw := c.Timestamp.UTC().Format(time.RFC3339Nano) + "," + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	ts, id, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if c.Timestamp, err = time.Parse(time.RFC3339Nano, ts); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.ID, err = uuid.Parse(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
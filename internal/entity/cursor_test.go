@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	want := Cursor{
+		Timestamp: time.Date(2025, 7, 13, 15, 0, 0, 123456000, time.UTC),
+		ID:        uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+	}
+
+	got, err := ParseCursor(want.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, want, *got)
+
+	for _, token := range []string{"", "not base64!", "bm8tY29tbWE", "eCwxMjNlNDU2Nw"} {
+		_, err = ParseCursor(token)
+		assert.ErrorIs(t, err, ErrInvalidCursor, token)
+	}
+}
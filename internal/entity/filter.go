@@ -0,0 +1,309 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidFilter = apperr.New(apperr.KindInvalid, "invalid_filter", "invalid filter")
+
+// FilterOp is an operator of the filter language.
+type FilterOp string
+
+const (
+	FilterEq        FilterOp = "="
+	FilterNe        FilterOp = "!="
+	FilterLt        FilterOp = "<"
+	FilterLe        FilterOp = "<="
+	FilterGt        FilterOp = ">"
+	FilterGe        FilterOp = ">="
+	FilterPrefix    FilterOp = "^="
+	FilterILike     FilterOp = "ilike"
+	FilterIn        FilterOp = "in"
+	FilterNotIn     FilterOp = "not in"
+	FilterExists    FilterOp = "exists"
+	FilterNotExists FilterOp = "not exists"
+)
+
+// MetaFieldPrefix starts the field of a predicate on a top-level meta key, as in "meta.severity".
+const MetaFieldPrefix = "meta."
+
+// Filter is one predicate of the filter language, such as "stage!=closed", "external_id^=INC-",
+// "meta.severity>=2", "meta.region in (eu,us)" or "meta.owner exists". Field is external_id, tag, stage
+// or meta.<key>. Meta predicates other than "not exists" only match timestamps that have the key, and the
+// ordering operators only match numeric values.
+type Filter struct {
+	Field  string   `json:"field"`
+	Op     FilterOp `json:"op"`
+	Values []string `json:"values,omitempty"`
+}
+
+// MetaKey returns the meta key of a meta predicate, or "" for a column.
+func (f Filter) MetaKey() string {
+	key, ok := strings.CutPrefix(f.Field, MetaFieldPrefix)
+	if !ok {
+		return ""
+	}
+	return key
+}
+
+var (
+	// Operators are matched in order, so longer ones must come before their prefixes.
+	symbolicFilterOps = []FilterOp{FilterNe, FilterLe, FilterGe, FilterPrefix, FilterEq, FilterLt, FilterGt}
+	wordFilterOps     = []FilterOp{FilterNotExists, FilterNotIn, FilterILike, FilterIn, FilterExists}
+
+	metaFilterOps   = slices.Concat(symbolicFilterOps, wordFilterOps)
+	columnFilterOps = map[string][]FilterOp{
+		"external_id": {FilterEq, FilterNe, FilterIn, FilterNotIn, FilterPrefix, FilterILike},
+		"tag":         {FilterEq, FilterNe, FilterIn, FilterNotIn},
+		"stage":       {FilterEq, FilterNe, FilterIn, FilterNotIn},
+	}
+)
+
+// ParseFilter parses a single predicate. Errors wrap ErrInvalidFilter and quote the offending token.
+func ParseFilter(expr string) (Filter, error) {
+	expr = strings.TrimSpace(expr)
+
+	end := strings.IndexFunc(expr, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '.'
+	})
+	if end == -1 {
+		end = len(expr)
+	}
+
+	f := Filter{Field: expr[:end]}
+	allowed, ok := filterOps(f.Field)
+	if !ok {
+		return Filter{}, filterError(expr, f.Field)
+	}
+
+	rest := strings.TrimSpace(expr[end:])
+	f.Op, rest = cutFilterOp(rest)
+	if f.Op == "" || !slices.Contains(allowed, f.Op) {
+		if f.Op != "" {
+			rest = string(f.Op)
+		}
+		return Filter{}, filterError(expr, rest)
+	}
+
+	var err error
+	if f.Values, err = parseFilterValues(f.Op, strings.TrimSpace(rest)); err != nil {
+		return Filter{}, filterError(expr, err.Error())
+	}
+
+	return f, nil
+}
+
+// filterOps returns the operators field accepts and whether the field may be filtered on at all.
+func filterOps(field string) ([]FilterOp, bool) {
+	if ops, ok := columnFilterOps[field]; ok {
+		return ops, true
+	}
+
+	key, ok := strings.CutPrefix(field, MetaFieldPrefix)
+	if !ok || key == "" || strings.Contains(key, ".") {
+		return nil, false
+	}
+
+	return metaFilterOps, true
+}
+
+// cutFilterOp splits the leading operator off s. Word operators must be followed by a space, an opening
+// parenthesis or the end of s, so that a value such as "inbox" is not read as "in".
+func cutFilterOp(s string) (FilterOp, string) {
+	for _, op := range wordFilterOps {
+		rest, ok := strings.CutPrefix(s, string(op))
+		if ok && (rest == "" || rest[0] == ' ' || rest[0] == '(') {
+			return op, rest
+		}
+	}
+
+	for _, op := range symbolicFilterOps {
+		if rest, ok := strings.CutPrefix(s, string(op)); ok {
+			return op, rest
+		}
+	}
+
+	return "", s
+}
+
+// parseFilterValues parses the operand of op. The returned error is the offending token.
+func parseFilterValues(op FilterOp, s string) ([]string, error) {
+	switch op {
+	case FilterExists, FilterNotExists:
+		if s != "" {
+			return nil, errors.New(s)
+		}
+		return nil, nil
+	case FilterIn, FilterNotIn:
+		return parseFilterList(s)
+	case FilterLt, FilterLe, FilterGt, FilterGe:
+		if n, err := strconv.ParseFloat(s, 64); err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, errors.New(s)
+		}
+	}
+
+	if s == "" {
+		return nil, errors.New(s)
+	}
+
+	return []string{s}, nil
+}
+
+// parseFilterList parses a parenthesized, comma-separated list of values such as "(eu, us)".
+func parseFilterList(s string) ([]string, error) {
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return nil, errors.New(s)
+	}
+
+	values := strings.Split(s[1:len(s)-1], ",")
+	for i, v := range values {
+		if values[i] = strings.TrimSpace(v); values[i] == "" {
+			return nil, errors.New(s)
+		}
+	}
+
+	return values, nil
+}
+
+// Matches evaluates f against ts in memory, with the semantics of the database query: meta values are
+// compared as the text that ->> returns, and a JSON null matches nothing but "exists".
+func (f Filter) Matches(ts *Timestamp) bool {
+	key := f.MetaKey()
+	if key == "" {
+		return matchColumn(f, ts)
+	}
+
+	value, ok := ts.Meta[key]
+	switch f.Op {
+	case FilterExists:
+		return ok
+	case FilterNotExists:
+		return !ok
+	case FilterLt, FilterLe, FilterGt, FilterGe:
+		return ok && matchNumber(f, value)
+	}
+
+	text, ok := metaText(value)
+	return ok && matchText(f, text)
+}
+
+func matchColumn(f Filter, ts *Timestamp) bool {
+	switch f.Field {
+	case "external_id":
+		return matchText(f, ts.ExternalID)
+	case "tag":
+		return matchText(f, string(ts.Tag))
+	case "stage":
+		return matchText(f, string(ts.Stage))
+	default:
+		return false
+	}
+}
+
+// metaText returns a meta value as ->> does: strings unquoted, other values as JSON and null as no value.
+func metaText(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	return string(raw), true
+}
+
+func matchText(f Filter, s string) bool {
+	if len(f.Values) == 0 {
+		return false
+	}
+
+	switch f.Op {
+	case FilterEq:
+		return s == f.Values[0]
+	case FilterNe:
+		return s != f.Values[0]
+	case FilterIn:
+		return slices.Contains(f.Values, s)
+	case FilterNotIn:
+		return !slices.Contains(f.Values, s)
+	case FilterPrefix:
+		return strings.HasPrefix(s, f.Values[0])
+	case FilterILike:
+		return likePattern(f.Values[0]).MatchString(s)
+	default:
+		return false
+	}
+}
+
+// matchNumber only matches numeric values, as the CASE on jsonb_typeof does in the database.
+func matchNumber(f Filter, value any) bool {
+	n, ok := value.(float64)
+	if !ok || len(f.Values) == 0 {
+		return false
+	}
+
+	operand, err := strconv.ParseFloat(f.Values[0], 64)
+	if err != nil {
+		return false
+	}
+
+	switch f.Op {
+	case FilterLt:
+		return n < operand
+	case FilterLe:
+		return n <= operand
+	case FilterGt:
+		return n > operand
+	case FilterGe:
+		return n >= operand
+	default:
+		return false
+	}
+}
+
+// likePattern translates a LIKE pattern into a case-insensitive regular expression: % matches any run of
+// characters, _ a single one and a backslash escapes the next character.
+func likePattern(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("(?is)^")
+
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			expr.WriteString(".*")
+		case r == '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String())
+}
+
+func filterError(expr, token string) error {
+	if token == "" {
+		return fmt.Errorf("%w %q: unexpected end", ErrInvalidFilter, expr)
+	}
+	return fmt.Errorf("%w %q: unexpected %q", ErrInvalidFilter, expr, token)
+}
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		in        string
+		want      Filter
+		wantToken string
+	}{
+		{name: "Not Equal", in: "stage!=closed", want: Filter{Field: "stage", Op: FilterNe, Values: []string{"closed"}}},
+		{
+			name: "Prefix",
+			in:   "external_id^=INC-",
+			want: Filter{Field: "external_id", Op: FilterPrefix, Values: []string{"INC-"}},
+		},
+		{
+			name: "ILike",
+			in:   "external_id ilike %db%",
+			want: Filter{Field: "external_id", Op: FilterILike, Values: []string{"%db%"}},
+		},
+		{
+			name: "Tag In",
+			in:   "tag in (incident, alert)",
+			want: Filter{Field: "tag", Op: FilterIn, Values: []string{"incident", "alert"}},
+		},
+		{
+			name: "Meta Numeric",
+			in:   "meta.severity>=2",
+			want: Filter{Field: "meta.severity", Op: FilterGe, Values: []string{"2"}},
+		},
+		{
+			name: "Meta Not In",
+			in:   "meta.region not in (eu,us)",
+			want: Filter{Field: "meta.region", Op: FilterNotIn, Values: []string{"eu", "us"}},
+		},
+		{name: "Meta Exists", in: "meta.owner exists", want: Filter{Field: "meta.owner", Op: FilterExists}},
+		{
+			name: "Value Starting With Operator Word",
+			in:   "meta.folder=inbox",
+			want: Filter{Field: "meta.folder", Op: FilterEq, Values: []string{"inbox"}},
+		},
+		{name: "Unknown Field", in: "meta=1", wantToken: `"meta"`},
+		{name: "Column Injection", in: "id;DROP TABLE timestamps=1", wantToken: `"id"`},
+		{name: "Nested Meta Key", in: "meta.a.b=1", wantToken: `"meta.a.b"`},
+		{name: "Operator Not Allowed", in: "tag^=inc", wantToken: `"^="`},
+		{name: "Unknown Operator", in: "stage~closed", wantToken: `"~closed"`},
+		{name: "Missing Value", in: "stage!=", wantToken: "unexpected end"},
+		{name: "Not Numeric", in: "meta.severity>=high", wantToken: `"high"`},
+		{name: "Unclosed List", in: "tag in (incident", wantToken: `"(incident"`},
+		{name: "Empty List Item", in: "tag in (incident,)", wantToken: `"(incident,)"`},
+		{name: "Operand After Exists", in: "meta.owner exists x", wantToken: `"x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseFilter(tt.in)
+			if tt.wantToken != "" {
+				assert.ErrorIs(t, err, ErrInvalidFilter)
+				assert.ErrorContains(t, err, tt.wantToken)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	ts := &Timestamp{
+		ExternalID: "INC-42",
+		Tag:        TagIncident,
+		Stage:      StageCreated,
+		Meta:       map[string]any{"severity": 3.0, "region": "eu", "owner": nil, "retry": true},
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: "external_id^=INC-", want: true},
+		{filter: "external_id^=inc-", want: false},
+		{filter: "external_id ilike inc\\_%", want: false},
+		{filter: "external_id ilike inc_4%", want: true},
+		{filter: "tag in (alert, incident)", want: true},
+		{filter: "stage not in (created)", want: false},
+		{filter: "meta.severity>=3", want: true},
+		{filter: "meta.severity<3", want: false},
+		{filter: "meta.region>1", want: false},
+		{filter: "meta.severity=3", want: true},
+		{filter: "meta.retry=true", want: true},
+		{filter: "meta.region!=us", want: true},
+		{filter: "meta.missing!=us", want: false},
+		{filter: "meta.owner=null", want: false},
+		{filter: "meta.owner exists", want: true},
+		{filter: "meta.missing not exists", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := ParseFilter(tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, f.Matches(ts))
+		})
+	}
+}
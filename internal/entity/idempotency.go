@@ -0,0 +1,10 @@
+package entity
+
+// IdempotencyRecord is the outcome of a request sent with an Idempotency-Key. StatusCode is zero while
+// the request is still being processed.
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	StatusCode  int
+	Response    []byte
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TimestampFields are the JSON names of the Timestamp fields, which are also its column names.
+var TimestampFields = []string{"id", "external_id", "timestamp", "tag", "stage", "meta", "version", "deleted_at"}
+
+// SortableFields are the fields List can order by.
+var SortableFields = []string{"id", "external_id", "timestamp", "tag", "stage", "version"}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a comma-separated list of sortable fields, each optionally prefixed with "-" for
+// descending order, such as "timestamp,-external_id".
+func ParseSort(s string) ([]SortField, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var sort []SortField
+	for _, token := range strings.Split(s, ",") {
+		field := SortField{Field: strings.TrimPrefix(token, "-"), Desc: strings.HasPrefix(token, "-")}
+		if !slices.Contains(SortableFields, field.Field) {
+			return nil, fmt.Errorf("invalid sort field %q", token)
+		}
+		if slices.ContainsFunc(sort, func(f SortField) bool { return f.Field == field.Field }) {
+			return nil, fmt.Errorf("duplicate sort field %q", token)
+		}
+		sort = append(sort, field)
+	}
+
+	return sort, nil
+}
+
+// IsDefaultSort reports whether sort is the newest-first order that cursors are defined for.
+func IsDefaultSort(sort []SortField) bool {
+	return len(sort) == 0 || slices.Equal(sort, []SortField{{Field: "timestamp", Desc: true}})
+}
+
+// ParseFields parses a comma-separated list of Timestamp fields.
+func ParseFields(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(s, ",")
+	for _, field := range fields {
+		if !slices.Contains(TimestampFields, field) {
+			return nil, fmt.Errorf("invalid field %q", field)
+		}
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    []SortField
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "Empty", in: "", want: nil, wantErr: assert.NoError},
+		{
+			name: "Mixed Directions",
+			in:   "timestamp,-external_id,stage",
+			want: []SortField{
+				{Field: "timestamp"},
+				{Field: "external_id", Desc: true},
+				{Field: "stage"},
+			},
+			wantErr: assert.NoError,
+		},
+		{name: "Not Sortable", in: "meta", wantErr: assert.Error},
+		{name: "Injection", in: "timestamp;DROP TABLE timestamps", wantErr: assert.Error},
+		{name: "Duplicate", in: "tag,-tag", wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSort(tt.in)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsDefaultSort(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsDefaultSort(nil))
+	assert.True(t, IsDefaultSort([]SortField{{Field: "timestamp", Desc: true}}))
+	assert.False(t, IsDefaultSort([]SortField{{Field: "timestamp"}}))
+}
+
+func TestParseFields(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseFields("id,external_id,stage")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "external_id", "stage"}, got)
+
+	_, err = ParseFields("id,password")
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+type SLAMetricsQuery struct {
+	TimestampFrom *time.Time
+	TimestampTo   *time.Time
+	// GroupBy is a meta key whose value splits every tag into further groups.
+	GroupBy string
+}
+
+// DurationStats summarises one duration over the entities of a group. Count is the number of entities
+// that reached the measured stage; the statistics are omitted when it is zero.
+type DurationStats struct {
+	Count int64     `json:"count"`
+	Mean  *Duration `json:"mean,omitempty" swaggertype:"string" example:"14m10s"`
+	P50   *Duration `json:"p50,omitempty" swaggertype:"string" example:"9m0s"`
+	P90   *Duration `json:"p90,omitempty" swaggertype:"string" example:"35m0s"`
+	P99   *Duration `json:"p99,omitempty" swaggertype:"string" example:"1h20m0s"`
+}
+
+type SLAMetricsGroup struct {
+	Tag Tag `json:"tag"`
+	// Group is the value of the group_by meta key, omitted when it was not requested or is missing.
+	Group *string `json:"group,omitempty"`
+	// Entities is the number of entities created inside the window.
+	Entities          int64         `json:"entities"`
+	TimeToAcknowledge DurationStats `json:"time_to_acknowledge"`
+	TimeToResolve     DurationStats `json:"time_to_resolve"`
+}
+
+// SLAMetrics reports MTTA and MTTR for entities whose created stage falls inside the window.
+type SLAMetrics struct {
+	TimestampFrom *time.Time         `json:"timestamp_from,omitempty"`
+	TimestampTo   *time.Time         `json:"timestamp_to,omitempty"`
+	GroupBy       string             `json:"group_by,omitempty"`
+	Groups        []*SLAMetricsGroup `json:"groups"`
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type SLAStatus string
+
+const (
+	SLAStatusMet      SLAStatus = "met"
+	SLAStatusBreached SLAStatus = "breached"
+	SLAStatusPending  SLAStatus = "pending"
+)
+
+// SLAPolicy is a single stage-to-stage target for a tag, e.g. "incident: created→acknowledged ≤ 15m".
+// The target is a whole number of seconds.
+type SLAPolicy struct {
+	ID         uuid.UUID `json:"id,omitempty"`
+	Name       string    `json:"name" validate:"required,max=255"`
+	Tag        Tag       `json:"tag" validate:"required,tag"`
+	StartStage Stage     `json:"start_stage" validate:"required,stage"`
+	EndStage   Stage     `json:"end_stage" validate:"required,stage"`
+	Target     Duration  `json:"target" validate:"gt=0" swaggertype:"string" example:"15m"`
+}
+
+type SLAPolicyRequest struct {
+	Name       string   `json:"name" validate:"required,max=255" example:"incident acknowledgement"`
+	Tag        Tag      `json:"tag" validate:"required,tag"`
+	StartStage Stage    `json:"start_stage" validate:"required,stage"`
+	EndStage   Stage    `json:"end_stage" validate:"required,stage"`
+	Target     Duration `json:"target" validate:"gt=0" swaggertype:"string" example:"15m"`
+}
+
+// SLATargetResult is the outcome of evaluating one SLAPolicy against an entity's timestamps.
+type SLATargetResult struct {
+	PolicyID   uuid.UUID `json:"policy_id"`
+	Name       string    `json:"name"`
+	Tag        Tag       `json:"tag"`
+	StartStage Stage     `json:"start_stage"`
+	EndStage   Stage     `json:"end_stage"`
+	Target     Duration  `json:"target" swaggertype:"string" example:"15m0s"`
+	Status     SLAStatus `json:"status" enums:"met,breached,pending"`
+	Elapsed    *Duration `json:"elapsed,omitempty" swaggertype:"string" example:"12m30s"`
+	// Paused is the time spent on hold, already excluded from Elapsed and BusinessElapsed.
+	Paused *Duration `json:"paused,omitempty" swaggertype:"string" example:"30m0s"`
+	// BusinessElapsed is only set when the evaluation was requested with a calendar; the status is then
+	// decided by business time.
+	BusinessElapsed *Duration  `json:"business_elapsed,omitempty" swaggertype:"string" example:"2m0s"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+}
+
+type SLAEvaluation struct {
+	ExternalID  string             `json:"external_id"`
+	EvaluatedAt time.Time          `json:"evaluated_at"`
+	Results     []*SLATargetResult `json:"results"`
+}
+
+func (r *SLAPolicyRequest) ToPolicy() *SLAPolicy {
+	return &SLAPolicy{
+		Name:       r.Name,
+		Tag:        r.Tag,
+		StartStage: r.StartStage,
+		EndStage:   r.EndStage,
+		Target:     r.Target,
+	}
+}
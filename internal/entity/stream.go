@@ -0,0 +1,94 @@
+package entity
+
+import "reflect"
+
+// StreamAction is the kind of change a TimestampEvent reports.
+type StreamAction string
+
+const (
+	StreamCreate StreamAction = "create"
+	StreamDelete StreamAction = "delete"
+)
+
+// TimestampEvent is a change pushed to stream subscribers. ID is unique within the replica that assigned it
+// and is what clients send back as Last-Event-ID.
+type TimestampEvent struct {
+	ID        string
+	Action    StreamAction
+	Tenant    string
+	Timestamp *Timestamp
+}
+
+// Matches reports whether ts passes the filters of p, evaluated in memory with the semantics of List.
+// Deleted, pagination, sort and fields are ignored: a stream reports deletes as events of their own.
+func (p *ListQueryParams) Matches(ts *Timestamp) bool {
+	if !p.matchesColumns(ts) {
+		return false
+	}
+
+	if len(p.MetaFilter) > 0 && !jsonContains(ts.Meta, p.MetaFilter) {
+		return false
+	}
+
+	for _, f := range p.Filters {
+		if !f.Matches(ts) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *ListQueryParams) matchesColumns(ts *Timestamp) bool {
+	switch {
+	case p.ExternalID != "" && ts.ExternalID != p.ExternalID,
+		p.Tag != "" && string(ts.Tag) != p.Tag,
+		p.Stage != "" && string(ts.Stage) != p.Stage,
+		p.TimestampFrom != nil && ts.Timestamp.Before(*p.TimestampFrom),
+		p.TimestampTo != nil && ts.Timestamp.After(*p.TimestampTo):
+		return false
+	default:
+		return true
+	}
+}
+
+// jsonContains reports whether container contains contained as jsonb @> does: objects contain the keys of
+// the contained object with contained values, arrays contain every contained element in any position and
+// scalars must be equal.
+func jsonContains(container, contained any) bool {
+	switch want := contained.(type) {
+	case map[string]any:
+		have, ok := container.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range want {
+			if hv, found := have[k]; !found || !jsonContains(hv, v) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		have, ok := container.([]any)
+		if !ok {
+			return false
+		}
+		for _, v := range want {
+			if !containsElement(have, v) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(container, contained)
+	}
+}
+
+func containsElement(elements []any, contained any) bool {
+	for _, e := range elements {
+		if jsonContains(e, contained) {
+			return true
+		}
+	}
+	return false
+}
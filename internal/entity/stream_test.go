@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestListQueryParamsMatches(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 7, 13, 0, 0, 0, 0, time.UTC)
+	ts := &Timestamp{
+		ExternalID: "INC-42",
+		Timestamp:  from.Add(time.Hour),
+		Tag:        TagIncident,
+		Stage:      StageCreated,
+		Meta: map[string]any{
+			"source": "email",
+			"labels": []any{"db", "prod"},
+			"owner":  map[string]any{"team": "core"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		params ListQueryParams
+		want   bool
+	}{
+		{name: "No Filters", want: true},
+		{name: "Columns", params: ListQueryParams{ExternalID: "INC-42", Tag: "incident", Stage: "created"}, want: true},
+		{name: "Other Tag", params: ListQueryParams{Tag: "alert"}, want: false},
+		{name: "Within Window", params: ListQueryParams{TimestampFrom: &from, TimestampTo: &ts.Timestamp}, want: true},
+		{
+			name:   "Inclusive Bounds",
+			params: ListQueryParams{TimestampFrom: &ts.Timestamp, TimestampTo: &ts.Timestamp},
+			want:   true,
+		},
+		{name: "After Window", params: ListQueryParams{TimestampTo: &from}, want: false},
+		{
+			name: "Meta Contained",
+			params: ListQueryParams{
+				MetaFilter: map[string]any{"labels": []any{"prod"}, "owner": map[string]any{"team": "core"}},
+			},
+			want: true,
+		},
+		{name: "Meta Not Contained", params: ListQueryParams{MetaFilter: map[string]any{"source": "phone"}}, want: false},
+		{
+			name: "Filters Combined With AND",
+			params: ListQueryParams{Filters: []Filter{
+				{Field: "tag", Op: FilterEq, Values: []string{"incident"}},
+				{Field: "meta.source", Op: FilterNotExists},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.params.Matches(ts))
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "context"
+
+// DefaultTenant owns the data recorded before tenants were introduced, and the API keys minted without one.
+const DefaultTenant = "default"
+
+type tenantKey struct{}
+
+// WithTenant scopes ctx to tenant. Timestamps, their audit log and webhooks are only read and written
+// within the tenant of the context.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant of ctx, or "" if it is not scoped to one, as in background jobs.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type TimelineEntry struct {
+	Timestamp
+	SincePrevious         *Duration `json:"since_previous,omitempty" swaggertype:"string" example:"12m30s"`
+	BusinessSincePrevious *Duration `json:"business_since_previous,omitempty" swaggertype:"string" example:"5m0s"`
+}
+
+// Timeline is every recorded stage of an entity in chronological order together with the time spent between them.
+// Durations exclude time spent on hold.
+type Timeline struct {
+	ExternalID   string     `json:"external_id"`
+	CurrentStage Stage      `json:"current_stage"`
+	Open         bool       `json:"open"`
+	OpenedAt     time.Time  `json:"opened_at"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+	OpenDuration Duration   `json:"open_duration" swaggertype:"string" example:"3h20m0s"`
+	// PausedDuration is the open time spent between on_hold and resumed stages.
+	PausedDuration Duration `json:"paused_duration" swaggertype:"string" example:"30m0s"`
+	// BusinessOpenDuration is only set when the timeline was requested with a calendar.
+	BusinessOpenDuration *Duration        `json:"business_open_duration,omitempty" swaggertype:"string" example:"1h0m0s"`
+	Entries              []*TimelineEntry `json:"entries"`
+}
+
+// IsPauseStage reports whether the stage stops (on_hold) or restarts (resumed) the SLA clock.
+func IsPauseStage(stage Stage) bool {
+	return stage == StageOnHold || stage == StageResumed
+}
+
+// IsClosingStage reports whether reaching the stage ends the entity's open time.
+func IsClosingStage(stage Stage) bool {
+	return stage == StageResolved || stage == StageClosed
+}
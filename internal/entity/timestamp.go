@@ -1,10 +1,15 @@
 package entity
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
 	"time"
 )
 
+// Tag and Stage values are managed in the database catalog. The constants below are the built-in entries
+// seeded by the migrations that the service logic refers to.
 type Tag string
 type Stage string
 
@@ -20,6 +25,8 @@ const (
 	StageCreated      Stage = "created"
 	StageAcknowledged Stage = "acknowledged"
 	StageInProgress   Stage = "in_progress"
+	StageOnHold       Stage = "on_hold"
+	StageResumed      Stage = "resumed"
 	StageResolved     Stage = "resolved"
 	StageClosed       Stage = "closed"
 )
@@ -28,28 +35,136 @@ type Timestamp struct {
 	ID         uuid.UUID      `json:"id,omitempty"`
 	ExternalID string         `json:"external_id" validate:"required"`
 	Timestamp  time.Time      `json:"timestamp" validate:"required"`
-	Tag        Tag            `json:"tag" validate:"required,oneof=incident sla deployment maintenance alert"`
-	Stage      Stage          `json:"stage" validate:"required,oneof=created acknowledged in_progress resolved closed"`
+	Tag        Tag            `json:"tag" validate:"required,tag"`
+	Stage      Stage          `json:"stage" validate:"required,stage"`
 	Meta       map[string]any `json:"meta,omitempty" validate:"omitempty"`
+	// Version starts at 1 and is incremented by every update.
+	Version int `json:"version"`
+	// DeletedAt is set while the timestamp is soft-deleted; it is only visible in deleted listings.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type CreateTimestampRequest struct {
 	ExternalID string         `json:"external_id" validate:"required"`
 	Timestamp  time.Time      `json:"timestamp" validate:"required" example:"2025-07-13T15:00:00Z"`
-	Tag        Tag            `json:"tag" validate:"required,oneof=incident sla deployment maintenance alert"`
-	Stage      Stage          `json:"stage" validate:"required,oneof=created acknowledged in_progress resolved closed"`
+	Tag        Tag            `json:"tag" validate:"required,tag"`
+	Stage      Stage          `json:"stage" validate:"required,stage"`
 	Meta       map[string]any `json:"meta,omitempty" validate:"omitempty"`
 }
 
+type BulkCreateRequest struct {
+	Items []CreateTimestampRequest `json:"items"`
+}
+
+type BulkItemStatus string
+
+const (
+	BulkItemCreated   BulkItemStatus = "created"
+	BulkItemInvalid   BulkItemStatus = "invalid"
+	BulkItemDuplicate BulkItemStatus = "duplicate"
+)
+
+// BulkItemResult reports the outcome for the item at Index of a bulk create request.
+type BulkItemResult struct {
+	Index  int            `json:"index"`
+	Status BulkItemStatus `json:"status" enums:"created,invalid,duplicate"`
+	ID     *uuid.UUID     `json:"id,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// UpdateTimestampRequest replaces the timestamp and meta of a recorded stage. The external_id, tag and
+// stage identify the event and cannot change; delete and recreate the timestamp instead.
+type UpdateTimestampRequest struct {
+	Timestamp time.Time      `json:"timestamp" validate:"required" example:"2025-07-13T15:00:00Z"`
+	Meta      map[string]any `json:"meta,omitempty" validate:"omitempty"`
+}
+
+// TimestampPatch is a JSON Merge Patch (RFC 7386) of the timestamp and meta of a recorded stage.
+type TimestampPatch struct {
+	Timestamp *time.Time `json:"timestamp,omitempty" example:"2025-07-13T15:00:00Z"`
+	// Meta is the merge patch for meta, nil when meta is left untouched. It may be JSON null to clear meta.
+	Meta json.RawMessage `json:"meta,omitempty" swaggertype:"object"`
+}
+
+var ErrImmutableField = apperr.New(apperr.KindInvalid, "immutable_field", "only timestamp and meta can be changed")
+
+func (p *TimestampPatch) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for name, value := range fields {
+		switch name {
+		case "timestamp":
+			var t time.Time
+			if err := json.Unmarshal(value, &t); err != nil {
+				return err
+			}
+			p.Timestamp = &t
+		case "meta":
+			p.Meta = value
+		default:
+			return fmt.Errorf("%s: %w", name, ErrImmutableField)
+		}
+	}
+
+	return nil
+}
+
 type ListQueryParams struct {
 	Limit         int    `validate:"gte=1"`
 	Offset        int    `validate:"gte=0"`
 	ExternalID    string `validate:"omitempty"`
-	Tag           string `validate:"omitempty,oneof=incident sla deployment maintenance alert"`
-	Stage         string `validate:"omitempty,oneof=created acknowledged in_progress resolved closed"`
+	Tag           string `validate:"omitempty,known_tag"`
+	Stage         string `validate:"omitempty,known_stage"`
 	TimestampFrom *time.Time
 	TimestampTo   *time.Time
 	MetaFilter    map[string]any `validate:"omitempty"`
+	// Filters are predicates of the filter language, combined with AND.
+	Filters []Filter
+	// Cursor continues keyset pagination after the given position and excludes a non-zero Offset.
+	Cursor *Cursor
+	Count  CountMode `validate:"omitempty,oneof=none exact estimated"`
+	// Sort defaults to newest first; cursors are only available with the default.
+	Sort []SortField
+	// Fields limits the returned fields; empty means all of them.
+	Fields []string `validate:"omitempty,dive,oneof=id external_id timestamp tag stage meta version deleted_at"`
+	// Deleted selects soft-deleted timestamps instead of, or in addition to, live ones.
+	Deleted DeletedMode `validate:"omitempty,oneof=exclude include only"`
+}
+
+// DeletedMode selects which timestamps a List returns with regard to soft deletion.
+type DeletedMode string
+
+const (
+	DeletedExclude DeletedMode = "exclude"
+	DeletedInclude DeletedMode = "include"
+	DeletedOnly    DeletedMode = "only"
+)
+
+// CountMode selects how the total of a List is computed. Estimated reads the planner's row estimate,
+// which avoids scanning large tables but may be off by a wide margin for selective filters.
+type CountMode string
+
+const (
+	CountNone      CountMode = "none"
+	CountExact     CountMode = "exact"
+	CountEstimated CountMode = "estimated"
+)
+
+// ListPage is one page of List results. NextCursor is set only for the default sort and, like HasMore,
+// only when there are more results. Total is omitted with CountNone. Next and Prev are the links to the
+// neighbouring pages.
+type ListPage struct {
+	Items      []*Timestamp `json:"items"`
+	Total      *int64       `json:"total,omitempty"`
+	Limit      int          `json:"limit"`
+	Offset     int          `json:"offset"`
+	HasMore    bool         `json:"has_more"`
+	Next       string       `json:"next,omitempty"`
+	Prev       string       `json:"prev,omitempty"`
+	NextCursor string       `json:"next_cursor,omitempty"`
 }
 
 func (r *CreateTimestampRequest) ToTimestamp() *Timestamp {
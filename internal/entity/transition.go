@@ -0,0 +1,60 @@
+package entity
+
+import "slices"
+
+// TransitionMode controls what happens when a new timestamp breaks the transition graph.
+type TransitionMode string
+
+const (
+	// TransitionModeStrict rejects the timestamp.
+	TransitionModeStrict TransitionMode = "strict"
+	// TransitionModeWarn stores the timestamp and records the violation in its meta.
+	TransitionModeWarn TransitionMode = "warn"
+	// TransitionModeOff skips the check.
+	TransitionModeOff TransitionMode = "off"
+)
+
+// TransitionWarningKey is the meta key under which warn mode records a violation.
+const TransitionWarningKey = "transition_warning"
+
+func (m TransitionMode) Valid() bool {
+	return m == TransitionModeStrict || m == TransitionModeWarn || m == TransitionModeOff
+}
+
+// TransitionGraph lists, for every stage, the stages that may directly follow it in time. The stages
+// an entity's lifecycle may start with are listed under the empty stage.
+type TransitionGraph map[Stage][]Stage
+
+// DefaultTransitionGraph is used for every tag without a graph of its own. An entity may be put on hold
+// before it is acknowledged, so resumed may be followed by any stage that follows created.
+func DefaultTransitionGraph() TransitionGraph {
+	return TransitionGraph{
+		"":                {StageCreated},
+		StageCreated:      {StageAcknowledged, StageInProgress, StageOnHold, StageResolved, StageClosed},
+		StageAcknowledged: {StageInProgress, StageOnHold, StageResolved, StageClosed},
+		StageInProgress:   {StageOnHold, StageResolved, StageClosed},
+		StageOnHold:       {StageResumed, StageResolved, StageClosed},
+		StageResumed:      {StageAcknowledged, StageInProgress, StageOnHold, StageResolved, StageClosed},
+		StageResolved:     {StageClosed},
+	}
+}
+
+// Allows reports whether to may directly follow from; an empty from means to opens the lifecycle.
+func (g TransitionGraph) Allows(from, to Stage) bool {
+	return slices.Contains(g[from], to)
+}
+
+// Knows reports whether the graph mentions stage, either as a source or as a target of a transition.
+func (g TransitionGraph) Knows(stage Stage) bool {
+	if _, ok := g[stage]; ok && stage != "" {
+		return true
+	}
+
+	for _, next := range g {
+		if slices.Contains(next, stage) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Webhook is a subscription to the timestamp events matching its tag, stage and meta filter, which are
+// delivered as signed POST requests to URL.
+type Webhook struct {
+	ID  uuid.UUID `json:"id,omitempty"`
+	URL string    `json:"url" validate:"required,http_url,max=2048"`
+	// Secret is the HMAC-SHA256 key of the X-Webhook-Signature header. It is never returned.
+	Secret     string         `json:"-" validate:"required,min=16,max=255"`
+	Tag        string         `json:"tag,omitempty" validate:"omitempty,known_tag"`
+	Stage      string         `json:"stage,omitempty" validate:"omitempty,known_stage"`
+	MetaFilter map[string]any `json:"meta_filter,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type WebhookRequest struct {
+	URL        string         `json:"url" example:"https://example.com/hooks/sla"`
+	Secret     string         `json:"secret" example:"at-least-16-characters"`
+	Tag        string         `json:"tag,omitempty"`
+	Stage      string         `json:"stage,omitempty"`
+	MetaFilter map[string]any `json:"meta_filter,omitempty"`
+}
+
+func (r *WebhookRequest) ToWebhook() *Webhook {
+	return &Webhook{
+		URL:        r.URL,
+		Secret:     r.Secret,
+		Tag:        r.Tag,
+		Stage:      r.Stage,
+		MetaFilter: r.MetaFilter,
+	}
+}
+
+// Matches reports whether ts passes the filter of w, with the semantics of the List parameters.
+func (w *Webhook) Matches(ts *Timestamp) bool {
+	params := ListQueryParams{Tag: w.Tag, Stage: w.Stage, MetaFilter: w.MetaFilter}
+	return params.Matches(ts)
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead is the dead-letter state of a delivery that failed every attempt.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// WebhookDelivery is one event queued for one webhook. Payload is the body that is sent, the same for
+// every attempt.
+type WebhookDelivery struct {
+	ID            uuid.UUID       `json:"id"`
+	WebhookID     uuid.UUID       `json:"webhook_id"`
+	Action        string          `json:"action"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Status        DeliveryStatus  `json:"status" enums:"pending,delivered,dead"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	// URL and Secret are those of the webhook, loaded with deliveries claimed for sending.
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// WebhookAttempt is one try to send a delivery. StatusCode is zero when no response was received.
+type WebhookAttempt struct {
+	ID         int64     `json:"id"`
+	DeliveryID uuid.UUID `json:"delivery_id"`
+	WebhookID  uuid.UUID `json:"webhook_id"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Duration   Duration  `json:"duration" swaggertype:"string" example:"120ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookPayload is the JSON body of a delivery. ID identifies the delivery, so that receivers can drop
+// the duplicates that retries may cause.
+type WebhookPayload struct {
+	ID        uuid.UUID  `json:"id"`
+	Action    string     `json:"action"`
+	Timestamp *Timestamp `json:"data"`
+}
+
+type WebhookDeliveryQuery struct {
+	Status DeliveryStatus `validate:"omitempty,oneof=pending delivered dead"`
+	Limit  int            `validate:"gte=1,lte=1000"`
+	Offset int            `validate:"gte=0"`
+}
@@ -0,0 +1,151 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	timestampv1 "github.com/sdvaanyaa/sla-timestamp-api/pkg/api/timestamp/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+var countModes = map[timestampv1.CountMode]entity.CountMode{
+	timestampv1.CountMode_COUNT_MODE_UNSPECIFIED: entity.CountNone,
+	timestampv1.CountMode_COUNT_MODE_NONE:        entity.CountNone,
+	timestampv1.CountMode_COUNT_MODE_EXACT:       entity.CountExact,
+	timestampv1.CountMode_COUNT_MODE_ESTIMATED:   entity.CountEstimated,
+}
+
+var deletedModes = map[timestampv1.DeletedMode]entity.DeletedMode{
+	timestampv1.DeletedMode_DELETED_MODE_UNSPECIFIED: entity.DeletedExclude,
+	timestampv1.DeletedMode_DELETED_MODE_EXCLUDE:     entity.DeletedExclude,
+	timestampv1.DeletedMode_DELETED_MODE_INCLUDE:     entity.DeletedInclude,
+	timestampv1.DeletedMode_DELETED_MODE_ONLY:        entity.DeletedOnly,
+}
+
+func fromCreateRequest(req *timestampv1.CreateRequest) *entity.Timestamp {
+	ts := &entity.Timestamp{
+		ExternalID: req.GetExternalId(),
+		Tag:        entity.Tag(req.GetTag()),
+		Stage:      entity.Stage(req.GetStage()),
+	}
+
+	// A missing timestamp is left zero and rejected by validation.
+	if req.GetTimestamp() != nil {
+		ts.Timestamp = req.GetTimestamp().AsTime()
+	}
+	if req.GetMeta() != nil {
+		ts.Meta = req.GetMeta().AsMap()
+	}
+
+	return ts
+}
+
+func fromListRequest(req *timestampv1.ListRequest) (*entity.ListQueryParams, error) {
+	params, err := fromFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	params.Limit = int(req.GetLimit())
+	if params.Limit == 0 {
+		params.Limit = DefaultListLimit
+	}
+	params.Offset = int(req.GetOffset())
+
+	var ok bool
+	if params.Count, ok = countModes[req.GetCount()]; !ok {
+		return nil, errors.New("invalid count")
+	}
+	if params.Deleted, ok = deletedModes[req.GetDeleted()]; !ok {
+		return nil, errors.New("invalid deleted")
+	}
+
+	if req.GetCursor() != "" {
+		if params.Cursor, err = entity.ParseCursor(req.GetCursor()); err != nil {
+			return nil, err
+		}
+	}
+
+	params.Sort, err = entity.ParseSort(req.GetSort())
+
+	return params, err
+}
+
+// fromFilter converts a filter the way the REST handlers convert the List query parameters: several tags
+// or stages become an "in" predicate.
+func fromFilter(f *timestampv1.Filter) (*entity.ListQueryParams, error) {
+	params := &entity.ListQueryParams{
+		ExternalID:    f.GetExternalId(),
+		TimestampFrom: optionalTime(f.GetTimestampFrom()),
+		TimestampTo:   optionalTime(f.GetTimestampTo()),
+	}
+
+	if f.GetMetaFilter() != nil {
+		params.MetaFilter = f.GetMetaFilter().AsMap()
+	}
+
+	var filters []entity.Filter
+	params.Tag, filters = multiValueFilter("tag", f.GetTags(), filters)
+	params.Stage, filters = multiValueFilter("stage", f.GetStages(), filters)
+
+	for _, expr := range f.GetPredicates() {
+		filter, err := entity.ParseFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	params.Filters = filters
+
+	return params, nil
+}
+
+func multiValueFilter(field string, values []string, filters []entity.Filter) (string, []entity.Filter) {
+	switch len(values) {
+	case 0:
+		return "", filters
+	case 1:
+		return values[0], filters
+	default:
+		return "", append(filters, entity.Filter{Field: field, Op: entity.FilterIn, Values: values})
+	}
+}
+
+func optionalTime(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	v := t.AsTime()
+
+	return &v
+}
+
+func toProtoTimestamp(ts *entity.Timestamp) (*timestampv1.Timestamp, error) {
+	out := &timestampv1.Timestamp{
+		Id:         ts.ID.String(),
+		ExternalId: ts.ExternalID,
+		Timestamp:  timestamppb.New(ts.Timestamp),
+		Tag:        string(ts.Tag),
+		Stage:      string(ts.Stage),
+		Version:    int32(ts.Version),
+	}
+
+	if ts.Meta != nil {
+		meta, err := structpb.NewStruct(ts.Meta)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("convert meta: %v", err))
+		}
+		out.Meta = meta
+	}
+
+	if ts.DeletedAt != nil {
+		out.DeletedAt = timestamppb.New(*ts.DeletedAt)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,154 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/middleware"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	timestampv1 "github.com/sdvaanyaa/sla-timestamp-api/pkg/api/timestamp/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+)
+
+// methodScopes is the scope each method requires, as for the matching REST routes. Methods missing here
+// are denied.
+var methodScopes = map[string]entity.Scope{
+	timestampv1.TimestampService_Create_FullMethodName:  entity.ScopeTimestampsWrite,
+	timestampv1.TimestampService_GetByID_FullMethodName: entity.ScopeTimestampsRead,
+	timestampv1.TimestampService_List_FullMethodName:    entity.ScopeTimestampsRead,
+	timestampv1.TimestampService_Delete_FullMethodName:  entity.ScopeTimestampsDelete,
+	timestampv1.TimestampService_Watch_FullMethodName:   entity.ScopeTimestampsRead,
+}
+
+// UnaryInterceptor attaches the caller to the context for the audit log, like middleware.RequestInfo,
+// authenticates it and checks the scope of the method, like middleware.Authenticate and
+// middleware.RequireScope, and logs the call, like middleware.Logging. The request ID and credential are read
+// from the x-request-id and authorization or x-api-key metadata.
+func UnaryInterceptor(auth service.Authenticator, log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = withRequestInfo(ctx)
+
+		ctx, err := authorize(ctx, auth, info.FullMethod)
+
+		var resp any
+		if err == nil {
+			resp, err = handler(ctx, req)
+		}
+		logCall(ctx, log, info.FullMethod, err, time.Since(start))
+
+		return resp, err
+	}
+}
+
+// StreamInterceptor is the UnaryInterceptor of streaming calls.
+func StreamInterceptor(auth service.Authenticator, log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := withRequestInfo(ss.Context())
+
+		ctx, err := authorize(ctx, auth, info.FullMethod)
+		if err == nil {
+			err = handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		}
+		logCall(ctx, log, info.FullMethod, err, time.Since(start))
+
+		return err
+	}
+}
+
+// authorize returns ctx with the caller presenting the credential of the call, or the status error that
+// rejects the call.
+func authorize(ctx context.Context, auth service.Authenticator, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	key := firstValue(md, middleware.HeaderAPIKey)
+	if key == "" {
+		key, _ = strings.CutPrefix(firstValue(md, "authorization"), "Bearer ")
+	}
+	if key == "" {
+		return ctx, statusError(service.ErrUnauthenticated)
+	}
+
+	p, err := auth.Authenticate(ctx, key)
+	if err != nil {
+		return ctx, statusError(err)
+	}
+
+	info := entity.RequestInfoFromContext(ctx)
+	info.Actor = p.Name
+	info.PrincipalID = p.ID
+	ctx = entity.WithPrincipal(entity.WithRequestInfo(ctx, info), p)
+	ctx = entity.WithTenant(ctx, p.Tenant)
+
+	scope, ok := methodScopes[method]
+	if !ok || !p.HasScope(scope) {
+		return ctx, statusError(fmt.Errorf("%w %s", service.ErrForbidden, scope))
+	}
+
+	return ctx, nil
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func withRequestInfo(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := firstValue(md, middleware.HeaderRequestID)
+	if requestID == "" || len(requestID) > middleware.MaxRequestIDLen {
+		requestID = uuid.NewString()
+	}
+
+	actor := firstValue(md, middleware.HeaderActor)
+	if actor == "" {
+		actor = entity.AnonymousActor
+	}
+	if len(actor) > middleware.MaxActorLen {
+		actor = actor[:middleware.MaxActorLen]
+	}
+
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			ip = host
+		}
+	}
+
+	return entity.WithRequestInfo(ctx, entity.RequestInfo{Actor: actor, IP: ip, RequestID: requestID})
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(strings.ToLower(key)); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func logCall(ctx context.Context, log *slog.Logger, method string, err error, duration time.Duration) {
+	info := entity.RequestInfoFromContext(ctx)
+
+	log.Info("gRPC request",
+		slog.String("method", method),
+		slog.String("ip", info.IP),
+		slog.String("request_id", info.RequestID),
+		slog.String("principal_id", info.PrincipalID),
+		slog.String("tenant_id", entity.TenantFromContext(ctx)),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", duration),
+	)
+}
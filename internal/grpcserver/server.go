@@ -0,0 +1,220 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	timestampv1 "github.com/sdvaanyaa/sla-timestamp-api/pkg/api/timestamp/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// DefaultListLimit is the page size of a List request without a limit, as for the REST API.
+const DefaultListLimit = 10
+
+// kindCodes maps the error kinds to gRPC codes. A version conflict is Aborted, which tells the client to
+// retry the read-modify-write cycle, and a rejected stage transition is FailedPrecondition.
+var kindCodes = map[apperr.Kind]codes.Code{
+	apperr.KindInvalid:            codes.InvalidArgument,
+	apperr.KindNotFound:           codes.NotFound,
+	apperr.KindConflict:           codes.AlreadyExists,
+	apperr.KindPreconditionFailed: codes.Aborted,
+	apperr.KindUnprocessable:      codes.FailedPrecondition,
+	apperr.KindTooManyRequests:    codes.ResourceExhausted,
+	apperr.KindUnavailable:        codes.Unavailable,
+	apperr.KindUnauthenticated:    codes.Unauthenticated,
+	apperr.KindForbidden:          codes.PermissionDenied,
+}
+
+type TimestampServer struct {
+	timestampv1.UnimplementedTimestampServiceServer
+
+	svc       service.TimestampService
+	streamSvc service.StreamService
+}
+
+// New registers the timestamp API on srv, backed by the same services as the REST handlers.
+func New(srv *grpc.Server, svc service.TimestampService, streamSvc service.StreamService) {
+	timestampv1.RegisterTimestampServiceServer(srv, &TimestampServer{svc: svc, streamSvc: streamSvc})
+}
+
+func (s *TimestampServer) Create(
+	ctx context.Context,
+	req *timestampv1.CreateRequest,
+) (*timestampv1.CreateResponse, error) {
+	id, err := s.svc.Create(ctx, fromCreateRequest(req))
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		st := status.New(codes.AlreadyExists, err.Error())
+		if detailed, detailErr := st.WithDetails(&errdetails.ResourceInfo{
+			ResourceType: "timestamp",
+			ResourceName: id.String(),
+		}); detailErr == nil {
+			st = detailed
+		}
+		return nil, st.Err()
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &timestampv1.CreateResponse{Id: id.String()}, nil
+}
+
+func (s *TimestampServer) GetByID(
+	ctx context.Context,
+	req *timestampv1.GetByIDRequest,
+) (*timestampv1.Timestamp, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid ID")
+	}
+
+	ts, err := s.svc.GetByID(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toProtoTimestamp(ts)
+}
+
+func (s *TimestampServer) List(ctx context.Context, req *timestampv1.ListRequest) (*timestampv1.ListResponse, error) {
+	params, err := fromListRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	page, err := s.svc.List(ctx, params)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &timestampv1.ListResponse{
+		Items:      make([]*timestampv1.Timestamp, len(page.Items)),
+		Total:      page.Total,
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
+	}
+	for i, ts := range page.Items {
+		if resp.Items[i], err = toProtoTimestamp(ts); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *TimestampServer) Delete(ctx context.Context, req *timestampv1.DeleteRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid ID")
+	}
+
+	if err = s.svc.Delete(ctx, id); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Watch sends events until the client cancels the call or the subscription is closed, in which case the
+// client reconnects with the ID of the last event it received.
+func (s *TimestampServer) Watch(
+	req *timestampv1.WatchRequest,
+	stream grpc.ServerStreamingServer[timestampv1.WatchEvent],
+) error {
+	params, err := fromFilter(req.GetFilter())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sub, err := s.streamSvc.Subscribe(stream.Context(), params, req.GetLastEventId())
+	if err != nil {
+		return statusError(err)
+	}
+	defer sub.Close()
+
+	if err = sendBacklog(stream, sub); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-sub.Events:
+			if !ok {
+				return status.Error(codes.Unavailable, "subscription closed, reconnect with last_event_id")
+			}
+			if err = sendEvent(stream, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendBacklog sends the reset and replayed events the subscription starts with.
+func sendBacklog(stream grpc.ServerStreamingServer[timestampv1.WatchEvent], sub *service.Subscription) error {
+	if sub.Reset {
+		if err := stream.Send(&timestampv1.WatchEvent{Action: timestampv1.WatchAction_WATCH_ACTION_RESET}); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range sub.Replay {
+		if err := sendEvent(stream, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sendEvent(stream grpc.ServerStreamingServer[timestampv1.WatchEvent], event entity.TimestampEvent) error {
+	ts, err := toProtoTimestamp(event.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	action := timestampv1.WatchAction_WATCH_ACTION_CREATE
+	if event.Action == entity.StreamDelete {
+		action = timestampv1.WatchAction_WATCH_ACTION_DELETE
+	}
+
+	return stream.Send(&timestampv1.WatchEvent{Id: event.ID, Action: action, Timestamp: ts})
+}
+
+// statusError maps a service error to the gRPC code of its kind, with the fields that failed validation as
+// details. Internal errors are not described to the client.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	kind, _ := apperr.KindOf(err)
+	if kind == apperr.KindInternal {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	st := status.New(kindCodes[kind], err.Error())
+	if fields := apperr.FieldsOf(err); len(fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+		for i, f := range fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Rule}
+		}
+		if detailed, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); detailErr == nil {
+			st = detailed
+		}
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,197 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	timestampv1 "github.com/sdvaanyaa/sla-timestamp-api/pkg/api/timestamp/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "Invalid Input", err: fmt.Errorf("list: %w", service.ErrInvalidInput), want: codes.InvalidArgument},
+		{name: "Not Found", err: fmt.Errorf("get: %w", repository.ErrNotFound), want: codes.NotFound},
+		{name: "Already Exists", err: repository.ErrAlreadyExists, want: codes.AlreadyExists},
+		{name: "Invalid Transition", err: service.ErrInvalidTransition, want: codes.FailedPrecondition},
+		{name: "Stream Unavailable", err: service.ErrStreamUnavailable, want: codes.Unavailable},
+		{name: "Version Conflict", err: repository.ErrVersionConflict, want: codes.Aborted},
+		{name: "Internal", err: errors.New("query failed"), want: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, status.Code(statusError(tt.err)))
+		})
+	}
+}
+
+func TestFromListRequest(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	metaFilter, err := structpb.NewStruct(map[string]any{"source": "email"})
+	require.NoError(t, err)
+
+	params, err := fromListRequest(&timestampv1.ListRequest{
+		Filter: &timestampv1.Filter{
+			ExternalId:    "INC-1",
+			Tags:          []string{"incident"},
+			Stages:        []string{"created", "resolved"},
+			TimestampFrom: timestamppb.New(from),
+			MetaFilter:    metaFilter,
+			Predicates:    []string{"meta.severity>=2"},
+		},
+		Sort:    "-external_id",
+		Count:   timestampv1.CountMode_COUNT_MODE_EXACT,
+		Deleted: timestampv1.DeletedMode_DELETED_MODE_INCLUDE,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultListLimit, params.Limit)
+	assert.Equal(t, "INC-1", params.ExternalID)
+	assert.Equal(t, "incident", params.Tag)
+	assert.Empty(t, params.Stage)
+	assert.Equal(t, &from, params.TimestampFrom)
+	assert.Nil(t, params.TimestampTo)
+	assert.Equal(t, map[string]any{"source": "email"}, params.MetaFilter)
+	require.Len(t, params.Filters, 2)
+	assert.Equal(t, entity.Filter{Field: "stage", Op: entity.FilterIn, Values: []string{"created", "resolved"}},
+		params.Filters[0])
+	assert.Equal(t, []entity.SortField{{Field: "external_id", Desc: true}}, params.Sort)
+	assert.Equal(t, entity.CountExact, params.Count)
+	assert.Equal(t, entity.DeletedInclude, params.Deleted)
+
+	_, err = fromListRequest(&timestampv1.ListRequest{Filter: &timestampv1.Filter{Predicates: []string{"bogus"}}})
+	assert.Error(t, err)
+
+	_, err = fromListRequest(&timestampv1.ListRequest{Count: timestampv1.CountMode(42)})
+	assert.Error(t, err)
+}
+
+func TestToProtoTimestamp(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+	ts := &entity.Timestamp{
+		ID:         uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+		ExternalID: "INC-1",
+		Timestamp:  time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageResolved,
+		Meta:       map[string]any{"severity": float64(2), "tags": []any{"db"}},
+		Version:    3,
+		DeletedAt:  &deletedAt,
+	}
+
+	got, err := toProtoTimestamp(ts)
+	require.NoError(t, err)
+
+	assert.Equal(t, ts.ID.String(), got.GetId())
+	assert.Equal(t, ts.Timestamp, got.GetTimestamp().AsTime())
+	assert.Equal(t, "resolved", got.GetStage())
+	assert.Equal(t, ts.Meta, got.GetMeta().AsMap())
+	assert.Equal(t, int32(3), got.GetVersion())
+	assert.Equal(t, deletedAt, got.GetDeletedAt().AsTime())
+
+	created := fromCreateRequest(&timestampv1.CreateRequest{
+		ExternalId: got.GetExternalId(),
+		Timestamp:  got.GetTimestamp(),
+		Tag:        got.GetTag(),
+		Stage:      got.GetStage(),
+		Meta:       got.GetMeta(),
+	})
+	assert.Equal(t, &entity.Timestamp{
+		ExternalID: ts.ExternalID,
+		Timestamp:  ts.Timestamp,
+		Tag:        ts.Tag,
+		Stage:      ts.Stage,
+		Meta:       ts.Meta,
+	}, created)
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	key := service.APIKeyPrefix + "secret"
+	reader := &entity.APIKey{ID: uuid.New(), Name: "dashboard", Scopes: []entity.Scope{entity.ScopeTimestampsRead}}
+
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		method string
+		want   codes.Code
+	}{
+		{
+			name:   "API Key Header",
+			md:     metadata.Pairs("x-api-key", key),
+			method: timestampv1.TimestampService_List_FullMethodName,
+			want:   codes.OK,
+		},
+		{
+			name:   "Bearer Token",
+			md:     metadata.Pairs("authorization", "Bearer "+key),
+			method: timestampv1.TimestampService_GetByID_FullMethodName,
+			want:   codes.OK,
+		},
+		{
+			name:   "Missing Key",
+			method: timestampv1.TimestampService_List_FullMethodName,
+			want:   codes.Unauthenticated,
+		},
+		{
+			name:   "Missing Scope",
+			md:     metadata.Pairs("x-api-key", key),
+			method: timestampv1.TimestampService_Delete_FullMethodName,
+			want:   codes.PermissionDenied,
+		},
+		{
+			name:   "Unknown Method",
+			md:     metadata.Pairs("x-api-key", key),
+			method: "/sla.timestamp.v1.TimestampService/Unknown",
+			want:   codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewAPIKeyStorageMock(ctrl)
+			storageMock.GetByHashMock.Optional().Return(reader, nil)
+
+			keys := service.NewAPIKeyService(storageMock, service.NewValidator())
+			ctx := metadata.NewIncomingContext(t.Context(), tt.md)
+
+			ctx, err := authorize(ctx, keys, tt.method)
+			require.Equal(t, tt.want, status.Code(err))
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, reader.ID.String(), entity.PrincipalFromContext(ctx).ID)
+			assert.Equal(t, "dashboard", entity.RequestInfoFromContext(ctx).Actor)
+			assert.Equal(t, reader.ID.String(), entity.RequestInfoFromContext(ctx).PrincipalID)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+)
+
+type APIKeyHandler struct {
+	svc service.APIKeyService
+}
+
+// Create godoc
+// Create mints an API key.
+//
+//	@Summary		Create an API key
+//	@Description	Mint a key with the given scopes in the tenant of the caller. The key is returned only in this
+//	@Description	response; only its hash and its first characters are stored.
+//	@Tags			api-keys
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		entity.APIKeyRequest	true	"API key body"
+//	@Success		201		{object}	entity.CreatedAPIKey
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		401		{object}	Problem	"Not authenticated"
+//	@Failure		403		{object}	Problem	"Missing keys:admin scope"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/api-keys [post]
+func (h *APIKeyHandler) Create(c *fiber.Ctx) error {
+	var req entity.APIKeyRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	created, err := h.svc.Create(c.UserContext(), req.ToAPIKey())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// List godoc
+// List lists API keys.
+//
+//	@Summary		List API keys
+//	@Description	Retrieve every key of the tenant of the caller, including revoked ones, without the keys themselves
+//	@Tags			api-keys
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{array}		entity.APIKey
+//	@Failure		401	{object}	Problem	"Not authenticated"
+//	@Failure		403	{object}	Problem	"Missing keys:admin scope"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/api-keys [get]
+func (h *APIKeyHandler) List(c *fiber.Ctx) error {
+	list, err := h.svc.List(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Revoke godoc
+// Revoke revokes an API key.
+//
+//	@Summary		Revoke API key
+//	@Description	Revoke a key of the tenant of the caller; requests made with it are rejected from then on
+//	@Tags			api-keys
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"API key ID"
+//	@Success		204	{string}	string	"No content"
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		401	{object}	Problem	"Not authenticated"
+//	@Failure		403	{object}	Problem	"Missing keys:admin scope"
+//	@Failure		404	{object}	Problem	"Not found"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	if err = h.svc.Revoke(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+)
+
+type AuditHandler struct {
+	svc service.AuditService
+}
+
+// History godoc
+// History lists the changes of a timestamp.
+//
+//	@Summary		Get timestamp history
+//	@Description	Every create, update, delete and restore of a timestamp, oldest first, with the caller and the
+//	@Description	state before and after. The history outlives the timestamp itself.
+//	@Tags			audit
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Timestamp ID"
+//	@Success		200	{array}		entity.AuditEntry
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/timestamps/{id}/history [get]
+func (h *AuditHandler) History(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	entries, err := h.svc.History(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(entries)
+}
+
+// List godoc
+// List lists audit entries.
+//
+//	@Summary		List audit entries
+//	@Description	Changes of all timestamps, newest first
+//	@Tags			audit
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			actor	query		string	false	"Actor"
+//	@Param			from	query		string	false	"Changed at or after (RFC3339)"		example(2025-07-01T00:00:00Z)
+//	@Param			to		query		string	false	"Changed at or before (RFC3339)"	example(2025-07-08T00:00:00Z)
+//	@Param			limit	query		int		false	"Limit"								default(100)	maximum(1000)
+//	@Param			offset	query		int		false	"Offset"							default(0)
+//	@Success		200		{array}		entity.AuditEntry
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/audit [get]
+func (h *AuditHandler) List(c *fiber.Ctx) error {
+	q, err := parseAuditQuery(c)
+	if err != nil {
+		return invalidParam(err)
+	}
+
+	entries, err := h.svc.List(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(entries)
+}
+
+func parseAuditQuery(c *fiber.Ctx) (entity.AuditQuery, error) {
+	q := entity.AuditQuery{Actor: c.Query("actor")}
+
+	var err error
+	if q.Limit, err = parseIntQuery(c, "limit", "100", 1); err != nil {
+		return q, err
+	}
+
+	if q.Offset, err = parseIntQuery(c, "offset", "0", 0); err != nil {
+		return q, err
+	}
+
+	if q.From, err = parseTimeQuery(c, "from"); err != nil {
+		return q, err
+	}
+
+	q.To, err = parseTimeQuery(c, "to")
+
+	return q, err
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+)
+
+type CalendarHandler struct {
+	svc service.CalendarService
+}
+
+// Create godoc
+// Create creates a business-hours calendar.
+//
+//	@Summary		Create a calendar
+//	@Description	Create a calendar with working hours per weekday in an IANA timezone
+//	@Tags			calendars
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		entity.CalendarRequest	true	"Calendar body"
+//	@Success		201		{object}	map[string]uuid.UUID
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		409		{object}	Problem	"Calendar already exists"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/calendars [post]
+func (h *CalendarHandler) Create(c *fiber.Ctx) error {
+	var req entity.CalendarRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	id, err := h.svc.Create(c.UserContext(), req.ToCalendar())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": id.String()})
+}
+
+// GetByID godoc
+// GetByID gets a calendar by ID.
+//
+//	@Summary		Get calendar by ID
+//	@Description	Retrieve a calendar with its holidays
+//	@Tags			calendars
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Calendar ID"
+//	@Success		200	{object}	entity.Calendar
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		404	{object}	Problem	"Not found"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/calendars/{id} [get]
+func (h *CalendarHandler) GetByID(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	cal, err := h.svc.GetByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(cal)
+}
+
+// List godoc
+// List lists calendars.
+//
+//	@Summary		List calendars
+//	@Description	Retrieve all calendars without their holidays
+//	@Tags			calendars
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{array}		entity.Calendar
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/calendars [get]
+func (h *CalendarHandler) List(c *fiber.Ctx) error {
+	list, err := h.svc.List(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	if list == nil {
+		list = []*entity.Calendar{}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Update godoc
+// Update replaces a calendar.
+//
+//	@Summary		Update calendar
+//	@Description	Replace the name, timezone and working hours of a calendar
+//	@Tags			calendars
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Param			id		path		string					true	"Calendar ID"
+//	@Param			body	body		entity.CalendarRequest	true	"Calendar body"
+//	@Success		204		{string}	string					"No content"
+//	@Failure		400		{object}	Problem					"Invalid input"
+//	@Failure		404		{object}	Problem					"Not found"
+//	@Failure		409		{object}	Problem					"Calendar already exists"
+//	@Failure		500		{object}	Problem					"Internal error"
+//	@Router			/calendars/{id} [put]
+func (h *CalendarHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	var req entity.CalendarRequest
+	if err = c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	cal := req.ToCalendar()
+	cal.ID = id
+
+	if err = h.svc.Update(c.UserContext(), cal); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Delete godoc
+// Delete deletes a calendar by ID.
+//
+//	@Summary		Delete calendar
+//	@Description	Delete a calendar and its holidays
+//	@Tags			calendars
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"Calendar ID"
+//	@Success		204	{string}	string	"No content"
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		404	{object}	Problem	"Not found"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/calendars/{id} [delete]
+func (h *CalendarHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	if err = h.svc.Delete(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AddHolidays godoc
+// AddHolidays adds holidays to a calendar.
+//
+//	@Summary		Add holidays
+//	@Description	Add or rename holidays of a calendar by date
+//	@Tags			calendars
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Calendar ID"
+//	@Param			body	body		[]entity.Holiday	true	"Holidays"
+//	@Success		200		{object}	map[string]int
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		404		{object}	Problem	"Not found"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/calendars/{id}/holidays [post]
+func (h *CalendarHandler) AddHolidays(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	var holidays []entity.Holiday
+	if err = c.BodyParser(&holidays); err != nil {
+		return errInvalidJSON
+	}
+
+	added, err := h.svc.AddHolidays(c.UserContext(), id, holidays)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"added": added})
+}
+
+// ImportHolidays godoc
+// ImportHolidays imports holidays from an iCalendar file.
+//
+//	@Summary		Import holidays
+//	@Description	Add every day covered by the events of an .ics file as a holiday
+//	@Tags			calendars
+//	@Security		ApiKeyAuth
+//	@Accept			plain
+//	@Produce		json
+//	@Param			id		path		string	true	"Calendar ID"
+//	@Param			body	body		string	true	"iCalendar (.ics) content"
+//	@Success		200		{object}	map[string]int
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		404		{object}	Problem	"Not found"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/calendars/{id}/holidays/import [post]
+func (h *CalendarHandler) ImportHolidays(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	imported, err := h.svc.ImportHolidays(c.UserContext(), id, bytes.NewReader(c.Body()))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"imported": imported})
+}
+
+// DeleteHoliday godoc
+// DeleteHoliday removes a holiday from a calendar.
+//
+//	@Summary		Delete holiday
+//	@Description	Remove the holiday on the given date
+//	@Tags			calendars
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string	true	"Calendar ID"
+//	@Param			date	path		string	true	"Date (YYYY-MM-DD)"
+//	@Success		204		{string}	string	"No content"
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		404		{object}	Problem	"Not found"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/calendars/{id}/holidays/{date} [delete]
+func (h *CalendarHandler) DeleteHoliday(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	if err = h.svc.DeleteHoliday(c.UserContext(), id, c.Params("date")); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// parseCalendarQuery returns uuid.Nil when no calendar was requested.
+func parseCalendarQuery(c *fiber.Ctx) (uuid.UUID, error) {
+	str := c.Query("calendar")
+	if str == "" {
+		return uuid.Nil, nil
+	}
+
+	id, err := uuid.Parse(str)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid calendar")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+)
+
+type CatalogHandler struct {
+	svc service.CatalogService
+}
+
+// ListTags godoc
+// ListTags lists the tag catalog.
+//
+//	@Summary		List tags
+//	@Description	Retrieve every tag, including deprecated ones
+//	@Tags			catalog
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{array}		entity.CatalogEntry
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/catalog/tags [get]
+func (h *CatalogHandler) ListTags(c *fiber.Ctx) error {
+	return h.list(c, entity.CatalogTags)
+}
+
+// CreateTag godoc
+// CreateTag adds a tag to the catalog.
+//
+//	@Summary		Add a tag
+//	@Description	Add a tag that new timestamps and SLA policies may use
+//	@Description	The catalog is shared by every tenant, so catalog:write is granted only by the apikey command.
+//	@Tags			catalog
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		entity.CatalogEntryRequest	true	"Tag body"
+//	@Success		201		{object}	entity.CatalogEntry
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		409		{object}	Problem	"Tag already exists"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/catalog/tags [post]
+func (h *CatalogHandler) CreateTag(c *fiber.Ctx) error {
+	return h.create(c, entity.CatalogTags)
+}
+
+// UpdateTag godoc
+// UpdateTag describes or deprecates a tag.
+//
+//	@Summary		Update a tag
+//	@Description	Replace the description and deprecation flag of a tag; deprecated tags are rejected for new data
+//	@Tags			catalog
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string						true	"Tag name"
+//	@Param			body	body		entity.CatalogEntryUpdate	true	"Tag update"
+//	@Success		200		{object}	entity.CatalogEntry
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		404		{object}	Problem	"Not found"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/catalog/tags/{name} [put]
+func (h *CatalogHandler) UpdateTag(c *fiber.Ctx) error {
+	return h.update(c, entity.CatalogTags)
+}
+
+// ListStages godoc
+// ListStages lists the stage catalog.
+//
+//	@Summary		List stages
+//	@Description	Retrieve every stage, including deprecated ones
+//	@Tags			catalog
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{array}		entity.CatalogEntry
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/catalog/stages [get]
+func (h *CatalogHandler) ListStages(c *fiber.Ctx) error {
+	return h.list(c, entity.CatalogStages)
+}
+
+// CreateStage godoc
+// CreateStage adds a stage to the catalog.
+//
+//	@Summary		Add a stage
+//	@Description	Add a stage that new timestamps and SLA policies may use
+//	@Description	The catalog is shared by every tenant, so catalog:write is granted only by the apikey command.
+//	@Tags			catalog
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		entity.CatalogEntryRequest	true	"Stage body"
+//	@Success		201		{object}	entity.CatalogEntry
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		409		{object}	Problem	"Stage already exists"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/catalog/stages [post]
+func (h *CatalogHandler) CreateStage(c *fiber.Ctx) error {
+	return h.create(c, entity.CatalogStages)
+}
+
+// UpdateStage godoc
+// UpdateStage describes or deprecates a stage.
+//
+//	@Summary		Update a stage
+//	@Description	Replace the description and deprecation flag of a stage; deprecated stages are rejected for new data
+//	@Tags			catalog
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string						true	"Stage name"
+//	@Param			body	body		entity.CatalogEntryUpdate	true	"Stage update"
+//	@Success		200		{object}	entity.CatalogEntry
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		404		{object}	Problem	"Not found"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/catalog/stages/{name} [put]
+func (h *CatalogHandler) UpdateStage(c *fiber.Ctx) error {
+	return h.update(c, entity.CatalogStages)
+}
+
+func (h *CatalogHandler) list(c *fiber.Ctx, kind entity.CatalogKind) error {
+	list, err := h.svc.List(c.UserContext(), kind)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+func (h *CatalogHandler) create(c *fiber.Ctx, kind entity.CatalogKind) error {
+	var req entity.CatalogEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	e := req.ToEntry()
+	if err := h.svc.Create(c.UserContext(), kind, e); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(e)
+}
+
+func (h *CatalogHandler) update(c *fiber.Ctx, kind entity.CatalogKind) error {
+	var req entity.CatalogEntryUpdate
+	if err := c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	e := req.ToEntry(c.Params("name"))
+	if err := h.svc.Update(c.UserContext(), kind, e); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(e)
+}
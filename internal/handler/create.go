@@ -3,39 +3,70 @@ package handler
 import (
 	"errors"
 	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
-	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
 )
 
+var errInvalidOnConflict = apperr.New(apperr.KindInvalid, "invalid_parameter", "on_conflict must be error or update")
+
 // Create godoc
 // Create creates a new timestamp.
 //
 //	@Summary		Create a timestamp
-//	@Description	Create a new timestamp entry
+//	@Description	Create a new timestamp entry. A retry with the same Idempotency-Key returns the original response.
 //	@Tags			timestamps
+//	@Security		ApiKeyAuth
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		entity.CreateTimestampRequest	true	"Timestamp body"
-//	@Success		201		{object}	map[string]uuid.UUID
-//	@Failure		400		{object}	map[string]string	"Invalid input"
-//	@Failure		500		{object}	map[string]string	"Internal error"
+//	@Param			body			body		entity.CreateTimestampRequest	true	"Timestamp body"
+//	@Param			on_conflict		query		string							false	"Conflict handling"	Enums(error, update)
+//	@Param			Idempotency-Key	header		string							false	"Client-generated key"
+//	@Success		200				{object}	map[string]uuid.UUID			"Updated (on_conflict=update)"
+//	@Success		201				{object}	map[string]uuid.UUID
+//	@Failure		400				{object}	Problem	"Invalid input"
+//	@Failure		409				{object}	Problem	"Already exists, with its id"
+//	@Failure		422				{object}	Problem	"Stage transition not allowed or key reused"
+//	@Failure		500				{object}	Problem	"Internal error"
 //	@Router			/timestamps [post]
 func (h *TimestampHandler) Create(c *fiber.Ctx) error {
 	var req entity.CreateTimestampRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid JSON"})
+		return errInvalidJSON
 	}
 
 	ts := req.ToTimestamp()
-	id, err := h.svc.Create(c.Context(), ts)
+
+	switch c.Query("on_conflict", "error") {
+	case "error":
+	case "update":
+		return h.upsert(c, ts)
+	default:
+		return errInvalidOnConflict
+	}
+
+	id, err := h.svc.Create(c.UserContext(), ts)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return &existsError{err: err, id: id.String()}
+	}
 	if err != nil {
-		status := fiber.StatusInternalServerError
-		if errors.Is(err, service.ErrInvalidInput) {
-			status = fiber.StatusBadRequest
-		}
-		return c.Status(status).JSON(fiber.Map{"error": err})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": id.String()})
 }
+
+func (h *TimestampHandler) upsert(c *fiber.Ctx, ts *entity.Timestamp) error {
+	created, err := h.svc.Upsert(c.UserContext(), ts)
+	if err != nil {
+		return err
+	}
+
+	status := fiber.StatusOK
+	if created {
+		status = fiber.StatusCreated
+	}
+
+	return c.Status(status).JSON(fiber.Map{"id": ts.ID.String()})
+}
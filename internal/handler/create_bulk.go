@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+)
+
+// CreateBulk godoc
+// CreateBulk creates many timestamps at once.
+//
+//	@Summary		Create timestamps in bulk
+//	@Description	Create up to 1000 timestamps in one batch; each item is reported as created, invalid or duplicate
+//	@Tags			timestamps
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		entity.BulkCreateRequest	true	"Timestamps"
+//	@Success		200		{object}	map[string][]entity.BulkItemResult
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/timestamps/bulk [post]
+func (h *TimestampHandler) CreateBulk(c *fiber.Ctx) error {
+	var req entity.BulkCreateRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	items := make([]*entity.Timestamp, len(req.Items))
+	for i := range req.Items {
+		items[i] = req.Items[i].ToTimestamp()
+	}
+
+	results, err := h.svc.CreateBulk(c.UserContext(), items)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"results": results})
+}
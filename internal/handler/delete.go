@@ -9,23 +9,25 @@ import (
 // Delete deletes a timestamp by ID.
 //
 //	@Summary		Delete timestamp
-//	@Description	Delete a timestamp entry by its ID
+//	@Description	Soft-delete a timestamp entry by its ID. It can be restored until the retention purge removes it.
 //	@Tags			timestamps
-//	@Param			id	path		string				true	"Timestamp ID"
-//	@Success		204	{string}	string				"No content"
-//	@Failure		400	{object}	map[string]string	"Invalid ID"
-//	@Failure		500	{object}	map[string]string	"Internal error"
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"Timestamp ID"
+//	@Success		204	{string}	string	"No content"
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		404	{object}	Problem	"Not found"
+//	@Failure		500	{object}	Problem	"Internal error"
 //	@Router			/timestamps/{id} [delete]
 func (h *TimestampHandler) Delete(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid ID"})
+		return errInvalidID
 	}
 
-	err = h.svc.Delete(c.Context(), id)
+	err = h.svc.Delete(c.UserContext(), id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err})
+		return err
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"log/slog"
+	"strings"
+)
+
+const (
+	MIMEApplicationProblemJSON = "application/problem+json"
+
+	internalErrorDetail = "internal error"
+)
+
+var (
+	errInvalidID   = apperr.New(apperr.KindInvalid, "invalid_id", "invalid ID")
+	errInvalidJSON = apperr.New(apperr.KindInvalid, "invalid_json", "invalid JSON")
+)
+
+var kindStatus = map[apperr.Kind]int{
+	apperr.KindInternal:           fiber.StatusInternalServerError,
+	apperr.KindInvalid:            fiber.StatusBadRequest,
+	apperr.KindNotFound:           fiber.StatusNotFound,
+	apperr.KindConflict:           fiber.StatusConflict,
+	apperr.KindPreconditionFailed: fiber.StatusPreconditionFailed,
+	apperr.KindUnprocessable:      fiber.StatusUnprocessableEntity,
+	apperr.KindTooManyRequests:    fiber.StatusTooManyRequests,
+	apperr.KindUnavailable:        fiber.StatusServiceUnavailable,
+	apperr.KindUnauthenticated:    fiber.StatusUnauthorized,
+	apperr.KindForbidden:          fiber.StatusForbidden,
+}
+
+// Problem is an RFC 7807 problem details response. Code is stable and meant for clients to match on;
+// Detail is for humans and may change.
+type Problem struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	Code      string              `json:"code"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []apperr.FieldError `json:"errors,omitempty"`
+	// ID is the existing resource of a conflict, when known.
+	ID string `json:"id,omitempty"`
+}
+
+// existsError is a conflict with an existing resource, whose ID is reported along with the error.
+type existsError struct {
+	err error
+	id  string
+}
+
+func (e *existsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *existsError) Unwrap() error {
+	return e.err
+}
+
+// invalidParam marks a query or path parameter that could not be parsed as invalid input.
+func invalidParam(err error) error {
+	return apperr.Wrap(apperr.KindInvalid, "invalid_parameter", err)
+}
+
+// ErrorHandler writes the error returned by a handler or middleware as a problem. Internal errors are
+// logged and described to the client only as such.
+func ErrorHandler(log *slog.Logger) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		p := NewProblem(err)
+		p.Instance = c.Path()
+		p.RequestID = entity.RequestInfoFromContext(c.UserContext()).RequestID
+
+		if p.Code == apperr.CodeInternal {
+			log.Error("request failed",
+				slog.String("method", c.Method()),
+				slog.String("path", c.Path()),
+				slog.String("request_id", p.RequestID),
+				slog.Any("error", err),
+			)
+		}
+
+		return c.Status(p.Status).JSON(p, MIMEApplicationProblemJSON)
+	}
+}
+
+// NewProblem describes err without the request it failed.
+func NewProblem(err error) Problem {
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		return Problem{
+			Type:   "about:blank",
+			Title:  utils.StatusMessage(fe.Code),
+			Status: fe.Code,
+			Detail: fe.Message,
+			Code:   statusCode(fe.Code),
+		}
+	}
+
+	kind, code := apperr.KindOf(err)
+	status := kindStatus[kind]
+
+	p := Problem{
+		Type:   "about:blank",
+		Title:  utils.StatusMessage(status),
+		Status: status,
+		Detail: internalErrorDetail,
+		Code:   code,
+		Errors: apperr.FieldsOf(err),
+	}
+	if kind != apperr.KindInternal {
+		p.Detail = err.Error()
+	}
+
+	var exists *existsError
+	if errors.As(err, &exists) {
+		p.ID = exists.id
+	}
+
+	return p
+}
+
+// statusCode derives a code from an HTTP status, such as not_found from 404, for the errors of Fiber itself.
+func statusCode(status int) string {
+	text := utils.StatusMessage(status)
+	if text == "" {
+		return apperr.CodeInternal
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
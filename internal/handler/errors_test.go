@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewProblem(t *testing.T) {
+	t.Parallel()
+
+	fields := []apperr.FieldError{{Field: "external_id", Rule: "required"}}
+
+	tests := []struct {
+		name string
+		err  error
+		want Problem
+	}{
+		{
+			name: "Not Found",
+			err:  fmt.Errorf("delete: %w", repository.ErrNotFound),
+			want: Problem{Status: fiber.StatusNotFound, Code: "timestamp_not_found", Detail: "delete: timestamp not found"},
+		},
+		{
+			name: "Already Exists",
+			err:  &existsError{err: repository.ErrAlreadyExists, id: "123e4567-e89b-12d3-a456-426614174000"},
+			want: Problem{
+				Status: fiber.StatusConflict,
+				Code:   "timestamp_exists",
+				Detail: "timestamp already exists",
+				ID:     "123e4567-e89b-12d3-a456-426614174000",
+			},
+		},
+		{
+			name: "Validation",
+			err:  &apperr.ValidationError{Err: service.ErrInvalidInput, Fields: fields},
+			want: Problem{
+				Status: fiber.StatusBadRequest,
+				Code:   "invalid_input",
+				Detail: "invalid input: external_id",
+				Errors: fields,
+			},
+		},
+		{
+			name: "Version Conflict",
+			err:  repository.ErrVersionConflict,
+			want: Problem{
+				Status: fiber.StatusPreconditionFailed,
+				Code:   "version_conflict",
+				Detail: "timestamp version conflict",
+			},
+		},
+		{
+			name: "Invalid Parameter",
+			err:  invalidParam(errors.New("invalid limit")),
+			want: Problem{Status: fiber.StatusBadRequest, Code: "invalid_parameter", Detail: "invalid limit"},
+		},
+		{
+			name: "Fiber",
+			err:  fiber.ErrMethodNotAllowed,
+			want: Problem{Status: fiber.StatusMethodNotAllowed, Code: "method_not_allowed", Detail: "Method Not Allowed"},
+		},
+		{
+			name: "Internal",
+			err:  errors.New("connection refused"),
+			want: Problem{Status: fiber.StatusInternalServerError, Code: apperr.CodeInternal, Detail: "internal error"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := NewProblem(tt.err)
+
+			tt.want.Type = "about:blank"
+			tt.want.Title = got.Title
+			assert.Equal(t, tt.want, got)
+			assert.NotEmpty(t, got.Title)
+		})
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(slog.New(slog.DiscardHandler))})
+	app.Get("/timestamps/:id", func(c *fiber.Ctx) error {
+		c.SetUserContext(entity.WithRequestInfo(c.UserContext(), entity.RequestInfo{RequestID: "req-1"}))
+		return fmt.Errorf("get by id: %w", repository.ErrNotFound)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/timestamps/1", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, MIMEApplicationProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var p Problem
+	require.NoError(t, json.Unmarshal(body, &p))
+	assert.Equal(t, Problem{
+		Type:      "about:blank",
+		Title:     "Not Found",
+		Status:    fiber.StatusNotFound,
+		Detail:    "get by id: timestamp not found",
+		Instance:  "/timestamps/1",
+		Code:      "timestamp_not_found",
+		RequestID: "req-1",
+	}, p)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"iter"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+var errInvalidExportFormat = apperr.New(apperr.KindInvalid, "invalid_parameter", "format must be csv or ndjson")
+
+var csvBaseColumns = []string{"id", "external_id", "timestamp", "tag", "stage"}
+
+// Export godoc
+// Export streams filtered timestamps as CSV or NDJSON.
+//
+//	@Summary		Export timestamps
+//	@Description	Stream every timestamp matching the filters, newest first, without pagination or caching
+//	@Tags			timestamps
+//	@Security		ApiKeyAuth
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Param			format			query		string		false	"Output format"	Enums(csv, ndjson)	default(ndjson)
+//	@Param			meta_columns	query		string		false	"Comma-separated meta keys flattened into CSV columns"
+//	@Param			external_id		query		string		false	"External ID"
+//	@Param			tag				query		string		false	"Tags, comma-separated (see /catalog/tags)"
+//	@Param			stage			query		string		false	"Stages, comma-separated (see /catalog/stages)"
+//	@Param			timestamp_from	query		string		false	"Timestamp from (RFC3339)"
+//	@Param			timestamp_to	query		string		false	"Timestamp to (RFC3339)"
+//	@Param			meta_filter		query		string		false	"Meta filter as JSON"
+//	@Param			filter			query		[]string	false	"Filter predicates, combined with AND"	collectionFormat(multi)
+//	@Success		200				{string}	string
+//	@Failure		400				{object}	Problem	"Invalid input"
+//	@Router			/timestamps/export [get]
+func (h *TimestampHandler) Export(c *fiber.Ctx) error {
+	format := c.Query("format", exportFormatNDJSON)
+	if format != exportFormatCSV && format != exportFormatNDJSON {
+		return errInvalidExportFormat
+	}
+
+	params, err := parseListQueryParams(c)
+	if err != nil {
+		return invalidParam(err)
+	}
+
+	rows, err := h.svc.Export(
+		c.UserContext(),
+		params.ExternalID,
+		params.Tag,
+		params.Stage,
+		params.TimestampFrom,
+		params.TimestampTo,
+		params.MetaFilter,
+		params.Filters,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="timestamps.%s"`, format))
+
+	// The body is written after the handler returns, so failures past this point can only be logged
+	// and end the stream early.
+	if format == exportFormatCSV {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		metaColumns := parseMetaColumns(c.Query("meta_columns"))
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			logExportError(writeCSV(w, rows, metaColumns))
+		})
+	} else {
+		c.Set(fiber.HeaderContentType, "application/x-ndjson")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			logExportError(writeNDJSON(w, rows))
+		})
+	}
+
+	return nil
+}
+
+func parseMetaColumns(str string) []string {
+	var columns []string
+	for _, key := range strings.Split(str, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			columns = append(columns, key)
+		}
+	}
+
+	return columns
+}
+
+// writeCSV writes meta as a single JSON column unless metaColumns picks keys to flatten into columns.
+func writeCSV(w *bufio.Writer, rows iter.Seq2[*entity.Timestamp, error], metaColumns []string) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader(metaColumns)); err != nil {
+		return err
+	}
+
+	for ts, err := range rows {
+		if err != nil {
+			return err
+		}
+
+		if err = cw.Write(csvRecord(ts, metaColumns)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func csvHeader(metaColumns []string) []string {
+	header := append([]string{}, csvBaseColumns...)
+	if len(metaColumns) == 0 {
+		return append(header, "meta")
+	}
+
+	for _, key := range metaColumns {
+		header = append(header, "meta."+key)
+	}
+
+	return header
+}
+
+func csvRecord(ts *entity.Timestamp, metaColumns []string) []string {
+	record := []string{
+		ts.ID.String(),
+		ts.ExternalID,
+		ts.Timestamp.Format(time.RFC3339Nano),
+		string(ts.Tag),
+		string(ts.Stage),
+	}
+	if len(metaColumns) == 0 {
+		return append(record, csvMetaValue(ts.Meta))
+	}
+
+	for _, key := range metaColumns {
+		record = append(record, csvMetaValue(ts.Meta[key]))
+	}
+
+	return record
+}
+
+// csvMetaValue writes strings as they are and any other value as JSON; missing values stay empty.
+func csvMetaValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case map[string]any:
+		if len(val) == 0 {
+			return ""
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+func writeNDJSON(w *bufio.Writer, rows iter.Seq2[*entity.Timestamp, error]) error {
+	enc := json.NewEncoder(w)
+
+	for ts, err := range rows {
+		if err != nil {
+			return err
+		}
+
+		if err = enc.Encode(ts); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+func logExportError(err error) {
+	if err != nil {
+		slog.Error("export timestamps failed", slog.Any("error", err))
+	}
+}
@@ -1,10 +1,8 @@
 package handler
 
 import (
-	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
 )
 
 // GetByID godoc
@@ -13,27 +11,27 @@ import (
 //	@Summary		Get timestamp by ID
 //	@Description	Retrieve a timestamp entry by its ID
 //	@Tags			timestamps
+//	@Security		ApiKeyAuth
 //	@Produce		json
 //	@Param			id	path		string	true	"Timestamp ID"
 //	@Success		200	{object}	entity.Timestamp
-//	@Failure		400	{object}	map[string]string	"Invalid ID"
-//	@Failure		404	{object}	map[string]string	"Not found"
-//	@Failure		500	{object}	map[string]string	"Internal error"
+//	@Header			200	{string}	ETag	"Version to send in If-Match"
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		404	{object}	Problem	"Not found"
+//	@Failure		500	{object}	Problem	"Internal error"
 //	@Router			/timestamps/{id} [get]
 func (h *TimestampHandler) GetByID(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid ID"})
+		return errInvalidID
 	}
 
-	ts, err := h.svc.GetByID(c.Context(), id)
+	ts, err := h.svc.GetByID(c.UserContext(), id)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "timestamp not found"})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err})
+		return err
 	}
 
+	setETag(c, ts)
 	return c.Status(fiber.StatusOK).JSON(ts)
 }
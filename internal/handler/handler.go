@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
 )
 
@@ -9,10 +10,96 @@ type TimestampHandler struct {
 	svc service.TimestampService
 }
 
-func New(app *fiber.App, svc service.TimestampService) {
+// New registers the routes on app, each behind requireScope with the scope it needs.
+func New(
+	app *fiber.App,
+	requireScope func(entity.Scope) fiber.Handler,
+	svc service.TimestampService,
+	policySvc service.PolicyService,
+	calendarSvc service.CalendarService,
+	metricsSvc service.MetricsService,
+	catalogSvc service.CatalogService,
+	auditSvc service.AuditService,
+	streamSvc service.StreamService,
+	webhookSvc service.WebhookService,
+	apiKeySvc service.APIKeyService,
+) {
 	h := &TimestampHandler{svc: svc}
-	app.Post("/timestamps", h.Create)
-	app.Get("timestamps/:id", h.GetByID)
-	app.Get("/timestamps", h.List)
-	app.Delete("/timestamps/:id", h.Delete)
+	ph := &PolicyHandler{svc: policySvc}
+	ch := &CalendarHandler{svc: calendarSvc}
+	mh := &MetricsHandler{svc: metricsSvc}
+	cth := &CatalogHandler{svc: catalogSvc}
+	ah := &AuditHandler{svc: auditSvc}
+	sh := &StreamHandler{svc: streamSvc}
+	wh := &WebhookHandler{svc: webhookSvc}
+	kh := &APIKeyHandler{svc: apiKeySvc}
+
+	readTimestamps := requireScope(entity.ScopeTimestampsRead)
+	writeTimestamps := requireScope(entity.ScopeTimestampsWrite)
+	deleteTimestamps := requireScope(entity.ScopeTimestampsDelete)
+	readSLA := requireScope(entity.ScopeSLARead)
+	writeSLA := requireScope(entity.ScopeSLAWrite)
+	readCalendars := requireScope(entity.ScopeCalendarsRead)
+	writeCalendars := requireScope(entity.ScopeCalendarsWrite)
+	readCatalog := requireScope(entity.ScopeCatalogRead)
+	writeCatalog := requireScope(entity.ScopeCatalogWrite)
+	readWebhooks := requireScope(entity.ScopeWebhooksRead)
+	writeWebhooks := requireScope(entity.ScopeWebhooksWrite)
+	readAudit := requireScope(entity.ScopeAuditRead)
+	adminKeys := requireScope(entity.ScopeKeysAdmin)
+
+	// Static /timestamps/* routes must be registered before timestamps/:id.
+	app.Get("/timestamps/sla", readSLA, ph.Evaluate)
+	app.Get("/timestamps/export", readTimestamps, h.Export)
+	app.Get("/timestamps/stream", readTimestamps, sh.Stream)
+
+	app.Post("/timestamps", writeTimestamps, h.Create)
+	app.Post("/timestamps/bulk", writeTimestamps, h.CreateBulk)
+	app.Get("timestamps/:id", readTimestamps, h.GetByID)
+	app.Get("/timestamps", readTimestamps, h.List)
+	app.Put("/timestamps/:id", writeTimestamps, h.Update)
+	app.Patch("/timestamps/:id", writeTimestamps, h.Patch)
+	app.Delete("/timestamps/:id", deleteTimestamps, h.Delete)
+	app.Post("/timestamps/:id/restore", deleteTimestamps, h.Restore)
+	app.Get("/timestamps/:id/history", readAudit, ah.History)
+
+	app.Get("/entities/:external_id/timeline", readTimestamps, h.Timeline)
+
+	app.Post("/sla/policies", writeSLA, ph.Create)
+	app.Get("/sla/policies", readSLA, ph.List)
+	app.Get("/sla/policies/:id", readSLA, ph.GetByID)
+	app.Put("/sla/policies/:id", writeSLA, ph.Update)
+	app.Delete("/sla/policies/:id", writeSLA, ph.Delete)
+
+	app.Post("/calendars", writeCalendars, ch.Create)
+	app.Get("/calendars", readCalendars, ch.List)
+	app.Get("/calendars/:id", readCalendars, ch.GetByID)
+	app.Put("/calendars/:id", writeCalendars, ch.Update)
+	app.Delete("/calendars/:id", writeCalendars, ch.Delete)
+	app.Post("/calendars/:id/holidays", writeCalendars, ch.AddHolidays)
+	app.Post("/calendars/:id/holidays/import", writeCalendars, ch.ImportHolidays)
+	app.Delete("/calendars/:id/holidays/:date", writeCalendars, ch.DeleteHoliday)
+
+	app.Get("/metrics/sla", readSLA, mh.SLA)
+
+	app.Get("/audit", readAudit, ah.List)
+
+	app.Post("/webhooks", writeWebhooks, wh.Create)
+	app.Get("/webhooks", readWebhooks, wh.List)
+	app.Get("/webhooks/:id", readWebhooks, wh.GetByID)
+	app.Put("/webhooks/:id", writeWebhooks, wh.Update)
+	app.Delete("/webhooks/:id", writeWebhooks, wh.Delete)
+	app.Get("/webhooks/:id/deliveries", readWebhooks, wh.Deliveries)
+	app.Get("/webhooks/:id/attempts", readWebhooks, wh.Attempts)
+
+	app.Get("/catalog/tags", readCatalog, cth.ListTags)
+	app.Post("/catalog/tags", writeCatalog, cth.CreateTag)
+	app.Put("/catalog/tags/:name", writeCatalog, cth.UpdateTag)
+	app.Get("/catalog/stages", readCatalog, cth.ListStages)
+	app.Post("/catalog/stages", writeCatalog, cth.CreateStage)
+	app.Put("/catalog/stages/:name", writeCatalog, cth.UpdateStage)
+
+	app.Post("/api-keys", adminKeys, kh.Create)
+	app.Get("/api-keys", adminKeys, kh.List)
+	app.Delete("/api-keys/:id", adminKeys, kh.Revoke)
 }
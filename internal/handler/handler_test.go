@@ -0,0 +1,438 @@
+package handler_test
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/handler"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/middleware"
+	rmocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	bmocks "github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/mocks"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/cache"
+	cmocks "github.com/sdvaanyaa/sla-timestamp-api/pkg/cache/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testTenant = "acme"
+	testAPIKey = service.APIKeyPrefix + "handler-test-key"
+)
+
+// storages are the mocks behind the services of a test app.
+type storages struct {
+	timestamps *rmocks.TimestampStorageMock
+	policies   *rmocks.PolicyStorageMock
+	calendars  *rmocks.CalendarStorageMock
+	metrics    *rmocks.MetricsStorageMock
+	catalog    *rmocks.CatalogStorageMock
+	audit      *rmocks.AuditStorageMock
+	webhooks   *rmocks.WebhookStorageMock
+	apiKeys    *rmocks.APIKeyStorageMock
+	cache      *cmocks.CacheMock
+}
+
+// newTestApp serves the routes of handler.New behind middleware.Authenticate, which accepts testAPIKey as a
+// key of testTenant with every scope.
+func newTestApp(t *testing.T, prepare func(t *testing.T, s *storages)) *fiber.App {
+	t.Helper()
+
+	ctrl := minimock.NewController(t)
+	s := &storages{
+		timestamps: rmocks.NewTimestampStorageMock(ctrl),
+		policies:   rmocks.NewPolicyStorageMock(ctrl),
+		calendars:  rmocks.NewCalendarStorageMock(ctrl),
+		metrics:    rmocks.NewMetricsStorageMock(ctrl),
+		catalog:    rmocks.NewCatalogStorageMock(ctrl),
+		audit:      rmocks.NewAuditStorageMock(ctrl),
+		webhooks:   rmocks.NewWebhookStorageMock(ctrl),
+		apiKeys:    rmocks.NewAPIKeyStorageMock(ctrl),
+		cache:      cmocks.NewCacheMock(ctrl),
+	}
+	s.apiKeys.GetByHashMock.Expect(minimock.AnyContext, service.HashAPIKey(testAPIKey)).Return(&entity.APIKey{
+		ID:     uuid.New(),
+		Name:   "handler-test",
+		Tenant: testTenant,
+		Scopes: entity.Scopes,
+	}, nil)
+	// The catalog holds the built-in tags and stages.
+	s.catalog.ListMock.Set(func(_ context.Context, kind entity.CatalogKind) ([]*entity.CatalogEntry, error) {
+		names := []string{"created", "acknowledged", "in_progress", "on_hold", "resumed", "resolved", "closed"}
+		if kind == entity.CatalogTags {
+			names = []string{"incident", "sla", "deployment", "maintenance", "alert"}
+		}
+
+		list := make([]*entity.CatalogEntry, len(names))
+		for i, name := range names {
+			list[i] = &entity.CatalogEntry{Name: name}
+		}
+		return list, nil
+	})
+	prepare(t, s)
+
+	val := service.NewValidator()
+	catalogSvc := service.NewCatalogService(s.catalog, val)
+	require.NoError(t, catalogSvc.Refresh(t.Context()))
+	apiKeySvc := service.NewAPIKeyService(s.apiKeys, val)
+
+	app := fiber.New(fiber.Config{ErrorHandler: handler.ErrorHandler(slog.New(slog.DiscardHandler))})
+	app.Use(middleware.Authenticate(apiKeySvc))
+	handler.New(
+		app,
+		middleware.RequireScope,
+		service.New(s.timestamps, val, s.cache, bmocks.NewBrokerMock(ctrl), s.calendars, service.TransitionRules{}),
+		service.NewPolicyService(s.policies, s.timestamps, s.calendars, val),
+		service.NewCalendarService(s.calendars, val),
+		service.NewMetricsService(s.metrics),
+		catalogSvc,
+		service.NewAuditService(s.audit, val),
+		service.NewStreamService(bmocks.NewSubscriberMock(ctrl), val, 1),
+		service.NewWebhookService(s.webhooks, val),
+		apiKeySvc,
+	)
+
+	return app
+}
+
+// assertTenant checks that a storage was called in the tenant of the caller.
+func assertTenant(t *testing.T, ctx context.Context) {
+	t.Helper()
+	assert.Equal(t, testTenant, entity.TenantFromContext(ctx))
+}
+
+func TestNew_Tenant(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	ts := &entity.Timestamp{
+		ID:         id,
+		ExternalID: "INC-1",
+		Timestamp:  time.Date(2025, 7, 13, 15, 0, 0, 0, time.UTC),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+		Version:    1,
+	}
+	webhook := &entity.Webhook{ID: id, URL: "https://example.com/hooks/sla", Secret: "at-least-16-characters"}
+	webhookBody := `{"url":"https://example.com/hooks/sla","secret":"at-least-16-characters"}`
+
+	cacheMiss := func(s *storages) {
+		s.cache.GetMock.Return(cache.ErrCacheMiss)
+		s.cache.SetMock.Return(nil)
+	}
+	getWebhook := func(t *testing.T, s *storages) {
+		s.webhooks.GetByIDMock.Inspect(func(ctx context.Context, _ uuid.UUID) {
+			assertTenant(t, ctx)
+		}).Return(webhook, nil)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		prepare    func(t *testing.T, s *storages)
+		wantStatus int
+	}{
+		{
+			name:   "List Timestamps",
+			method: fiber.MethodGet,
+			path:   "/timestamps",
+			prepare: func(t *testing.T, s *storages) {
+				cacheMiss(s)
+				s.timestamps.ListMock.Inspect(func(ctx context.Context, _ *entity.ListQueryParams) {
+					assertTenant(t, ctx)
+				}).Return([]*entity.Timestamp{ts}, nil, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Get Timestamp",
+			method: fiber.MethodGet,
+			path:   "/timestamps/" + id.String(),
+			prepare: func(t *testing.T, s *storages) {
+				cacheMiss(s)
+				s.timestamps.GetByIDMock.Inspect(func(ctx context.Context, _ uuid.UUID) {
+					assertTenant(t, ctx)
+				}).Return(ts, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Timeline",
+			method: fiber.MethodGet,
+			path:   "/entities/INC-1/timeline",
+			prepare: func(t *testing.T, s *storages) {
+				s.timestamps.ListByExternalIDMock.Inspect(func(ctx context.Context, _ string) {
+					assertTenant(t, ctx)
+				}).Return([]*entity.Timestamp{ts}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Evaluate SLA",
+			method: fiber.MethodGet,
+			path:   "/timestamps/sla?external_id=INC-1",
+			prepare: func(t *testing.T, s *storages) {
+				s.timestamps.ListByExternalIDMock.Inspect(func(ctx context.Context, _ string) {
+					assertTenant(t, ctx)
+				}).Return([]*entity.Timestamp{ts}, nil)
+				s.policies.ListMock.Inspect(func(ctx context.Context, _ string) {
+					assertTenant(t, ctx)
+				}).Return(nil, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "SLA Metrics",
+			method: fiber.MethodGet,
+			path:   "/metrics/sla",
+			prepare: func(t *testing.T, s *storages) {
+				s.metrics.SLAMetricsMock.Inspect(func(ctx context.Context, _ entity.SLAMetricsQuery) {
+					assertTenant(t, ctx)
+				}).Return(nil, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "History",
+			method: fiber.MethodGet,
+			path:   "/timestamps/" + id.String() + "/history",
+			prepare: func(t *testing.T, s *storages) {
+				s.audit.HistoryMock.Inspect(func(ctx context.Context, _ uuid.UUID) {
+					assertTenant(t, ctx)
+				}).Return([]*entity.AuditEntry{}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Audit Log",
+			method: fiber.MethodGet,
+			path:   "/audit",
+			prepare: func(t *testing.T, s *storages) {
+				s.audit.ListMock.Inspect(func(ctx context.Context, _ entity.AuditQuery) {
+					assertTenant(t, ctx)
+				}).Return([]*entity.AuditEntry{}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Create Webhook",
+			method: fiber.MethodPost,
+			path:   "/webhooks",
+			body:   webhookBody,
+			prepare: func(t *testing.T, s *storages) {
+				s.webhooks.CreateMock.Inspect(func(ctx context.Context, _ *entity.Webhook) {
+					assertTenant(t, ctx)
+				}).Return(id, nil)
+			},
+			wantStatus: fiber.StatusCreated,
+		},
+		{
+			name:   "List Webhooks",
+			method: fiber.MethodGet,
+			path:   "/webhooks",
+			prepare: func(t *testing.T, s *storages) {
+				s.webhooks.ListMock.Inspect(func(ctx context.Context) {
+					assertTenant(t, ctx)
+				}).Return([]*entity.Webhook{webhook}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "Get Webhook",
+			method:     fiber.MethodGet,
+			path:       "/webhooks/" + id.String(),
+			prepare:    getWebhook,
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Update Webhook",
+			method: fiber.MethodPut,
+			path:   "/webhooks/" + id.String(),
+			body:   webhookBody,
+			prepare: func(t *testing.T, s *storages) {
+				s.webhooks.UpdateMock.Inspect(func(ctx context.Context, _ *entity.Webhook) {
+					assertTenant(t, ctx)
+				}).Return(nil)
+			},
+			wantStatus: fiber.StatusNoContent,
+		},
+		{
+			name:   "Delete Webhook",
+			method: fiber.MethodDelete,
+			path:   "/webhooks/" + id.String(),
+			prepare: func(t *testing.T, s *storages) {
+				s.webhooks.DeleteMock.Inspect(func(ctx context.Context, _ uuid.UUID) {
+					assertTenant(t, ctx)
+				}).Return(nil)
+			},
+			wantStatus: fiber.StatusNoContent,
+		},
+		{
+			name:   "Webhook Deliveries",
+			method: fiber.MethodGet,
+			path:   "/webhooks/" + id.String() + "/deliveries",
+			prepare: func(t *testing.T, s *storages) {
+				getWebhook(t, s)
+				s.webhooks.ListDeliveriesMock.Inspect(
+					func(ctx context.Context, _ uuid.UUID, _ entity.WebhookDeliveryQuery) {
+						assertTenant(t, ctx)
+					},
+				).Return([]*entity.WebhookDelivery{}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Webhook Attempts",
+			method: fiber.MethodGet,
+			path:   "/webhooks/" + id.String() + "/attempts",
+			prepare: func(t *testing.T, s *storages) {
+				getWebhook(t, s)
+				s.webhooks.ListAttemptsMock.Inspect(
+					func(ctx context.Context, _ uuid.UUID, _ entity.WebhookDeliveryQuery) {
+						assertTenant(t, ctx)
+					},
+				).Return([]*entity.WebhookAttempt{}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Create Policy",
+			method: fiber.MethodPost,
+			path:   "/sla/policies",
+			body:   `{"name":"ack","tag":"incident","start_stage":"created","end_stage":"acknowledged","target":"15m"}`,
+			prepare: func(t *testing.T, s *storages) {
+				s.policies.CreateMock.Inspect(func(ctx context.Context, _ *entity.SLAPolicy) {
+					assertTenant(t, ctx)
+				}).Return(id, nil)
+			},
+			wantStatus: fiber.StatusCreated,
+		},
+		{
+			name:   "List Policies",
+			method: fiber.MethodGet,
+			path:   "/sla/policies",
+			prepare: func(t *testing.T, s *storages) {
+				s.policies.ListMock.Inspect(func(ctx context.Context, _ string) {
+					assertTenant(t, ctx)
+				}).Return(nil, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Delete Policy",
+			method: fiber.MethodDelete,
+			path:   "/sla/policies/" + id.String(),
+			prepare: func(t *testing.T, s *storages) {
+				s.policies.DeleteMock.Inspect(func(ctx context.Context, _ uuid.UUID) {
+					assertTenant(t, ctx)
+				}).Return(nil)
+			},
+			wantStatus: fiber.StatusNoContent,
+		},
+		{
+			name:   "Create Calendar",
+			method: fiber.MethodPost,
+			path:   "/calendars",
+			body:   `{"name":"eu-support","timezone":"Europe/Berlin"}`,
+			prepare: func(t *testing.T, s *storages) {
+				s.calendars.CreateMock.Inspect(func(ctx context.Context, _ *entity.Calendar) {
+					assertTenant(t, ctx)
+				}).Return(id, nil)
+			},
+			wantStatus: fiber.StatusCreated,
+		},
+		{
+			name:   "Get Calendar",
+			method: fiber.MethodGet,
+			path:   "/calendars/" + id.String(),
+			prepare: func(t *testing.T, s *storages) {
+				s.calendars.GetByIDMock.Inspect(func(ctx context.Context, _ uuid.UUID) {
+					assertTenant(t, ctx)
+				}).Return(&entity.Calendar{ID: id, Name: "eu-support", Timezone: "Europe/Berlin"}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Add Holidays",
+			method: fiber.MethodPost,
+			path:   "/calendars/" + id.String() + "/holidays",
+			body:   `[{"date":"2025-12-25","name":"Christmas Day"}]`,
+			prepare: func(t *testing.T, s *storages) {
+				s.calendars.AddHolidaysMock.Inspect(func(ctx context.Context, _ uuid.UUID, _ []entity.Holiday) {
+					assertTenant(t, ctx)
+				}).Return(1, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Create API Key",
+			method: fiber.MethodPost,
+			path:   "/api-keys",
+			body:   `{"name":"incident-bot","scopes":["timestamps:read"]}`,
+			prepare: func(t *testing.T, s *storages) {
+				s.apiKeys.CreateMock.Inspect(func(ctx context.Context, k *entity.APIKey, _ string) {
+					assertTenant(t, ctx)
+					assert.Equal(t, testTenant, k.Tenant)
+				}).Return(nil)
+			},
+			wantStatus: fiber.StatusCreated,
+		},
+		{
+			name:       "Create API Key With Cross-Tenant Scope",
+			method:     fiber.MethodPost,
+			path:       "/api-keys",
+			body:       `{"name":"catalog-bot","scopes":["catalog:write"]}`,
+			prepare:    func(*testing.T, *storages) {},
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:   "List API Keys",
+			method: fiber.MethodGet,
+			path:   "/api-keys",
+			prepare: func(t *testing.T, s *storages) {
+				s.apiKeys.ListMock.Expect(minimock.AnyContext, testTenant).Return([]*entity.APIKey{}, nil)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Revoke API Key",
+			method: fiber.MethodDelete,
+			path:   "/api-keys/" + id.String(),
+			prepare: func(t *testing.T, s *storages) {
+				s.apiKeys.RevokeMock.Expect(minimock.AnyContext, testTenant, id).Return(nil)
+			},
+			wantStatus: fiber.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := newTestApp(t, tt.prepare)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(middleware.HeaderAPIKey, testAPIKey)
+			if tt.body != "" {
+				req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode, string(body))
+		})
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
-	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"maps"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,47 +18,142 @@ import (
 // List lists timestamps with pagination.
 //
 //	@Summary		List timestamps
-//	@Description	Retrieve a list of timestamps with optional pagination
+//	@Description	Retrieve timestamps, newest first. With envelope=true or a cursor (empty for the first page) the
+//	@Description	result is wrapped in entity.ListPage; otherwise a bare array is returned. The Link header points
+//	@Description	to the next and previous pages either way. Each filter is a predicate such as stage!=closed,
+//	@Description	external_id^=INC-, external_id ilike %db%, meta.severity>=2, meta.region in (eu,us) or
+//	@Description	meta.owner exists.
 //	@Tags			timestamps
+//	@Security		ApiKeyAuth
 //	@Produce		json
-//	@Param			limit			query		int		false	"Limit"		default(10)
-//	@Param			offset			query		int		false	"Offset"	default(0)
-//	@Param			external_id		query		string	false	"External ID"
-//	@Param			tag				query		string	false	"Tag"						Enums(incident, sla, deployment, maintenance, alert)
-//	@Param			stage			query		string	false	"Stage"						Enums(created, acknowledged, in_progress, resolved, closed)
-//	@Param			timestamp_from	query		string	false	"Timestamp from (RFC3339)"	example(2025-07-10T00:00:00Z)
-//	@Param			timestamp_to	query		string	false	"Timestamp to (RFC3339)"	example(2025-07-13T00:00:00Z)
-//	@Param			meta_filter		query		string	false	"Meta filter as JSON"		example({"source":"email"})
-//	@Success		200				{array}		entity.Timestamp
-//	@Failure		400				{object}	map[string]string	"Invalid input"
-//	@Failure		500				{object}	map[string]string	"Internal error"
+//	@Param			limit			query		int					false	"Limit"		default(10)
+//	@Param			offset			query		int					false	"Offset"	default(0)
+//	@Param			cursor			query		string				false	"next_cursor of the previous page"
+//	@Param			envelope		query		bool				false	"Wrap the result in entity.ListPage"
+//	@Param			count			query		string				false	"How to compute total"			Enums(none, exact, estimated)
+//	@Param			sort			query		string				false	"Sort fields, - for descending"	example(timestamp,-external_id)
+//	@Param			fields			query		string				false	"Fields to return"				example(id,external_id,stage)
+//	@Param			external_id		query		string				false	"External ID"
+//	@Param			tag				query		string				false	"Tags, comma-separated (see /catalog/tags)"
+//	@Param			stage			query		string				false	"Stages, comma-separated (see /catalog/stages)"
+//	@Param			timestamp_from	query		string				false	"Timestamp from (RFC3339)"				example(2025-07-10T00:00:00Z)
+//	@Param			timestamp_to	query		string				false	"Timestamp to (RFC3339)"				example(2025-07-13T00:00:00Z)
+//	@Param			meta_filter		query		string				false	"Meta filter as JSON"					example({"source":"email"})
+//	@Param			filter			query		[]string			false	"Filter predicates, combined with AND"	collectionFormat(multi)
+//	@Param			deleted			query		string				false	"Soft-deleted timestamps"				Enums(exclude, include, only)	default(exclude)
+//	@Success		200				{array}		entity.Timestamp	"Bare array, or entity.ListPage"
+//	@Header			200				{string}	Link				"RFC 8288 next and prev links"
+//	@Failure		400				{object}	Problem				"Invalid input"
+//	@Failure		500				{object}	Problem				"Internal error"
 //	@Router			/timestamps [get]
 func (h *TimestampHandler) List(c *fiber.Ctx) error {
 	params, err := parseListQueryParams(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err})
+		return invalidParam(err)
 	}
 
-	list, err := h.svc.List(
-		c.Context(),
-		params.Limit,
-		params.Offset,
-		params.ExternalID,
-		params.Tag,
-		params.Stage,
-		params.TimestampFrom,
-		params.TimestampTo,
-		params.MetaFilter,
-	)
+	envelope, err := strconv.ParseBool(c.Query("envelope", "false"))
+	if err != nil {
+		return invalidParam(errors.New("invalid envelope"))
+	}
 
+	page, err := h.svc.List(c.UserContext(), params)
 	if err != nil {
-		if errors.Is(err, service.ErrInvalidInput) {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err})
+		return err
+	}
+
+	// The page may come from the cache, so the links are set on a copy.
+	linked := *page
+	setPageLinks(c, &linked)
+
+	wrap := envelope || c.Request().URI().QueryArgs().Has("cursor")
+	if len(params.Fields) == 0 {
+		if wrap {
+			return c.Status(fiber.StatusOK).JSON(linked)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err})
+		return c.Status(fiber.StatusOK).JSON(page.Items)
+	}
+
+	items := make([]map[string]any, len(page.Items))
+	for i, ts := range page.Items {
+		items[i] = projectTimestamp(ts, params.Fields)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(list)
+	if wrap {
+		return c.Status(fiber.StatusOK).JSON(projectedPage{ListPage: linked, Items: items})
+	}
+	return c.Status(fiber.StatusOK).JSON(items)
+}
+
+// projectedPage is a ListPage whose items carry only the requested fields.
+type projectedPage struct {
+	entity.ListPage
+	Items []map[string]any `json:"items"`
+}
+
+func projectTimestamp(ts *entity.Timestamp, fields []string) map[string]any {
+	out := make(map[string]any, len(fields))
+	for _, field := range fields {
+		switch field {
+		case "id":
+			out[field] = ts.ID
+		case "external_id":
+			out[field] = ts.ExternalID
+		case "timestamp":
+			out[field] = ts.Timestamp
+		case "tag":
+			out[field] = ts.Tag
+		case "stage":
+			out[field] = ts.Stage
+		case "meta":
+			out[field] = ts.Meta
+		case "version":
+			out[field] = ts.Version
+		case "deleted_at":
+			out[field] = ts.DeletedAt
+		}
+	}
+	return out
+}
+
+// setPageLinks fills in Next and Prev and mirrors them in an RFC 8288 Link header. Cursor pagination
+// only moves forward, so it has no prev link.
+func setPageLinks(c *fiber.Ctx, page *entity.ListPage) {
+	query, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return
+	}
+
+	link := func(set func(url.Values)) string {
+		q := maps.Clone(query)
+		set(q)
+		return c.Path() + "?" + q.Encode()
+	}
+
+	cursorMode := query.Has("cursor")
+
+	switch {
+	case !page.HasMore:
+	case cursorMode:
+		page.Next = link(func(q url.Values) { q.Set("cursor", page.NextCursor) })
+	default:
+		page.Next = link(func(q url.Values) { q.Set("offset", strconv.Itoa(page.Offset+page.Limit)) })
+	}
+
+	if !cursorMode && page.Offset > 0 {
+		page.Prev = link(func(q url.Values) { q.Set("offset", strconv.Itoa(max(page.Offset-page.Limit, 0))) })
+	}
+
+	var links []string
+	if page.Next != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, page.Next))
+	}
+	if page.Prev != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, page.Prev))
+	}
+	if len(links) > 0 {
+		c.Set(fiber.HeaderLink, strings.Join(links, ", "))
+	}
 }
 
 func parseListQueryParams(c *fiber.Ctx) (*entity.ListQueryParams, error) {
@@ -87,16 +185,74 @@ func parseListQueryParams(c *fiber.Ctx) (*entity.ListQueryParams, error) {
 		}
 	}
 
-	return &entity.ListQueryParams{
+	filters, err := parseFilters(c)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, tagFilter := multiValueFilter("tag", c.Query("tag"))
+	stage, stageFilter := multiValueFilter("stage", c.Query("stage"))
+
+	params := &entity.ListQueryParams{
 		Limit:         limit,
 		Offset:        offset,
 		ExternalID:    c.Query("external_id"),
-		Tag:           c.Query("tag"),
-		Stage:         c.Query("stage"),
+		Tag:           tag,
+		Stage:         stage,
 		TimestampFrom: timestampFrom,
 		TimestampTo:   timestampTo,
 		MetaFilter:    metaFilter,
-	}, nil
+		Filters:       slices.Concat(tagFilter, stageFilter, filters),
+		Count:         entity.CountMode(c.Query("count", string(entity.CountNone))),
+		Deleted:       entity.DeletedMode(c.Query("deleted", string(entity.DeletedExclude))),
+	}
+
+	if err = parseListShape(c, params); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// parseFilters parses every filter query parameter; the predicates are combined with AND.
+func parseFilters(c *fiber.Ctx) ([]entity.Filter, error) {
+	var filters []entity.Filter
+	for _, expr := range c.Context().QueryArgs().PeekMulti("filter") {
+		f, err := entity.ParseFilter(string(expr))
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// multiValueFilter turns a comma-separated value of a simple parameter, as in tag=incident,alert, into an
+// "in" filter. A single value is returned as is.
+func multiValueFilter(field, value string) (string, []entity.Filter) {
+	if !strings.Contains(value, ",") {
+		return value, nil
+	}
+	return "", []entity.Filter{{Field: field, Op: entity.FilterIn, Values: strings.Split(value, ",")}}
+}
+
+// parseListShape reads the parameters that shape the result rather than filter it: cursor, sort and fields.
+func parseListShape(c *fiber.Ctx, params *entity.ListQueryParams) error {
+	var err error
+
+	if token := c.Query("cursor"); token != "" {
+		if params.Cursor, err = entity.ParseCursor(token); err != nil {
+			return err
+		}
+	}
+
+	if params.Sort, err = entity.ParseSort(c.Query("sort")); err != nil {
+		return err
+	}
+
+	params.Fields, err = entity.ParseFields(c.Query("fields"))
+
+	return err
 }
 
 func parseIntQuery(c *fiber.Ctx, key, defaultVal string, min int) (int, error) {
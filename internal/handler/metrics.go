@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"strings"
+)
+
+const metaGroupPrefix = "meta."
+
+type MetricsHandler struct {
+	svc service.MetricsService
+}
+
+// SLA godoc
+// SLA reports MTTA and MTTR percentiles.
+//
+//	@Summary		Get SLA metrics
+//	@Description	Mean, p50, p90 and p99 time-to-acknowledge and time-to-resolve of entities created in the window
+//	@Tags			metrics
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			timestamp_from	query		string	false	"Timestamp from (RFC3339)"	example(2025-07-01T00:00:00Z)
+//	@Param			timestamp_to	query		string	false	"Timestamp to (RFC3339)"	example(2025-07-08T00:00:00Z)
+//	@Param			group_by		query		string	false	"Meta key to group by"		example(meta.service)
+//	@Success		200				{object}	entity.SLAMetrics
+//	@Failure		400				{object}	Problem	"Invalid input"
+//	@Failure		500				{object}	Problem	"Internal error"
+//	@Router			/metrics/sla [get]
+func (h *MetricsHandler) SLA(c *fiber.Ctx) error {
+	q, err := parseMetricsQuery(c)
+	if err != nil {
+		return invalidParam(err)
+	}
+
+	metrics, err := h.svc.SLA(c.UserContext(), q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(metrics)
+}
+
+func parseMetricsQuery(c *fiber.Ctx) (entity.SLAMetricsQuery, error) {
+	var (
+		q   entity.SLAMetricsQuery
+		err error
+	)
+
+	if q.TimestampFrom, err = parseTimeQuery(c, "timestamp_from"); err != nil {
+		return q, err
+	}
+
+	if q.TimestampTo, err = parseTimeQuery(c, "timestamp_to"); err != nil {
+		return q, err
+	}
+
+	if groupBy := c.Query("group_by"); groupBy != "" {
+		key, ok := strings.CutPrefix(groupBy, metaGroupPrefix)
+		if !ok || key == "" {
+			return q, errors.New("group_by must be a meta key such as meta.service")
+		}
+		q.GroupBy = key
+	}
+
+	return q, nil
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+)
+
+type PolicyHandler struct {
+	svc service.PolicyService
+}
+
+// Create godoc
+// Create creates a new SLA policy.
+//
+//	@Summary		Create an SLA policy
+//	@Description	Create a stage-to-stage SLA target for a tag
+//	@Tags			sla
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		entity.SLAPolicyRequest	true	"Policy body"
+//	@Success		201		{object}	map[string]uuid.UUID
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		409		{object}	Problem	"Policy already exists"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/sla/policies [post]
+func (h *PolicyHandler) Create(c *fiber.Ctx) error {
+	var req entity.SLAPolicyRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	id, err := h.svc.Create(c.UserContext(), req.ToPolicy())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": id.String()})
+}
+
+// GetByID godoc
+// GetByID gets an SLA policy by ID.
+//
+//	@Summary		Get SLA policy by ID
+//	@Description	Retrieve an SLA policy by its ID
+//	@Tags			sla
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Policy ID"
+//	@Success		200	{object}	entity.SLAPolicy
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		404	{object}	Problem	"Not found"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/sla/policies/{id} [get]
+func (h *PolicyHandler) GetByID(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	p, err := h.svc.GetByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(p)
+}
+
+// List godoc
+// List lists SLA policies.
+//
+//	@Summary		List SLA policies
+//	@Description	Retrieve SLA policies, optionally filtered by tag
+//	@Tags			sla
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			tag	query		string	false	"Tag (see /catalog/tags)"
+//	@Success		200	{array}		entity.SLAPolicy
+//	@Failure		400	{object}	Problem	"Invalid input"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/sla/policies [get]
+func (h *PolicyHandler) List(c *fiber.Ctx) error {
+	list, err := h.svc.List(c.UserContext(), c.Query("tag"))
+	if err != nil {
+		return err
+	}
+
+	if list == nil {
+		list = []*entity.SLAPolicy{}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Update godoc
+// Update replaces an SLA policy.
+//
+//	@Summary		Update SLA policy
+//	@Description	Replace an SLA policy by its ID
+//	@Tags			sla
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Param			id		path		string					true	"Policy ID"
+//	@Param			body	body		entity.SLAPolicyRequest	true	"Policy body"
+//	@Success		204		{string}	string					"No content"
+//	@Failure		400		{object}	Problem					"Invalid input"
+//	@Failure		404		{object}	Problem					"Not found"
+//	@Failure		409		{object}	Problem					"Policy already exists"
+//	@Failure		500		{object}	Problem					"Internal error"
+//	@Router			/sla/policies/{id} [put]
+func (h *PolicyHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	var req entity.SLAPolicyRequest
+	if err = c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	p := req.ToPolicy()
+	p.ID = id
+
+	if err = h.svc.Update(c.UserContext(), p); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Delete godoc
+// Delete deletes an SLA policy by ID.
+//
+//	@Summary		Delete SLA policy
+//	@Description	Delete an SLA policy by its ID
+//	@Tags			sla
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"Policy ID"
+//	@Success		204	{string}	string	"No content"
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		404	{object}	Problem	"Not found"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/sla/policies/{id} [delete]
+func (h *PolicyHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	if err = h.svc.Delete(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Evaluate godoc
+// Evaluate evaluates SLA policies for an entity.
+//
+//	@Summary		Evaluate SLA
+//	@Description	Evaluate every SLA policy for the entity's tags as met, breached or pending
+//	@Tags			sla
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			external_id	query		string	true	"External ID"
+//	@Param			calendar	query		string	false	"Calendar ID to measure targets in business time"
+//	@Success		200			{object}	entity.SLAEvaluation
+//	@Failure		400			{object}	Problem	"Invalid input"
+//	@Failure		404			{object}	Problem	"Not found"
+//	@Failure		500			{object}	Problem	"Internal error"
+//	@Router			/timestamps/sla [get]
+func (h *PolicyHandler) Evaluate(c *fiber.Ctx) error {
+	calendarID, err := parseCalendarQuery(c)
+	if err != nil {
+		return invalidParam(err)
+	}
+
+	eval, err := h.svc.Evaluate(c.UserContext(), c.Query("external_id"), calendarID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(eval)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+)
+
+var errDeletedNotFound = apperr.New(apperr.KindNotFound, "timestamp_not_found", "deleted timestamp not found")
+
+// Restore godoc
+// Restore undoes the soft delete of a timestamp.
+//
+//	@Summary		Restore timestamp
+//	@Description	Restore a soft-deleted timestamp. Fails with 409 if the same external_id, tag and stage has
+//	@Description	been recorded again since the delete.
+//	@Tags			timestamps
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Timestamp ID"
+//	@Success		200	{object}	entity.Timestamp
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		404	{object}	Problem	"No deleted timestamp with this ID"
+//	@Failure		409	{object}	Problem	"Stage recorded again"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/timestamps/{id}/restore [post]
+func (h *TimestampHandler) Restore(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	ts, err := h.svc.Restore(c.UserContext(), id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return errDeletedNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	setETag(c, ts)
+	return c.Status(fiber.StatusOK).JSON(ts)
+}
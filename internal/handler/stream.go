@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"time"
+)
+
+// streamHeartbeat is how often an idle stream sends a comment, which keeps proxies from closing it and
+// detects clients that went away.
+const streamHeartbeat = 15 * time.Second
+
+type StreamHandler struct {
+	svc service.StreamService
+}
+
+// Stream godoc
+// Stream pushes create and delete events of timestamps as server-sent events.
+//
+//	@Summary		Stream timestamp events
+//	@Description	Server-sent events for every timestamp created or deleted from now on that matches the
+//	@Description	filters, which are those of GET /timestamps. Each event has an id, its action as the event
+//	@Description	name and the timestamp as data. A client reconnecting with Last-Event-ID gets the buffered
+//	@Description	events it missed first; when they are no longer buffered, a reset event tells it to reload
+//	@Description	with GET /timestamps instead.
+//	@Tags			timestamps
+//	@Security		ApiKeyAuth
+//	@Produce		text/event-stream
+//	@Param			Last-Event-ID	header		string		false	"ID of the last event received"
+//	@Param			external_id		query		string		false	"External ID"
+//	@Param			tag				query		string		false	"Tags, comma-separated (see /catalog/tags)"
+//	@Param			stage			query		string		false	"Stages, comma-separated (see /catalog/stages)"
+//	@Param			timestamp_from	query		string		false	"Timestamp from (RFC3339)"
+//	@Param			timestamp_to	query		string		false	"Timestamp to (RFC3339)"
+//	@Param			meta_filter		query		string		false	"Meta filter as JSON"
+//	@Param			filter			query		[]string	false	"Filter predicates, combined with AND"	collectionFormat(multi)
+//	@Success		200				{string}	string
+//	@Failure		400				{object}	Problem	"Invalid input"
+//	@Failure		503				{object}	Problem	"Stream unavailable"
+//	@Router			/timestamps/stream [get]
+func (h *StreamHandler) Stream(c *fiber.Ctx) error {
+	params, err := parseListQueryParams(c)
+	if err != nil {
+		return invalidParam(err)
+	}
+
+	sub, err := h.svc.Subscribe(c.UserContext(), params, c.Get("Last-Event-ID"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The body is written after the handler returns; the stream ends when a write fails because the
+	// client went away, or when the subscription is closed.
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+		_ = writeStream(w, sub)
+	})
+
+	return nil
+}
+
+func writeStream(w *bufio.Writer, sub *service.Subscription) error {
+	if sub.Reset {
+		if _, err := fmt.Fprint(w, "event: reset\ndata: {}\n\n"); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range sub.Replay {
+		if err := writeEvent(w, event); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(w, event); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return err
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+func writeEvent(w *bufio.Writer, event entity.TimestampEvent) error {
+	data, err := json.Marshal(event.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Action, data)
+
+	return err
+}
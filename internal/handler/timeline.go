@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// Timeline godoc
+// Timeline gets the stage timeline of an entity.
+//
+//	@Summary		Get entity timeline
+//	@Description	Retrieve every timestamp of an entity in chronological order with the elapsed time between stages
+//	@Tags			entities
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			external_id	path		string	true	"External ID"
+//	@Param			calendar	query		string	false	"Calendar ID for business-time durations"
+//	@Success		200			{object}	entity.Timeline
+//	@Failure		400			{object}	Problem	"Invalid input"
+//	@Failure		404			{object}	Problem	"Not found"
+//	@Failure		500			{object}	Problem	"Internal error"
+//	@Router			/entities/{external_id}/timeline [get]
+func (h *TimestampHandler) Timeline(c *fiber.Ctx) error {
+	calendarID, err := parseCalendarQuery(c)
+	if err != nil {
+		return invalidParam(err)
+	}
+
+	tl, err := h.svc.Timeline(c.UserContext(), c.Params("external_id"), calendarID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tl)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"strconv"
+	"strings"
+)
+
+var errInvalidIfMatch = apperr.New(apperr.KindPreconditionFailed, "invalid_if_match", "invalid If-Match header")
+
+// Update godoc
+// Update replaces the timestamp and meta of a timestamp.
+//
+//	@Summary		Replace a timestamp
+//	@Description	Replace the timestamp and meta; external_id, tag and stage are immutable
+//	@Tags			timestamps
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string							true	"Timestamp ID"
+//	@Param			If-Match	header		string							false	"ETag from a previous read"
+//	@Param			body		body		entity.UpdateTimestampRequest	true	"Timestamp body"
+//	@Success		200			{object}	entity.Timestamp
+//	@Failure		400			{object}	Problem	"Invalid input"
+//	@Failure		404			{object}	Problem	"Not found"
+//	@Failure		412			{object}	Problem	"Version mismatch"
+//	@Failure		422			{object}	Problem	"Stage transition not allowed"
+//	@Failure		500			{object}	Problem	"Internal error"
+//	@Router			/timestamps/{id} [put]
+func (h *TimestampHandler) Update(c *fiber.Ctx) error {
+	id, version, err := parseUpdateTarget(c)
+	if err != nil {
+		return err
+	}
+
+	var req entity.UpdateTimestampRequest
+	if err = c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	ts, err := h.svc.Update(c.UserContext(), id, &req, version)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, ts)
+	return c.Status(fiber.StatusOK).JSON(ts)
+}
+
+// Patch godoc
+// Patch applies a JSON Merge Patch (RFC 7386) to a timestamp.
+//
+//	@Summary		Patch a timestamp
+//	@Description	Merge-patch the timestamp and meta; null removes a meta key
+//	@Tags			timestamps
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"Timestamp ID"
+//	@Param			If-Match	header		string					false	"ETag from a previous read"
+//	@Param			body		body		entity.TimestampPatch	true	"Merge patch"
+//	@Success		200			{object}	entity.Timestamp
+//	@Failure		400			{object}	Problem	"Invalid input"
+//	@Failure		404			{object}	Problem	"Not found"
+//	@Failure		412			{object}	Problem	"Version mismatch"
+//	@Failure		422			{object}	Problem	"Stage transition not allowed"
+//	@Failure		500			{object}	Problem	"Internal error"
+//	@Router			/timestamps/{id} [patch]
+func (h *TimestampHandler) Patch(c *fiber.Ctx) error {
+	id, version, err := parseUpdateTarget(c)
+	if err != nil {
+		return err
+	}
+
+	// The body is decoded directly so that application/merge-patch+json is accepted too.
+	var patch entity.TimestampPatch
+	if err = json.Unmarshal(c.Body(), &patch); err != nil {
+		if errors.Is(err, entity.ErrImmutableField) {
+			return err
+		}
+		return errInvalidJSON
+	}
+
+	ts, err := h.svc.Patch(c.UserContext(), id, &patch, version)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, ts)
+	return c.Status(fiber.StatusOK).JSON(ts)
+}
+
+// parseUpdateTarget reads the timestamp ID and the If-Match version; version is 0 without If-Match or
+// with If-Match: *.
+func parseUpdateTarget(c *fiber.Ctx) (uuid.UUID, int, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, 0, errInvalidID
+	}
+
+	ifMatch := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if ifMatch == "" || ifMatch == "*" {
+		return id, 0, nil
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= 0 {
+		return uuid.Nil, 0, errInvalidIfMatch
+	}
+
+	return id, version, nil
+}
+
+func setETag(c *fiber.Ctx, ts *entity.Timestamp) {
+	c.Set(fiber.HeaderETag, `"`+strconv.Itoa(ts.Version)+`"`)
+}
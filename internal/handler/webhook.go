@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+)
+
+type WebhookHandler struct {
+	svc service.WebhookService
+}
+
+// Create godoc
+// Create registers a webhook.
+//
+//	@Summary		Create a webhook
+//	@Description	Subscribe a URL to the create, update, delete and restore events of the timestamps matching the
+//	@Description	optional tag, stage and meta filter. Each event is POSTed as {id, action, data} with the
+//	@Description	X-Webhook-ID, X-Webhook-Event, X-Webhook-Timestamp and X-Webhook-Signature headers; the
+//	@Description	signature is sha256= followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+//	@Description	secret. Failed deliveries are retried with exponential backoff and finally marked dead.
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		entity.WebhookRequest	true	"Webhook body"
+//	@Success		201		{object}	map[string]uuid.UUID
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/webhooks [post]
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	var req entity.WebhookRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	id, err := h.svc.Create(c.UserContext(), req.ToWebhook())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": id.String()})
+}
+
+// GetByID godoc
+// GetByID gets a webhook by ID.
+//
+//	@Summary		Get webhook by ID
+//	@Description	Retrieve a webhook by its ID; the secret is not returned
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			id	path		string	true	"Webhook ID"
+//	@Success		200	{object}	entity.Webhook
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		404	{object}	Problem	"Not found"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/webhooks/{id} [get]
+func (h *WebhookHandler) GetByID(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	w, err := h.svc.GetByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(w)
+}
+
+// List godoc
+// List lists webhooks.
+//
+//	@Summary		List webhooks
+//	@Description	Retrieve every webhook, oldest first
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{array}		entity.Webhook
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/webhooks [get]
+func (h *WebhookHandler) List(c *fiber.Ctx) error {
+	list, err := h.svc.List(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	if list == nil {
+		list = []*entity.Webhook{}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Update godoc
+// Update replaces a webhook.
+//
+//	@Summary		Update webhook
+//	@Description	Replace the URL, secret and filter of a webhook; pending deliveries keep their payload
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Param			id		path		string					true	"Webhook ID"
+//	@Param			body	body		entity.WebhookRequest	true	"Webhook body"
+//	@Success		204		{string}	string					"No content"
+//	@Failure		400		{object}	Problem					"Invalid input"
+//	@Failure		404		{object}	Problem					"Not found"
+//	@Failure		500		{object}	Problem					"Internal error"
+//	@Router			/webhooks/{id} [put]
+func (h *WebhookHandler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	var req entity.WebhookRequest
+	if err = c.BodyParser(&req); err != nil {
+		return errInvalidJSON
+	}
+
+	w := req.ToWebhook()
+	w.ID = id
+
+	if err = h.svc.Update(c.UserContext(), w); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Delete godoc
+// Delete deletes a webhook by ID.
+//
+//	@Summary		Delete webhook
+//	@Description	Delete a webhook together with its pending deliveries and delivery history
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"Webhook ID"
+//	@Success		204	{string}	string	"No content"
+//	@Failure		400	{object}	Problem	"Invalid ID"
+//	@Failure		404	{object}	Problem	"Not found"
+//	@Failure		500	{object}	Problem	"Internal error"
+//	@Router			/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return errInvalidID
+	}
+
+	if err = h.svc.Delete(c.UserContext(), id); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Deliveries godoc
+// Deliveries lists the deliveries of a webhook.
+//
+//	@Summary		List webhook deliveries
+//	@Description	Deliveries of a webhook, newest first; status=dead lists the dead-lettered ones
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			id		path		string	true	"Webhook ID"
+//	@Param			status	query		string	false	"Delivery status"	Enums(pending, delivered, dead)
+//	@Param			limit	query		int		false	"Limit"				default(100)	maximum(1000)
+//	@Param			offset	query		int		false	"Offset"			default(0)
+//	@Success		200		{array}		entity.WebhookDelivery
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		404		{object}	Problem	"Not found"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) Deliveries(c *fiber.Ctx) error {
+	id, q, err := parseWebhookDeliveryQuery(c)
+	if err != nil {
+		return invalidParam(err)
+	}
+
+	list, err := h.svc.Deliveries(c.UserContext(), id, q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Attempts godoc
+// Attempts lists the delivery attempts of a webhook.
+//
+//	@Summary		List webhook delivery attempts
+//	@Description	Every attempt to deliver an event to a webhook, newest first, with the response status or
+//	@Description	the error. A status selects the attempts of the deliveries in that status.
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			id		path		string	true	"Webhook ID"
+//	@Param			status	query		string	false	"Delivery status"	Enums(pending, delivered, dead)
+//	@Param			limit	query		int		false	"Limit"				default(100)	maximum(1000)
+//	@Param			offset	query		int		false	"Offset"			default(0)
+//	@Success		200		{array}		entity.WebhookAttempt
+//	@Failure		400		{object}	Problem	"Invalid input"
+//	@Failure		404		{object}	Problem	"Not found"
+//	@Failure		500		{object}	Problem	"Internal error"
+//	@Router			/webhooks/{id}/attempts [get]
+func (h *WebhookHandler) Attempts(c *fiber.Ctx) error {
+	id, q, err := parseWebhookDeliveryQuery(c)
+	if err != nil {
+		return invalidParam(err)
+	}
+
+	list, err := h.svc.Attempts(c.UserContext(), id, q)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+func parseWebhookDeliveryQuery(c *fiber.Ctx) (uuid.UUID, entity.WebhookDeliveryQuery, error) {
+	q := entity.WebhookDeliveryQuery{Status: entity.DeliveryStatus(c.Query("status"))}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, q, errInvalidID
+	}
+
+	if q.Limit, err = parseIntQuery(c, "limit", "100", 1); err != nil {
+		return uuid.Nil, q, err
+	}
+
+	q.Offset, err = parseIntQuery(c, "offset", "0", 0)
+
+	return id, q, err
+}
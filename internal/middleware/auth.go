@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"strings"
+)
+
+const HeaderAPIKey = "X-API-Key"
+
+// Authenticate rejects requests without a valid credential, an API key or a JWT, sent as
+// "Authorization: Bearer <credential>" or in X-API-Key. The name of the caller replaces X-Actor as the actor
+// of the audit log, its ID is logged, and the request is scoped to its tenant.
+func Authenticate(auth service.Authenticator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderAPIKey)
+		if key == "" {
+			key, _ = strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		}
+
+		p, err := authenticate(c, auth, key)
+		if err != nil {
+			c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+			return err
+		}
+
+		info := entity.RequestInfoFromContext(c.UserContext())
+		info.Actor = p.Name
+		info.PrincipalID = p.ID
+
+		ctx := entity.WithRequestInfo(c.UserContext(), info)
+		ctx = entity.WithTenant(entity.WithPrincipal(ctx, p), p.Tenant)
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
+
+func authenticate(c *fiber.Ctx, auth service.Authenticator, key string) (*entity.Principal, error) {
+	if key == "" {
+		return nil, service.ErrUnauthenticated
+	}
+	return auth.Authenticate(c.UserContext(), key)
+}
+
+// RequireScope lets a request through only if its caller has been granted scope.
+func RequireScope(scope entity.Scope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		p := entity.PrincipalFromContext(c.UserContext())
+		if p == nil {
+			return service.ErrUnauthenticated
+		}
+
+		if !p.HasScope(scope) {
+			return fmt.Errorf("%w %s", service.ErrForbidden, scope)
+		}
+
+		return c.Next()
+	}
+}
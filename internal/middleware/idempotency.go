@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/handler"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/service"
+	"log/slog"
+	"slices"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	HeaderReplayed       = "Idempotent-Replayed"
+	MaxIdempotencyKeyLen = 255
+)
+
+var errIdempotencyKeyTooLong = apperr.New(apperr.KindInvalid, "invalid_idempotency_key", "Idempotency-Key is too long")
+
+// Idempotency makes POST requests sent with an Idempotency-Key header safe to retry: the first response
+// is stored and returned again for a retry with the same key and the same request. Server errors are not
+// stored, so such a request can be retried with the same key. Keys are scoped to the tenant of the caller.
+func Idempotency(svc service.IdempotencyService, log *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if c.Method() != fiber.MethodPost || key == "" {
+			return c.Next()
+		}
+		if len(key) > MaxIdempotencyKeyLen {
+			return errIdempotencyKeyTooLong
+		}
+		key = entity.TenantFromContext(c.UserContext()) + "/" + key
+
+		rec, err := svc.Begin(c.Context(), key, requestHash(c))
+		if err != nil {
+			return err
+		}
+		if rec != nil {
+			c.Set(HeaderReplayed, "true")
+			c.Set(fiber.HeaderContentType, replayContentType(rec.StatusCode))
+			return c.Status(rec.StatusCode).Send(rec.Response)
+		}
+
+		// An error is written to the response before it is stored, so that a retry gets the same problem.
+		if err = c.Next(); err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		if c.Response().StatusCode() >= fiber.StatusInternalServerError {
+			if releaseErr := svc.Release(c.Context(), key); releaseErr != nil {
+				log.Error("release idempotency key failed", slog.Any("error", releaseErr))
+			}
+			return nil
+		}
+
+		body := slices.Clone(c.Response().Body())
+		if err = svc.Complete(c.Context(), key, c.Response().StatusCode(), body); err != nil {
+			log.Error("store idempotent response failed", slog.Any("error", err))
+		}
+
+		return nil
+	}
+}
+
+// replayContentType is that of a stored response, which is a problem for every error.
+func replayContentType(status int) string {
+	if status >= fiber.StatusBadRequest {
+		return handler.MIMEApplicationProblemJSON
+	}
+	return fiber.MIMEApplicationJSON
+}
+
+// requestHash identifies the request a key was first used for, so that the key cannot be reused for a
+// different one.
+func requestHash(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{0})
+	h.Write([]byte(c.Path()))
+	h.Write([]byte{0})
+	h.Write(c.Request().URI().QueryString())
+	h.Write([]byte{0})
+	h.Write(c.Body())
+
+	return hex.EncodeToString(h.Sum(nil))
+}
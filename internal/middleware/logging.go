@@ -2,24 +2,35 @@ package middleware
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
 	"log/slog"
 	"time"
 )
 
+// Logging logs every request with the status of its response. Errors are written to the response here,
+// rather than after the middleware returns, so that the status logged is the one sent.
 func Logging(log *slog.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
-		err := c.Next()
+		if err := c.Next(); err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
 		duration := time.Since(start)
+		info := entity.RequestInfoFromContext(c.UserContext())
 
 		log.Info("HTTP request",
 			slog.String("method", c.Method()),
 			slog.String("path", c.Path()),
 			slog.String("ip", c.IP()),
+			slog.String("request_id", info.RequestID),
+			slog.String("principal_id", info.PrincipalID),
+			slog.String("tenant_id", entity.TenantFromContext(c.UserContext())),
 			slog.Int("status", c.Response().StatusCode()),
 			slog.Duration("duration", duration),
 		)
 
-		return err
+		return nil
 	}
 }
@@ -3,11 +3,14 @@ package middleware
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
 	"time"
 )
 
 const RequestLimit = 100
 
+var errRateLimited = apperr.New(apperr.KindTooManyRequests, "rate_limited", "rate limit exceeded")
+
 func RateLimiter() fiber.Handler {
 	return limiter.New(limiter.Config{
 		Max:        RequestLimit,
@@ -16,7 +19,7 @@ func RateLimiter() fiber.Handler {
 			return c.IP()
 		},
 		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "rate limit exceeded"})
+			return errRateLimited
 		},
 	})
 }
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+)
+
+const (
+	HeaderRequestID = "X-Request-ID"
+	HeaderActor     = "X-Actor"
+	MaxRequestIDLen = 128
+	MaxActorLen     = 255
+)
+
+// RequestInfo attaches the caller of the request to its user context for the audit log. A request ID sent
+// by the client is kept, otherwise one is generated; either way it is echoed in the response. The actor is
+// whatever the client sends in X-Actor until Authenticate replaces it with the name of the API key.
+func RequestInfo() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(HeaderRequestID)
+		if requestID == "" || len(requestID) > MaxRequestIDLen {
+			requestID = uuid.NewString()
+		}
+		c.Set(HeaderRequestID, requestID)
+
+		actor := c.Get(HeaderActor, entity.AnonymousActor)
+		if len(actor) > MaxActorLen {
+			actor = actor[:MaxActorLen]
+		}
+
+		info := entity.RequestInfo{Actor: actor, IP: c.IP(), RequestID: requestID}
+		c.SetUserContext(entity.WithRequestInfo(c.UserContext(), info))
+
+		return c.Next()
+	}
+}
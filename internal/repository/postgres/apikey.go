@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+)
+
+type pgAPIKeyStorage struct {
+	db *pgdb.Client
+}
+
+func NewAPIKeyStorage(db *pgdb.Client) repository.APIKeyStorage {
+	return &pgAPIKeyStorage{
+		db: db,
+	}
+}
+
+const apiKeyColumns = `id, name, tenant_id, prefix, scopes, created_at, revoked_at`
+
+func (s *pgAPIKeyStorage) Create(ctx context.Context, k *entity.APIKey, hash string) error {
+	query := `
+		INSERT INTO api_keys (name, tenant_id, prefix, key_hash, scopes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	scopes := make([]string, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	err := s.db.QueryRow(ctx, query, k.Name, k.Tenant, k.Prefix, hash, scopes).Scan(&k.ID, &k.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create api key: %w", ErrQueryFailed)
+	}
+
+	return nil
+}
+
+func (s *pgAPIKeyStorage) GetByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	k, err := scanAPIKeyRow(s.db.QueryRow(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get api key by hash: %w", repository.ErrAPIKeyNotFound)
+		}
+		return nil, fmt.Errorf("get api key by hash: %w", ErrQueryFailed)
+	}
+
+	return k, nil
+}
+
+func (s *pgAPIKeyStorage) List(ctx context.Context, tenant string) ([]*entity.APIKey, error) {
+	return s.list(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE tenant_id = $1 ORDER BY created_at, id`, tenant)
+}
+
+func (s *pgAPIKeyStorage) ListAll(ctx context.Context) ([]*entity.APIKey, error) {
+	return s.list(ctx, `SELECT `+apiKeyColumns+` FROM api_keys ORDER BY created_at, id`)
+}
+
+func (s *pgAPIKeyStorage) list(ctx context.Context, query string, args ...any) ([]*entity.APIKey, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", ErrQueryFailed)
+	}
+	defer rows.Close()
+
+	list := []*entity.APIKey{}
+
+	for rows.Next() {
+		k, scanErr := scanAPIKeyRow(rows)
+		if scanErr != nil {
+			return nil, fmt.Errorf("list api keys: %w", ErrScanFailed)
+		}
+
+		list = append(list, k)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("list api keys: %w", ErrRowsFailed)
+	}
+
+	return list, nil
+}
+
+func (s *pgAPIKeyStorage) Revoke(ctx context.Context, tenant string, id uuid.UUID) error {
+	return s.revoke(ctx, `WHERE id = $1 AND tenant_id = $2`, id, tenant)
+}
+
+func (s *pgAPIKeyStorage) RevokeAny(ctx context.Context, id uuid.UUID) error {
+	return s.revoke(ctx, `WHERE id = $1`, id)
+}
+
+func (s *pgAPIKeyStorage) revoke(ctx context.Context, where string, args ...any) error {
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, now()) ` + where
+
+	tag, err := s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", ErrQueryFailed)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("revoke api key: %w", repository.ErrAPIKeyNotFound)
+	}
+
+	return nil
+}
+
+func scanAPIKeyRow(row pgx.Row) (*entity.APIKey, error) {
+	var k entity.APIKey
+	var scopes []string
+	if err := row.Scan(&k.ID, &k.Name, &k.Tenant, &k.Prefix, &scopes, &k.CreatedAt, &k.RevokedAt); err != nil {
+		return nil, err
+	}
+
+	k.Scopes = make([]entity.Scope, len(scopes))
+	for i, scope := range scopes {
+		k.Scopes[i] = entity.Scope(scope)
+	}
+
+	return &k, nil
+}
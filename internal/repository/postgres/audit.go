@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"strings"
+)
+
+type pgAuditStorage struct {
+	db *pgdb.Client
+}
+
+func NewAuditStorage(db *pgdb.Client) repository.AuditStorage {
+	return &pgAuditStorage{
+		db: db,
+	}
+}
+
+// auditChange is one mutated timestamp. before is nil for a create.
+type auditChange struct {
+	id     uuid.UUID
+	before *entity.Timestamp
+	after  *entity.Timestamp
+}
+
+// recordAudit writes an entry for every change in one statement, attributed to the caller in ctx. It is
+// run on the transaction of the mutation so that the two are committed together, and the entries take
+// the tenant of that transaction.
+func recordAudit(ctx context.Context, q pgdb.Querier, op entity.AuditOperation, changes ...auditChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(changes))
+	befores := make([]*string, len(changes))
+	afters := make([]*string, len(changes))
+	for i, change := range changes {
+		ids[i] = change.id.String()
+		befores[i] = auditJSON(change.before)
+		afters[i] = auditJSON(change.after)
+	}
+
+	query := `
+		INSERT INTO timestamp_audit (timestamp_id, operation, actor, source_ip, request_id, before, after)
+		SELECT c.id, $4, $5, $6, $7, c.before, c.after
+		FROM unnest($1::uuid[], $2::jsonb[], $3::jsonb[]) AS c (id, before, after)
+	`
+
+	info := entity.RequestInfoFromContext(ctx)
+	if _, err := q.Exec(ctx, query, ids, befores, afters, op, info.Actor, info.IP, info.RequestID); err != nil {
+		return fmt.Errorf("record audit: %w", ErrQueryFailed)
+	}
+
+	return nil
+}
+
+// auditJSON returns ts in its API representation, or nil for a missing snapshot. Marshalling a Timestamp
+// cannot fail because its meta was itself decoded from JSON.
+func auditJSON(ts *entity.Timestamp) *string {
+	if ts == nil {
+		return nil
+	}
+
+	data, _ := json.Marshal(ts)
+	s := string(data)
+
+	return &s
+}
+
+func (s *pgAuditStorage) History(ctx context.Context, timestampID uuid.UUID) ([]*entity.AuditEntry, error) {
+	query := `
+		SELECT id, timestamp_id, operation, actor, source_ip, request_id, before, after, created_at
+		FROM timestamp_audit
+		WHERE timestamp_id = $1
+		ORDER BY id
+	`
+
+	return s.queryEntries(ctx, "history", query, timestampID)
+}
+
+func (s *pgAuditStorage) List(ctx context.Context, q entity.AuditQuery) ([]*entity.AuditEntry, error) {
+	var where strings.Builder
+	var args []any
+
+	if q.Actor != "" {
+		args = append(args, q.Actor)
+		where.WriteString(fmt.Sprintf(" AND actor = $%d", len(args)))
+	}
+
+	if q.From != nil {
+		args = append(args, *q.From)
+		where.WriteString(fmt.Sprintf(" AND created_at >= $%d", len(args)))
+	}
+
+	if q.To != nil {
+		args = append(args, *q.To)
+		where.WriteString(fmt.Sprintf(" AND created_at <= $%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, timestamp_id, operation, actor, source_ip, request_id, before, after, created_at
+		FROM timestamp_audit
+		WHERE 1=1%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, where.String(), len(args)+1, len(args)+2)
+	args = append(args, q.Limit, q.Offset)
+
+	return s.queryEntries(ctx, "list audit", query, args...)
+}
+
+// queryEntries runs query as the tenant of ctx and collects the entries it selects.
+func (s *pgAuditStorage) queryEntries(
+	ctx context.Context,
+	op, query string,
+	args ...any,
+) ([]*entity.AuditEntry, error) {
+	var list []*entity.AuditEntry
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, ErrQueryFailed)
+		}
+
+		list, err = collectAuditEntries(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func collectAuditEntries(rows pgx.Rows) ([]*entity.AuditEntry, error) {
+	defer rows.Close()
+
+	list := []*entity.AuditEntry{}
+
+	for rows.Next() {
+		var e entity.AuditEntry
+		var before, after []byte
+
+		err := rows.Scan(
+			&e.ID, &e.TimestampID, &e.Operation, &e.Actor, &e.SourceIP, &e.RequestID, &before, &after, &e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", ErrScanFailed)
+		}
+		e.Before, e.After = before, after
+
+		list = append(list, &e)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("audit: %w", ErrRowsFailed)
+	}
+
+	return list, nil
+}
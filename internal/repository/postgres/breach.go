@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"time"
+)
+
+type pgBreachStorage struct {
+	db *pgdb.Client
+}
+
+func NewBreachStorage(db *pgdb.Client) repository.BreachStorage {
+	return &pgBreachStorage{
+		db: db,
+	}
+}
+
+// FindOverdue is run by the breach scanner and covers every tenant. Only timestamps after openedAfter are
+// read: an entity whose latest timestamp is older has no newer stage to pick instead.
+func (s *pgBreachStorage) FindOverdue(
+	ctx context.Context,
+	openedAfter, openedBefore time.Time,
+	limit int,
+) ([]*entity.Breach, error) {
+	query := `
+		SELECT cur.tenant_id, cur.external_id, cur.tag, cur.stage, cur.timestamp
+		FROM (
+			SELECT DISTINCT ON (tenant_id, external_id, tag) tenant_id, external_id, tag, stage, timestamp
+			FROM timestamps
+			WHERE deleted_at IS NULL AND timestamp > $1
+			ORDER BY tenant_id, external_id, tag, timestamp DESC, id
+		) cur
+		WHERE cur.stage NOT IN ('on_hold', 'resolved', 'closed')
+			AND cur.timestamp < $2
+			AND NOT EXISTS (
+				SELECT 1 FROM sla_breaches b
+				WHERE b.tenant_id = cur.tenant_id AND b.external_id = cur.external_id
+					AND b.tag = cur.tag AND b.stage = cur.stage
+			)
+		ORDER BY cur.timestamp
+		LIMIT $3
+	`
+
+	rows, err := s.db.Query(ctx, query, openedAfter, openedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("find overdue: %w", ErrQueryFailed)
+	}
+	defer rows.Close()
+
+	var list []*entity.Breach
+
+	for rows.Next() {
+		var b entity.Breach
+		if err = rows.Scan(&b.Tenant, &b.ExternalID, &b.Tag, &b.Stage, &b.StageStartedAt); err != nil {
+			return nil, fmt.Errorf("find overdue: %w", ErrScanFailed)
+		}
+
+		list = append(list, &b)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("find overdue: %w", ErrRowsFailed)
+	}
+
+	return list, nil
+}
+
+func (s *pgBreachStorage) Record(ctx context.Context, b *entity.Breach) (bool, error) {
+	query := `
+		INSERT INTO sla_breaches (tenant_id, external_id, tag, stage, stage_started_at, detected_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING
+	`
+
+	tag, err := s.db.Exec(ctx, query, b.Tenant, b.ExternalID, b.Tag, b.Stage, b.StageStartedAt, b.DetectedAt)
+	if err != nil {
+		return false, fmt.Errorf("record breach: %w", ErrQueryFailed)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *pgBreachStorage) Forget(ctx context.Context, b *entity.Breach) error {
+	query := `DELETE FROM sla_breaches WHERE tenant_id = $1 AND external_id = $2 AND tag = $3 AND stage = $4`
+
+	if _, err := s.db.Exec(ctx, query, b.Tenant, b.ExternalID, b.Tag, b.Stage); err != nil {
+		return fmt.Errorf("forget breach: %w", ErrQueryFailed)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"time"
+)
+
+type pgCalendarStorage struct {
+	db *pgdb.Client
+}
+
+func NewCalendarStorage(db *pgdb.Client) repository.CalendarStorage {
+	return &pgCalendarStorage{
+		db: db,
+	}
+}
+
+func (s *pgCalendarStorage) Create(ctx context.Context, c *entity.Calendar) (uuid.UUID, error) {
+	query := `
+		INSERT INTO calendars (name, timezone, working_hours)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	var id uuid.UUID
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		return tx.QueryRow(ctx, query, c.Name, c.Timezone, workingHours(c)).Scan(&id)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return uuid.Nil, fmt.Errorf("create calendar: %w", repository.ErrCalendarExists)
+		}
+		return uuid.Nil, fmt.Errorf("create calendar: %w", ErrQueryFailed)
+	}
+
+	return id, nil
+}
+
+func (s *pgCalendarStorage) GetByID(ctx context.Context, id uuid.UUID) (*entity.Calendar, error) {
+	query := `SELECT id, name, timezone, working_hours FROM calendars WHERE id = $1`
+
+	var c *entity.Calendar
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		if c, err = scanCalendarRow(tx.QueryRow(ctx, query, id)); err != nil {
+			return err
+		}
+
+		c.Holidays, err = listHolidays(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get calendar by id: %w", repository.ErrCalendarNotFound)
+		}
+		return nil, fmt.Errorf("get calendar by id: %w", err)
+	}
+
+	return c, nil
+}
+
+func (s *pgCalendarStorage) List(ctx context.Context) ([]*entity.Calendar, error) {
+	query := `SELECT id, name, timezone, working_hours FROM calendars ORDER BY name`
+
+	var list []*entity.Calendar
+
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		rows, err := tx.Query(ctx, query)
+		if err != nil {
+			return fmt.Errorf("list calendars: %w", ErrQueryFailed)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			c, scanErr := scanCalendarRow(rows)
+			if scanErr != nil {
+				return fmt.Errorf("list calendars: %w", scanErr)
+			}
+
+			list = append(list, c)
+		}
+
+		if rows.Err() != nil {
+			return fmt.Errorf("list calendars: %w", ErrRowsFailed)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *pgCalendarStorage) Update(ctx context.Context, c *entity.Calendar) error {
+	query := `UPDATE calendars SET name = $2, timezone = $3, working_hours = $4 WHERE id = $1`
+
+	var tag pgconn.CommandTag
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		tag, err = tx.Exec(ctx, query, c.ID, c.Name, c.Timezone, workingHours(c))
+		return err
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("update calendar: %w", repository.ErrCalendarExists)
+		}
+		return fmt.Errorf("update calendar: %w", ErrQueryFailed)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("update calendar: %w", repository.ErrCalendarNotFound)
+	}
+
+	return nil
+}
+
+func (s *pgCalendarStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM calendars WHERE id = $1`
+
+	var tag pgconn.CommandTag
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		tag, err = tx.Exec(ctx, query, id)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete calendar: %w", ErrQueryFailed)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete calendar: %w", repository.ErrCalendarNotFound)
+	}
+
+	return nil
+}
+
+func (s *pgCalendarStorage) AddHolidays(ctx context.Context, id uuid.UUID, holidays []entity.Holiday) (int, error) {
+	dates := make([]string, 0, len(holidays))
+	names := make([]string, 0, len(holidays))
+	for _, h := range holidays {
+		dates = append(dates, h.Date)
+		names = append(names, h.Name)
+	}
+
+	query := `
+		INSERT INTO calendar_holidays (calendar_id, date, name)
+		SELECT $1, d::date, n
+		FROM unnest($2::text[], $3::text[]) AS h (d, n)
+		WHERE EXISTS (SELECT 1 FROM calendars WHERE id = $1)
+		ON CONFLICT (calendar_id, date) DO UPDATE SET name = EXCLUDED.name
+	`
+
+	var tag pgconn.CommandTag
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		tag, err = tx.Exec(ctx, query, id, dates, names)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("add holidays: %w", ErrQueryFailed)
+	}
+
+	if tag.RowsAffected() == 0 && len(holidays) > 0 {
+		return 0, fmt.Errorf("add holidays: %w", repository.ErrCalendarNotFound)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (s *pgCalendarStorage) DeleteHoliday(ctx context.Context, id uuid.UUID, date string) error {
+	query := `DELETE FROM calendar_holidays WHERE calendar_id = $1 AND date = $2::date`
+
+	var tag pgconn.CommandTag
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		tag, err = tx.Exec(ctx, query, id, date)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete holiday: %w", ErrQueryFailed)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete holiday: %w", repository.ErrHolidayNotFound)
+	}
+
+	return nil
+}
+
+func listHolidays(ctx context.Context, tx *pgdb.Tx, id uuid.UUID) ([]entity.Holiday, error) {
+	query := `SELECT date, name FROM calendar_holidays WHERE calendar_id = $1 ORDER BY date`
+
+	rows, err := tx.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("list holidays: %w", ErrQueryFailed)
+	}
+	defer rows.Close()
+
+	var list []entity.Holiday
+
+	for rows.Next() {
+		var date time.Time
+		var h entity.Holiday
+		if err = rows.Scan(&date, &h.Name); err != nil {
+			return nil, fmt.Errorf("list holidays: %w", ErrScanFailed)
+		}
+
+		h.Date = date.Format(entity.DateLayout)
+		list = append(list, h)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("list holidays: %w", ErrRowsFailed)
+	}
+
+	return list, nil
+}
+
+func scanCalendarRow(row pgx.Row) (*entity.Calendar, error) {
+	var c entity.Calendar
+	var hoursBytes []byte
+	if err := row.Scan(&c.ID, &c.Name, &c.Timezone, &hoursBytes); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, ErrScanFailed
+	}
+
+	if err := json.Unmarshal(hoursBytes, &c.WorkingHours); err != nil {
+		return nil, ErrUnmarshalFailed
+	}
+
+	return &c, nil
+}
+
+func workingHours(c *entity.Calendar) map[string]entity.WorkingHours {
+	if c.WorkingHours == nil {
+		return map[string]entity.WorkingHours{}
+	}
+	return c.WorkingHours
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+)
+
+type pgCatalogStorage struct {
+	db *pgdb.Client
+}
+
+func NewCatalogStorage(db *pgdb.Client) repository.CatalogStorage {
+	return &pgCatalogStorage{
+		db: db,
+	}
+}
+
+// catalogTable maps a kind to its table; the name is interpolated into queries, so only known kinds pass.
+func catalogTable(kind entity.CatalogKind) (string, error) {
+	switch kind {
+	case entity.CatalogTags:
+		return "tags", nil
+	case entity.CatalogStages:
+		return "stages", nil
+	default:
+		return "", fmt.Errorf("unknown catalog %q", kind)
+	}
+}
+
+func (s *pgCatalogStorage) List(ctx context.Context, kind entity.CatalogKind) ([]*entity.CatalogEntry, error) {
+	table, err := catalogTable(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT name, description, deprecated, created_at
+		FROM %s
+		ORDER BY name
+	`, table)
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", table, ErrQueryFailed)
+	}
+	defer rows.Close()
+
+	list := make([]*entity.CatalogEntry, 0)
+
+	for rows.Next() {
+		var e entity.CatalogEntry
+		if err = rows.Scan(&e.Name, &e.Description, &e.Deprecated, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("list %s: %w", table, ErrScanFailed)
+		}
+
+		list = append(list, &e)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("list %s: %w", table, ErrRowsFailed)
+	}
+
+	return list, nil
+}
+
+func (s *pgCatalogStorage) Create(ctx context.Context, kind entity.CatalogKind, e *entity.CatalogEntry) error {
+	table, err := catalogTable(kind)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (name, description)
+		VALUES ($1, $2)
+		RETURNING created_at
+	`, table)
+
+	if err = s.db.QueryRow(ctx, query, e.Name, e.Description).Scan(&e.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("create %s entry: %w", table, repository.ErrCatalogEntryExists)
+		}
+		return fmt.Errorf("create %s entry: %w", table, ErrQueryFailed)
+	}
+
+	return nil
+}
+
+func (s *pgCatalogStorage) Update(ctx context.Context, kind entity.CatalogKind, e *entity.CatalogEntry) error {
+	table, err := catalogTable(kind)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET description = $2, deprecated = $3
+		WHERE name = $1
+		RETURNING created_at
+	`, table)
+
+	if err = s.db.QueryRow(ctx, query, e.Name, e.Description, e.Deprecated).Scan(&e.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("update %s entry: %w", table, repository.ErrCatalogEntryNotFound)
+		}
+		return fmt.Errorf("update %s entry: %w", table, ErrQueryFailed)
+	}
+
+	return nil
+}
@@ -2,23 +2,124 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
 )
 
 func (s *pgStorage) Create(ctx context.Context, ts *entity.Timestamp) (uuid.UUID, error) {
 	query := `
 		INSERT INTO timestamps (external_id, timestamp, tag, stage, meta)
 		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id
+		ON CONFLICT DO NOTHING
+		RETURNING id, version
 	`
 
 	var id uuid.UUID
-	err := s.db.QueryRow(ctx, query, ts.ExternalID, ts.Timestamp, ts.Tag, ts.Stage, ts.Meta).Scan(&id)
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		err := tx.QueryRow(ctx, query, ts.ExternalID, ts.Timestamp, ts.Tag, ts.Stage, ts.Meta).Scan(&id, &ts.Version)
+		if err != nil {
+			return err
+		}
+
+		stored := *ts
+		stored.ID = id
+
+		return recordAudit(ctx, tx, entity.AuditCreate, auditChange{id: id, after: &stored})
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.existingID(ctx, ts)
+	}
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("create: %w", ErrQueryFailed)
 	}
 
 	return id, nil
 }
+
+// existingID looks up the timestamp that a create conflicted with. It runs as a separate statement so that
+// a row committed after the insert started is visible.
+func (s *pgStorage) existingID(ctx context.Context, ts *entity.Timestamp) (uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM timestamps
+		WHERE external_id = $1 AND tag = $2 AND stage = $3 AND deleted_at IS NULL
+	`
+
+	var id uuid.UUID
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		return tx.QueryRow(ctx, query, ts.ExternalID, ts.Tag, ts.Stage).Scan(&id)
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create: %w", ErrQueryFailed)
+	}
+
+	return id, fmt.Errorf("create: %w", repository.ErrAlreadyExists)
+}
+
+// Upsert inserts ts or, if its external_id, tag and stage are already stored for the tenant, replaces the
+// timestamp and meta of the stored one. A fresh row is the only one at version 1, which tells the two cases
+// apart.
+func (s *pgStorage) Upsert(ctx context.Context, ts *entity.Timestamp) (bool, error) {
+	// The stored row is locked first so that the audit entry records what the update replaced.
+	lockQuery := `
+		SELECT id, external_id, timestamp, tag, stage, meta, version, deleted_at
+		FROM timestamps
+		WHERE external_id = $1 AND tag = $2 AND stage = $3
+			AND stage NOT IN ('on_hold', 'resumed') AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	query := `
+		INSERT INTO timestamps (external_id, timestamp, tag, stage, meta)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, external_id, tag, stage) WHERE stage NOT IN ('on_hold', 'resumed') AND deleted_at IS NULL
+		DO UPDATE SET timestamp = EXCLUDED.timestamp, meta = EXCLUDED.meta, version = timestamps.version + 1
+		RETURNING id, version
+	`
+
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		before, err := queryTimestamp(ctx, tx, lockQuery, ts.ExternalID, ts.Tag, ts.Stage)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, query, ts.ExternalID, ts.Timestamp, ts.Tag, ts.Stage, ts.Meta).Scan(&ts.ID, &ts.Version)
+		if err != nil {
+			return err
+		}
+
+		op := entity.AuditUpdate
+		if ts.Version == 1 {
+			op = entity.AuditCreate
+		}
+		after := *ts
+
+		return recordAudit(ctx, tx, op, auditChange{id: ts.ID, before: before, after: &after})
+	})
+	if err != nil {
+		return false, fmt.Errorf("upsert: %w", ErrQueryFailed)
+	}
+
+	return ts.Version == 1, nil
+}
+
+// queryTimestamp returns the single timestamp row selected by query, which must select the columns of
+// entity.TimestampFields, or nil if there is none. Query errors are returned as they are so that callers
+// can inspect them.
+func queryTimestamp(ctx context.Context, q pgdb.Querier, query string, args ...any) (*entity.Timestamp, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	return scanTimestampRow(rows, entity.TimestampFields)
+}
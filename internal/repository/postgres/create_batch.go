@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+)
+
+func (s *pgStorage) CreateBatch(ctx context.Context, tss []*entity.Timestamp) error {
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		changes, err := insertBatch(ctx, tx, tss)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, tx, entity.AuditCreate, changes...)
+	})
+	if err != nil {
+		return fmt.Errorf("create batch: %w", ErrQueryFailed)
+	}
+
+	return nil
+}
+
+// insertBatch inserts tss in one round trip and returns the audit changes of the inserted ones.
+func insertBatch(ctx context.Context, tx *pgdb.Tx, tss []*entity.Timestamp) ([]auditChange, error) {
+	query := `
+		INSERT INTO timestamps (external_id, timestamp, tag, stage, meta)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+		RETURNING id, version
+	`
+
+	batch := &pgx.Batch{}
+	for _, ts := range tss {
+		batch.Queue(query, ts.ExternalID, ts.Timestamp, ts.Tag, ts.Stage, ts.Meta)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	defer results.Close()
+
+	changes := make([]auditChange, 0, len(tss))
+	for _, ts := range tss {
+		err := results.QueryRow().Scan(&ts.ID, &ts.Version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			ts.ID = uuid.Nil
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, auditChange{id: ts.ID, after: ts})
+	}
+
+	return changes, results.Close()
+}
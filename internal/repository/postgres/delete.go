@@ -2,22 +2,105 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"time"
 )
 
-func (s *pgStorage) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM timestamps WHERE id = $1`
+func (s *pgStorage) Delete(ctx context.Context, id uuid.UUID) (*entity.Timestamp, error) {
+	lockQuery := `
+		SELECT id, external_id, timestamp, tag, stage, meta, version, deleted_at
+		FROM timestamps
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	query := `UPDATE timestamps SET deleted_at = now() WHERE id = $1 RETURNING deleted_at`
 
-	tag, err := s.db.Exec(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("delete: %w", ErrQueryFailed)
+	var deleted entity.Timestamp
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		before, err := queryTimestamp(ctx, tx, lockQuery, id)
+		switch {
+		case err != nil:
+			return err
+		case before == nil:
+			return repository.ErrNotFound
+		}
+
+		deleted = *before
+		if err = tx.QueryRow(ctx, query, id).Scan(&deleted.DeletedAt); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, tx, entity.AuditDelete, auditChange{id: id, before: before, after: &deleted})
+	})
+
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, fmt.Errorf("delete: %w", err)
+	case err != nil:
+		return nil, fmt.Errorf("delete: %w", ErrQueryFailed)
 	}
 
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("delete: %w", repository.ErrNotFound)
+	return &deleted, nil
+}
+
+func (s *pgStorage) Restore(ctx context.Context, id uuid.UUID) (*entity.Timestamp, error) {
+	lockQuery := `
+		SELECT id, external_id, timestamp, tag, stage, meta, version, deleted_at
+		FROM timestamps
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		FOR UPDATE
+	`
+	query := `UPDATE timestamps SET deleted_at = NULL WHERE id = $1`
+
+	var restored entity.Timestamp
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		before, err := queryTimestamp(ctx, tx, lockQuery, id)
+		switch {
+		case err != nil:
+			return err
+		case before == nil:
+			return repository.ErrNotFound
+		}
+
+		if _, err = tx.Exec(ctx, query, id); err != nil {
+			if isUniqueViolation(err) {
+				return repository.ErrAlreadyExists
+			}
+			return err
+		}
+
+		restored = *before
+		restored.DeletedAt = nil
+
+		return recordAudit(ctx, tx, entity.AuditRestore, auditChange{id: id, before: before, after: &restored})
+	})
+
+	switch {
+	case errors.Is(err, repository.ErrNotFound), errors.Is(err, repository.ErrAlreadyExists):
+		return nil, fmt.Errorf("restore: %w", err)
+	case err != nil:
+		return nil, fmt.Errorf("restore: %w", ErrQueryFailed)
+	}
+
+	return &restored, nil
+}
+
+// PurgeDeleted purges across all tenants and leaves the audit entries of the purged timestamps in place.
+func (s *pgStorage) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	query := `
+		DELETE FROM timestamps
+		WHERE deleted_at < now() - make_interval(secs => $1)
+	`
+
+	tag, err := s.db.Exec(ctx, query, retention.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("purge deleted timestamps: %w", ErrQueryFailed)
 	}
 
-	return nil
+	return tag.RowsAffected(), nil
 }
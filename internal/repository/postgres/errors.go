@@ -1,10 +1,21 @@
 package postgres
 
-import "errors"
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const uniqueViolationCode = "23505"
 
 var (
 	ErrQueryFailed     = errors.New("database query execution error")
 	ErrScanFailed      = errors.New("failed to extract row data")
 	ErrRowsFailed      = errors.New("unexpected error during result iteration")
 	ErrUnmarshalFailed = errors.New("failed to unmarshal meta data")
+	ErrNoTenant        = errors.New("no tenant in context")
 )
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"iter"
+	"time"
+)
+
+// Export runs the query only once the sequence is ranged over. pgx reads the result set from the
+// connection row by row, so memory use does not grow with the number of rows exported. The tenant
+// transaction stays open until the sequence is done.
+func (s *pgStorage) Export(
+	ctx context.Context,
+	externalID, tag, stage string,
+	timestampFrom, timestampTo *time.Time,
+	metaFilter map[string]any,
+	filters []entity.Filter,
+) iter.Seq2[*entity.Timestamp, error] {
+	return func(yield func(*entity.Timestamp, error) bool) {
+		where, args, err := buildListFilter(&entity.ListQueryParams{
+			ExternalID:    externalID,
+			Tag:           tag,
+			Stage:         stage,
+			TimestampFrom: timestampFrom,
+			TimestampTo:   timestampTo,
+			MetaFilter:    metaFilter,
+			Filters:       filters,
+		})
+		if err != nil {
+			yield(nil, fmt.Errorf("build query: %w", err))
+			return
+		}
+
+		query := "SELECT id, external_id, timestamp, tag, stage, meta, version, deleted_at FROM timestamps WHERE 1=1" +
+			where + " ORDER BY timestamp DESC"
+
+		// Errors are yielded where they occur, so the transaction only reports whether it could start or
+		// commit. yield must not be called again once the caller stopped ranging or an error was yielded.
+		stopped := false
+		err = withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+			rows, err := tx.Query(ctx, query, args...)
+			if err != nil {
+				stopped = true
+				yield(nil, fmt.Errorf("export: %w", ErrQueryFailed))
+				return nil
+			}
+			defer rows.Close()
+
+			for rows.Next() {
+				ts, scanErr := scanTimestampRow(rows, entity.TimestampFields)
+				if !yield(ts, scanErr) || scanErr != nil {
+					stopped = true
+					return nil
+				}
+			}
+
+			if rows.Err() != nil {
+				stopped = true
+				yield(nil, fmt.Errorf("export: %w", ErrRowsFailed))
+			}
+
+			return nil
+		})
+		if err != nil && !stopped {
+			yield(nil, fmt.Errorf("export: %w", err))
+		}
+	}
+}
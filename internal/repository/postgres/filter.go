@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"strconv"
+	"strings"
+)
+
+// filterColumns whitelists the columns that may be interpolated into filter conditions.
+var filterColumns = map[string]string{
+	"external_id": "external_id",
+	"tag":         "tag",
+	"stage":       "stage",
+}
+
+// likeEscaper escapes the LIKE wildcards, so that a prefix matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// buildFilterConditions returns the " AND ..." conditions for filters with their arguments, numbered from
+// argIndex. Only whitelisted columns and fixed operators are interpolated; meta keys and values are always
+// passed as arguments.
+func buildFilterConditions(filters []entity.Filter, argIndex int) (string, []any, error) {
+	var query strings.Builder
+	var args []any
+
+	for _, f := range filters {
+		condition, conditionArgs, err := buildFilterCondition(f, argIndex+len(args))
+		if err != nil {
+			return "", nil, err
+		}
+
+		query.WriteString(" AND " + condition)
+		args = append(args, conditionArgs...)
+	}
+
+	return query.String(), args, nil
+}
+
+func buildFilterCondition(f entity.Filter, argIndex int) (string, []any, error) {
+	key := f.MetaKey()
+	if key == "" {
+		column, ok := filterColumns[f.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown filter field %q", f.Field)
+		}
+
+		comparison, value, err := buildComparison(f, argIndex)
+		if err != nil {
+			return "", nil, err
+		}
+		return column + comparison, []any{value}, nil
+	}
+
+	switch f.Op {
+	case entity.FilterExists:
+		return fmt.Sprintf("meta ? $%d::text", argIndex), []any{key}, nil
+	case entity.FilterNotExists:
+		return fmt.Sprintf("NOT meta ? $%d::text", argIndex), []any{key}, nil
+	case entity.FilterLt, entity.FilterLe, entity.FilterGt, entity.FilterGe:
+		return buildNumericMetaCondition(f, key, argIndex)
+	}
+
+	comparison, value, err := buildComparison(f, argIndex+1)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("meta->>$%d::text", argIndex) + comparison, []any{key, value}, nil
+}
+
+// buildComparison returns the operator and placeholder to append to the filtered expression, and the
+// argument for the placeholder.
+func buildComparison(f entity.Filter, argIndex int) (string, any, error) {
+	if len(f.Values) == 0 {
+		return "", nil, fmt.Errorf("filter %q has no value", f.Field)
+	}
+
+	switch f.Op {
+	case entity.FilterEq:
+		return fmt.Sprintf(" = $%d", argIndex), f.Values[0], nil
+	case entity.FilterNe:
+		return fmt.Sprintf(" <> $%d", argIndex), f.Values[0], nil
+	case entity.FilterIn:
+		return fmt.Sprintf(" = ANY($%d)", argIndex), f.Values, nil
+	case entity.FilterNotIn:
+		return fmt.Sprintf(" <> ALL($%d)", argIndex), f.Values, nil
+	case entity.FilterPrefix:
+		return fmt.Sprintf(" LIKE $%d", argIndex), likeEscaper.Replace(f.Values[0]) + "%", nil
+	case entity.FilterILike:
+		return fmt.Sprintf(" ILIKE $%d", argIndex), f.Values[0], nil
+	default:
+		return "", nil, fmt.Errorf("unsupported filter operator %q", f.Op)
+	}
+}
+
+// buildNumericMetaCondition compares a meta value as a number. The CASE guarantees that the cast only
+// runs on numbers, so that a string under the same key does not fail the query.
+func buildNumericMetaCondition(f entity.Filter, key string, argIndex int) (string, []any, error) {
+	if len(f.Values) == 0 {
+		return "", nil, fmt.Errorf("filter %q has no value", f.Field)
+	}
+
+	value, err := strconv.ParseFloat(f.Values[0], 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("filter %q: %w", f.Field, err)
+	}
+
+	// f.Op is one of the four ordering operators checked by the caller.
+	condition := fmt.Sprintf(
+		"CASE WHEN jsonb_typeof(meta->$%[1]d::text) = 'number' THEN (meta->>$%[1]d::text)::numeric END %[2]s $%[3]d",
+		argIndex, f.Op, argIndex+1,
+	)
+
+	return condition, []any{key, value}, nil
+}
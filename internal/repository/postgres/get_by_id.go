@@ -9,18 +9,22 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
 )
 
 func (s *pgStorage) GetByID(ctx context.Context, id uuid.UUID) (*entity.Timestamp, error) {
 	query := `
-		SELECT id, external_id, timestamp, tag, stage, meta
+		SELECT id, external_id, timestamp, tag, stage, meta, version
 		FROM timestamps
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 	var ts entity.Timestamp
 	var metaBytes []byte
 
-	err := s.db.QueryRow(ctx, query, id).Scan(&ts.ID, &ts.ExternalID, &ts.Timestamp, &ts.Tag, &ts.Stage, &metaBytes)
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		return tx.QueryRow(ctx, query, id).
+			Scan(&ts.ID, &ts.ExternalID, &ts.Timestamp, &ts.Tag, &ts.Stage, &metaBytes, &ts.Version)
+	})
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
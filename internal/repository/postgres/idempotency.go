@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"time"
+)
+
+type pgIdempotencyStorage struct {
+	db *pgdb.Client
+}
+
+func NewIdempotencyStorage(db *pgdb.Client) repository.IdempotencyStorage {
+	return &pgIdempotencyStorage{
+		db: db,
+	}
+}
+
+func (s *pgIdempotencyStorage) Reserve(
+	ctx context.Context,
+	key, requestHash string,
+	ttl time.Duration,
+) (*entity.IdempotencyRecord, error) {
+	query := `
+		INSERT INTO idempotency_keys (key, request_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status_code = NULL, response = NULL, created_at = now()
+		WHERE idempotency_keys.created_at < now() - make_interval(secs => $3)
+	`
+
+	tag, err := s.db.Exec(ctx, query, key, requestHash, ttl.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("reserve idempotency key: %w", ErrQueryFailed)
+	}
+	if tag.RowsAffected() == 1 {
+		return nil, nil
+	}
+
+	query = `
+		SELECT request_hash, COALESCE(status_code, 0), response
+		FROM idempotency_keys
+		WHERE key = $1
+	`
+
+	rec := &entity.IdempotencyRecord{Key: key}
+	err = s.db.QueryRow(ctx, query, key).Scan(&rec.RequestHash, &rec.StatusCode, &rec.Response)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Released by a failed request in the meantime; report it as still in flight so the client retries.
+		rec.RequestHash = requestHash
+		return rec, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reserve idempotency key: %w", ErrQueryFailed)
+	}
+
+	return rec, nil
+}
+
+func (s *pgIdempotencyStorage) Complete(ctx context.Context, key string, statusCode int, response []byte) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $2, response = $3
+		WHERE key = $1
+	`
+
+	if _, err := s.db.Exec(ctx, query, key, statusCode, response); err != nil {
+		return fmt.Errorf("complete idempotency key: %w", ErrQueryFailed)
+	}
+
+	return nil
+}
+
+func (s *pgIdempotencyStorage) Release(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE key = $1 AND status_code IS NULL
+	`
+
+	if _, err := s.db.Exec(ctx, query, key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", ErrQueryFailed)
+	}
+
+	return nil
+}
+
+func (s *pgIdempotencyStorage) Purge(ctx context.Context, ttl time.Duration) (int64, error) {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE created_at < now() - make_interval(secs => $1)
+	`
+
+	tag, err := s.db.Exec(ctx, query, ttl.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("purge idempotency keys: %w", ErrQueryFailed)
+	}
+
+	return tag.RowsAffected(), nil
+}
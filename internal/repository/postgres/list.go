@@ -6,23 +6,45 @@ import (
 	"fmt"
 	"github.com/jackc/pgx/v5"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"slices"
 	"strings"
-	"time"
 )
 
-func (s *pgStorage) List(
-	ctx context.Context,
-	limit, offset int,
-	externalID, tag, stage string,
-	timestampFrom, timestampTo *time.Time,
-	metaFilter map[string]any,
-) ([]*entity.Timestamp, error) {
-	query, args, err := buildListQuery(externalID, tag, stage, timestampFrom, timestampTo, limit, offset, metaFilter)
+func (s *pgStorage) List(ctx context.Context, params *entity.ListQueryParams) ([]*entity.Timestamp, *int64, error) {
+	query, columns, args, err := buildListQuery(params)
 	if err != nil {
-		return nil, fmt.Errorf("build query: %w", err)
+		return nil, nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var list []*entity.Timestamp
+	var total *int64
+
+	// The page and its count run in one transaction, both scoped to the tenant.
+	err = withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		if list, err = listRows(ctx, tx, query, columns, args); err != nil {
+			return err
+		}
+
+		total, err = s.count(ctx, tx, params)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	rows, err := s.db.Query(ctx, query, args...)
+	return list, total, nil
+}
+
+// listRows runs a query built by buildListQuery and scans the given columns of its rows.
+func listRows(
+	ctx context.Context,
+	q pgdb.Querier,
+	query string,
+	columns []string,
+	args []any,
+) ([]*entity.Timestamp, error) {
+	rows, err := q.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("list: %w", ErrQueryFailed)
 	}
@@ -31,7 +53,7 @@ func (s *pgStorage) List(
 	var list []*entity.Timestamp
 
 	for rows.Next() {
-		ts, scanErr := scanTimestampRow(rows)
+		ts, scanErr := scanTimestampRow(rows, columns)
 		if scanErr != nil {
 			return nil, scanErr
 		}
@@ -46,50 +68,173 @@ func (s *pgStorage) List(
 	return list, nil
 }
 
-func buildListQuery(
-	externalID, tag, stage string,
-	timestampFrom, timestampTo *time.Time,
-	limit, offset int,
-	metaFilter map[string]any,
-) (string, []any, error) {
+// count returns the number of timestamps matching the filters of params, ignoring the cursor and the
+// page, or nil for CountNone.
+func (s *pgStorage) count(ctx context.Context, q pgdb.Querier, params *entity.ListQueryParams) (*int64, error) {
+	where, args, err := buildListFilter(params)
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var total int64
+
+	switch params.Count {
+	case entity.CountExact:
+		query := "SELECT COUNT(*) FROM timestamps WHERE 1=1" + where
+		if err = q.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+			return nil, fmt.Errorf("count: %w", ErrQueryFailed)
+		}
+	case entity.CountEstimated:
+		if total, err = s.estimate(ctx, q, "SELECT 1 FROM timestamps WHERE 1=1"+where, args); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	return &total, nil
+}
+
+// estimate returns the planner's row estimate for query, read from EXPLAIN instead of running it.
+func (s *pgStorage) estimate(ctx context.Context, q pgdb.Querier, query string, args []any) (int64, error) {
+	var planJSON []byte
+	if err := q.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&planJSON); err != nil {
+		return 0, fmt.Errorf("estimate: %w", ErrQueryFailed)
+	}
+
+	var plan []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(planJSON, &plan); err != nil || len(plan) == 0 {
+		return 0, fmt.Errorf("estimate: %w", ErrScanFailed)
+	}
+
+	return int64(plan[0].Plan.Rows), nil
+}
+
+// buildListQuery returns the query for params and the columns it selects. Rows are ordered by
+// params.Sort with id as the final tiebreaker, so that every row has a unique position.
+func buildListQuery(params *entity.ListQueryParams) (string, []string, []any, error) {
+	where, args, err := buildListFilter(params)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	argIndex := len(args) + 1
+	if params.Cursor != nil {
+		where += fmt.Sprintf(" AND (timestamp, id) < ($%d, $%d)", argIndex, argIndex+1)
+		args = append(args, params.Cursor.Timestamp, params.Cursor.ID)
+		argIndex += 2
+	}
+
+	orderBy, err := buildOrderBy(params.Sort)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	columns := listColumns(params.Fields)
+	query := fmt.Sprintf(
+		"SELECT %s FROM timestamps WHERE 1=1%s ORDER BY %s LIMIT $%d OFFSET $%d",
+		strings.Join(columns, ", "), where, orderBy, argIndex, argIndex+1,
+	)
+	args = append(args, params.Limit, params.Offset)
+
+	return query, columns, args, nil
+}
+
+// sortColumns whitelists the columns that may be interpolated into ORDER BY.
+var sortColumns = map[string]string{
+	"id":          "id",
+	"external_id": "external_id",
+	"timestamp":   "timestamp",
+	"tag":         "tag",
+	"stage":       "stage",
+	"version":     "version",
+}
+
+func buildOrderBy(sort []entity.SortField) (string, error) {
+	if len(sort) == 0 {
+		sort = []entity.SortField{{Field: "timestamp", Desc: true}}
+	}
+
+	terms := make([]string, 0, len(sort)+1)
+	hasID := false
+	for _, f := range sort {
+		column, ok := sortColumns[f.Field]
+		if !ok {
+			return "", fmt.Errorf("unknown sort field %q", f.Field)
+		}
+		hasID = hasID || column == "id"
+
+		if f.Desc {
+			column += " DESC"
+		}
+		terms = append(terms, column)
+	}
+
+	if !hasID {
+		terms = append(terms, "id DESC")
+	}
+
+	return strings.Join(terms, ", "), nil
+}
+
+// listColumns selects the requested fields in the canonical column order. id and timestamp are always
+// selected because the next cursor is built from them.
+func listColumns(fields []string) []string {
+	if len(fields) == 0 {
+		return entity.TimestampFields
+	}
+
+	return slices.DeleteFunc(slices.Clone(entity.TimestampFields), func(column string) bool {
+		return column != "id" && column != "timestamp" && !slices.Contains(fields, column)
+	})
+}
+
+// buildListFilter returns the " AND ..." conditions shared by List and Export together with their
+// arguments, numbered from $1. Pagination, sort and fields of params are ignored.
+func buildListFilter(params *entity.ListQueryParams) (string, []any, error) {
 	var query strings.Builder
-	query.WriteString("SELECT id, external_id, timestamp, tag, stage, meta FROM timestamps WHERE 1=1")
 
 	var args []any
 	argIndex := 1
 
-	if externalID != "" {
+	query.WriteString(deletedCondition(params.Deleted))
+
+	if params.ExternalID != "" {
 		query.WriteString(fmt.Sprintf(" AND external_id = $%d", argIndex))
-		args = append(args, externalID)
+		args = append(args, params.ExternalID)
 		argIndex++
 	}
 
-	if tag != "" {
+	if params.Tag != "" {
 		query.WriteString(fmt.Sprintf(" AND tag = $%d", argIndex))
-		args = append(args, tag)
+		args = append(args, params.Tag)
 		argIndex++
 	}
 
-	if stage != "" {
+	if params.Stage != "" {
 		query.WriteString(fmt.Sprintf(" AND stage = $%d", argIndex))
-		args = append(args, stage)
+		args = append(args, params.Stage)
 		argIndex++
 	}
 
-	if timestampFrom != nil {
+	if params.TimestampFrom != nil {
 		query.WriteString(fmt.Sprintf(" AND timestamp >= $%d", argIndex))
-		args = append(args, *timestampFrom)
+		args = append(args, *params.TimestampFrom)
 		argIndex++
 	}
 
-	if timestampTo != nil {
+	if params.TimestampTo != nil {
 		query.WriteString(fmt.Sprintf(" AND timestamp <= $%d", argIndex))
-		args = append(args, *timestampTo)
+		args = append(args, *params.TimestampTo)
 		argIndex++
 	}
 
-	if len(metaFilter) > 0 {
-		metaJSON, err := json.Marshal(metaFilter)
+	if len(params.MetaFilter) > 0 {
+		metaJSON, err := json.Marshal(params.MetaFilter)
 		if err != nil {
 			return "", nil, fmt.Errorf("marshal meta_filter: %w", err)
 		}
@@ -98,16 +243,56 @@ func buildListQuery(
 		argIndex++
 	}
 
-	query.WriteString(fmt.Sprintf(" ORDER BY timestamp DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1))
-	args = append(args, limit, offset)
+	conditions, filterArgs, err := buildFilterConditions(params.Filters, argIndex)
+	if err != nil {
+		return "", nil, err
+	}
+	query.WriteString(conditions)
+	args = append(args, filterArgs...)
 
 	return query.String(), args, nil
 }
 
-func scanTimestampRow(rows pgx.Rows) (*entity.Timestamp, error) {
+func deletedCondition(mode entity.DeletedMode) string {
+	switch mode {
+	case entity.DeletedInclude:
+		return ""
+	case entity.DeletedOnly:
+		return " AND deleted_at IS NOT NULL"
+	default:
+		return " AND deleted_at IS NULL"
+	}
+}
+
+// scanTimestampRow scans a row of the given columns, which must be in the order of entity.TimestampFields.
+// Meta is only unmarshalled when it was selected.
+func scanTimestampRow(rows pgx.Rows, columns []string) (*entity.Timestamp, error) {
 	var ts entity.Timestamp
 	var metaBytes []byte
-	if err := rows.Scan(&ts.ID, &ts.ExternalID, &ts.Timestamp, &ts.Tag, &ts.Stage, &metaBytes); err != nil {
+
+	dest := make([]any, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id":
+			dest[i] = &ts.ID
+		case "external_id":
+			dest[i] = &ts.ExternalID
+		case "timestamp":
+			dest[i] = &ts.Timestamp
+		case "tag":
+			dest[i] = &ts.Tag
+		case "stage":
+			dest[i] = &ts.Stage
+		case "meta":
+			dest[i] = &metaBytes
+		case "version":
+			dest[i] = &ts.Version
+		case "deleted_at":
+			dest[i] = &ts.DeletedAt
+		}
+	}
+
+	if err := rows.Scan(dest...); err != nil {
 		return nil, fmt.Errorf("scan row: %w", ErrScanFailed)
 	}
 	if metaBytes != nil {
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+)
+
+func (s *pgStorage) ListByExternalID(ctx context.Context, externalID string) ([]*entity.Timestamp, error) {
+	query := `
+		SELECT id, external_id, timestamp, tag, stage, meta, version, deleted_at
+		FROM timestamps
+		WHERE external_id = $1 AND deleted_at IS NULL
+		ORDER BY timestamp, stage
+	`
+
+	var list []*entity.Timestamp
+
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		rows, err := tx.Query(ctx, query, externalID)
+		if err != nil {
+			return fmt.Errorf("list by external id: %w", ErrQueryFailed)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			ts, scanErr := scanTimestampRow(rows, entity.TimestampFields)
+			if scanErr != nil {
+				return scanErr
+			}
+
+			list = append(list, ts)
+		}
+
+		if rows.Err() != nil {
+			return fmt.Errorf("list by external id: %w", ErrRowsFailed)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"time"
+)
+
+type pgMetricsStorage struct {
+	db *pgdb.Client
+}
+
+func NewMetricsStorage(db *pgdb.Client) repository.MetricsStorage {
+	return &pgMetricsStorage{
+		db: db,
+	}
+}
+
+// SLAMetrics covers the timestamps of the tenant in ctx. It first collapses the stages of every
+// (external_id, tag) into one row with window functions, then aggregates the durations per group. Rows
+// before the window cannot belong to an entity created inside it, so they are filtered out before the
+// window functions run.
+func (s *pgMetricsStorage) SLAMetrics(
+	ctx context.Context,
+	q entity.SLAMetricsQuery,
+) ([]*entity.SLAMetricsGroup, error) {
+	query := `
+		WITH entities AS (
+			SELECT DISTINCT ON (external_id, tag)
+				tag,
+				FIRST_VALUE(meta ->> $3) OVER w AS grp,
+				MIN(timestamp) FILTER (WHERE stage = 'created') OVER w AS created_at,
+				MIN(timestamp) FILTER (WHERE stage = 'acknowledged') OVER w AS acknowledged_at,
+				MIN(timestamp) FILTER (WHERE stage = 'resolved') OVER w AS resolved_at
+			FROM timestamps
+			WHERE deleted_at IS NULL AND ($1::timestamptz IS NULL OR timestamp >= $1)
+			WINDOW w AS (
+				PARTITION BY external_id, tag
+				ORDER BY stage <> 'created', timestamp
+				ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING
+			)
+		),
+		durations AS (
+			SELECT
+				tag,
+				grp,
+				CASE WHEN acknowledged_at >= created_at
+					THEN EXTRACT(EPOCH FROM acknowledged_at - created_at)::float8 END AS tta,
+				CASE WHEN resolved_at >= created_at
+					THEN EXTRACT(EPOCH FROM resolved_at - created_at)::float8 END AS ttr
+			FROM entities
+			WHERE created_at IS NOT NULL
+				AND ($1::timestamptz IS NULL OR created_at >= $1)
+				AND ($2::timestamptz IS NULL OR created_at <= $2)
+		)
+		SELECT
+			tag,
+			grp,
+			COUNT(*),
+			COUNT(tta),
+			AVG(tta),
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY tta),
+			PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY tta),
+			PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY tta),
+			COUNT(ttr),
+			AVG(ttr),
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY ttr),
+			PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY ttr),
+			PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY ttr)
+		FROM durations
+		GROUP BY tag, grp
+		ORDER BY tag, grp NULLS LAST
+	`
+
+	var groupBy *string
+	if q.GroupBy != "" {
+		groupBy = &q.GroupBy
+	}
+
+	var groups []*entity.SLAMetricsGroup
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		rows, err := tx.Query(ctx, query, q.TimestampFrom, q.TimestampTo, groupBy)
+		if err != nil {
+			return fmt.Errorf("sla metrics: %w", ErrQueryFailed)
+		}
+
+		groups, err = collectMetricsGroups(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func collectMetricsGroups(rows pgx.Rows) ([]*entity.SLAMetricsGroup, error) {
+	defer rows.Close()
+
+	groups := make([]*entity.SLAMetricsGroup, 0)
+
+	for rows.Next() {
+		var (
+			g        entity.SLAMetricsGroup
+			tta, ttr [4]*float64
+		)
+
+		err := rows.Scan(
+			&g.Tag, &g.Group, &g.Entities,
+			&g.TimeToAcknowledge.Count, &tta[0], &tta[1], &tta[2], &tta[3],
+			&g.TimeToResolve.Count, &ttr[0], &ttr[1], &ttr[2], &ttr[3],
+		)
+		if err != nil {
+			return nil, fmt.Errorf("sla metrics: %w", ErrScanFailed)
+		}
+
+		fillStats(&g.TimeToAcknowledge, tta)
+		fillStats(&g.TimeToResolve, ttr)
+
+		groups = append(groups, &g)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("sla metrics: %w", ErrRowsFailed)
+	}
+
+	return groups, nil
+}
+
+// fillStats sets mean, p50, p90 and p99 from seconds in that order.
+func fillStats(stats *entity.DurationStats, seconds [4]*float64) {
+	stats.Mean = secondsToDuration(seconds[0])
+	stats.P50 = secondsToDuration(seconds[1])
+	stats.P90 = secondsToDuration(seconds[2])
+	stats.P99 = secondsToDuration(seconds[3])
+}
+
+func secondsToDuration(seconds *float64) *entity.Duration {
+	if seconds == nil {
+		return nil
+	}
+
+	d := entity.Duration(time.Duration(*seconds * float64(time.Second)).Round(time.Millisecond))
+
+	return &d
+}
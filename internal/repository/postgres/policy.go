@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"time"
+)
+
+type pgPolicyStorage struct {
+	db *pgdb.Client
+}
+
+func NewPolicyStorage(db *pgdb.Client) repository.PolicyStorage {
+	return &pgPolicyStorage{
+		db: db,
+	}
+}
+
+func (s *pgPolicyStorage) Create(ctx context.Context, p *entity.SLAPolicy) (uuid.UUID, error) {
+	query := `
+		INSERT INTO sla_policies (name, tag, start_stage, end_stage, target_seconds)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var id uuid.UUID
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		return tx.QueryRow(ctx, query, p.Name, p.Tag, p.StartStage, p.EndStage, targetSeconds(p)).Scan(&id)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return uuid.Nil, fmt.Errorf("create policy: %w", repository.ErrPolicyExists)
+		}
+		return uuid.Nil, fmt.Errorf("create policy: %w", ErrQueryFailed)
+	}
+
+	return id, nil
+}
+
+func (s *pgPolicyStorage) GetByID(ctx context.Context, id uuid.UUID) (*entity.SLAPolicy, error) {
+	query := `
+		SELECT id, name, tag, start_stage, end_stage, target_seconds
+		FROM sla_policies
+		WHERE id = $1
+	`
+
+	var p *entity.SLAPolicy
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		p, err = scanPolicyRow(tx.QueryRow(ctx, query, id))
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get policy by id: %w", repository.ErrPolicyNotFound)
+		}
+		return nil, fmt.Errorf("get policy by id: %w", ErrQueryFailed)
+	}
+
+	return p, nil
+}
+
+func (s *pgPolicyStorage) List(ctx context.Context, tag string) ([]*entity.SLAPolicy, error) {
+	query := `
+		SELECT id, name, tag, start_stage, end_stage, target_seconds
+		FROM sla_policies
+		WHERE $1 = '' OR tag::text = $1
+		ORDER BY tag, start_stage, end_stage
+	`
+
+	var list []*entity.SLAPolicy
+
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		rows, err := tx.Query(ctx, query, tag)
+		if err != nil {
+			return fmt.Errorf("list policies: %w", ErrQueryFailed)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			p, scanErr := scanPolicyRow(rows)
+			if scanErr != nil {
+				return fmt.Errorf("list policies: %w", ErrScanFailed)
+			}
+
+			list = append(list, p)
+		}
+
+		if rows.Err() != nil {
+			return fmt.Errorf("list policies: %w", ErrRowsFailed)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *pgPolicyStorage) Update(ctx context.Context, p *entity.SLAPolicy) error {
+	query := `
+		UPDATE sla_policies
+		SET name = $2, tag = $3, start_stage = $4, end_stage = $5, target_seconds = $6
+		WHERE id = $1
+	`
+
+	var tag pgconn.CommandTag
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		tag, err = tx.Exec(ctx, query, p.ID, p.Name, p.Tag, p.StartStage, p.EndStage, targetSeconds(p))
+		return err
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("update policy: %w", repository.ErrPolicyExists)
+		}
+		return fmt.Errorf("update policy: %w", ErrQueryFailed)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("update policy: %w", repository.ErrPolicyNotFound)
+	}
+
+	return nil
+}
+
+func (s *pgPolicyStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM sla_policies WHERE id = $1`
+
+	var tag pgconn.CommandTag
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		tag, err = tx.Exec(ctx, query, id)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete policy: %w", ErrQueryFailed)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete policy: %w", repository.ErrPolicyNotFound)
+	}
+
+	return nil
+}
+
+func scanPolicyRow(row pgx.Row) (*entity.SLAPolicy, error) {
+	var p entity.SLAPolicy
+	var seconds int64
+	if err := row.Scan(&p.ID, &p.Name, &p.Tag, &p.StartStage, &p.EndStage, &seconds); err != nil {
+		return nil, err
+	}
+	p.Target = entity.Duration(time.Duration(seconds) * time.Second)
+	return &p, nil
+}
+
+func targetSeconds(p *entity.SLAPolicy) int64 {
+	return int64(time.Duration(p.Target) / time.Second)
+}
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+)
+
+// tenantRole is the role that tenant-scoped statements run as. Row-level security admits it only to the
+// rows of the tenant in app.tenant_id; the user the pool connects as, which the maintenance jobs run as,
+// owns the tables and is not subject to it.
+const tenantRole = "sla_tenant"
+
+// withTenant runs fn in a transaction scoped to the tenant of ctx. Both settings are local to the
+// transaction, so they never carry over to the next statement on the pooled connection.
+func withTenant(ctx context.Context, db *pgdb.Client, fn func(tx *pgdb.Tx) error) error {
+	tenant := entity.TenantFromContext(ctx)
+	if tenant == "" {
+		return ErrNoTenant
+	}
+
+	return db.WithTx(ctx, func(tx *pgdb.Tx) error {
+		query := `SELECT set_config('app.tenant_id', $1, true), set_config('role', $2, true)`
+		if _, err := tx.Exec(ctx, query, tenant, tenantRole); err != nil {
+			return err
+		}
+
+		return fn(tx)
+	})
+}
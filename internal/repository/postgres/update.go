@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+)
+
+// Update locks the stored row before updating it, which tells a missing row and a stale version apart and
+// gives the audit entry the state the update replaced.
+func (s *pgStorage) Update(ctx context.Context, ts *entity.Timestamp, expectedVersion int) error {
+	lockQuery := `
+		SELECT id, external_id, timestamp, tag, stage, meta, version, deleted_at
+		FROM timestamps
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+	query := `
+		UPDATE timestamps
+		SET timestamp = $2, meta = $3, version = version + 1
+		WHERE id = $1
+		RETURNING version
+	`
+
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		before, err := queryTimestamp(ctx, tx, lockQuery, ts.ID)
+		switch {
+		case err != nil:
+			return err
+		case before == nil:
+			return repository.ErrNotFound
+		case before.Version != expectedVersion:
+			return repository.ErrVersionConflict
+		}
+
+		if err = tx.QueryRow(ctx, query, ts.ID, ts.Timestamp, ts.Meta).Scan(&ts.Version); err != nil {
+			return err
+		}
+		after := *ts
+
+		return recordAudit(ctx, tx, entity.AuditUpdate, auditChange{id: ts.ID, before: before, after: &after})
+	})
+
+	switch {
+	case errors.Is(err, repository.ErrNotFound), errors.Is(err, repository.ErrVersionConflict):
+		return fmt.Errorf("update: %w", err)
+	case err != nil:
+		return fmt.Errorf("update: %w", ErrQueryFailed)
+	}
+
+	return nil
+}
@@ -0,0 +1,377 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/pgdb"
+	"time"
+)
+
+type pgWebhookStorage struct {
+	db *pgdb.Client
+}
+
+func NewWebhookStorage(db *pgdb.Client) repository.WebhookStorage {
+	return &pgWebhookStorage{
+		db: db,
+	}
+}
+
+const webhookColumns = `id, url, secret, COALESCE(tag, ''), COALESCE(stage, ''), meta_filter, created_at`
+
+func (s *pgWebhookStorage) Create(ctx context.Context, w *entity.Webhook) (uuid.UUID, error) {
+	query := `
+		INSERT INTO webhooks (url, secret, tag, stage, meta_filter)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5)
+		RETURNING id
+	`
+
+	metaFilter, err := webhookMetaFilter(w)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create webhook: %w", err)
+	}
+
+	var id uuid.UUID
+	err = withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		return tx.QueryRow(ctx, query, w.URL, w.Secret, w.Tag, w.Stage, metaFilter).Scan(&id)
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create webhook: %w", ErrQueryFailed)
+	}
+
+	return id, nil
+}
+
+func (s *pgWebhookStorage) GetByID(ctx context.Context, id uuid.UUID) (*entity.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	var w *entity.Webhook
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		w, err = scanWebhookRow(tx.QueryRow(ctx, query, id))
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("get webhook by id: %w", repository.ErrWebhookNotFound)
+		}
+		return nil, fmt.Errorf("get webhook by id: %w", ErrQueryFailed)
+	}
+
+	return w, nil
+}
+
+func (s *pgWebhookStorage) List(ctx context.Context) ([]*entity.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY created_at, id`
+
+	var list []*entity.Webhook
+
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		rows, err := tx.Query(ctx, query)
+		if err != nil {
+			return fmt.Errorf("list webhooks: %w", ErrQueryFailed)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			w, scanErr := scanWebhookRow(rows)
+			if scanErr != nil {
+				return fmt.Errorf("list webhooks: %w", ErrScanFailed)
+			}
+
+			list = append(list, w)
+		}
+
+		if rows.Err() != nil {
+			return fmt.Errorf("list webhooks: %w", ErrRowsFailed)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *pgWebhookStorage) Update(ctx context.Context, w *entity.Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET url = $2, secret = $3, tag = NULLIF($4, ''), stage = NULLIF($5, ''), meta_filter = $6
+		WHERE id = $1
+	`
+
+	metaFilter, err := webhookMetaFilter(w)
+	if err != nil {
+		return fmt.Errorf("update webhook: %w", err)
+	}
+
+	var tag pgconn.CommandTag
+	err = withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		tag, err = tx.Exec(ctx, query, w.ID, w.URL, w.Secret, w.Tag, w.Stage, metaFilter)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("update webhook: %w", ErrQueryFailed)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("update webhook: %w", repository.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+func (s *pgWebhookStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM webhooks WHERE id = $1`
+
+	var tag pgconn.CommandTag
+	err := withTenant(ctx, s.db, func(tx *pgdb.Tx) error {
+		var err error
+		tag, err = tx.Exec(ctx, query, id)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", ErrQueryFailed)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete webhook: %w", repository.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+func (s *pgWebhookStorage) CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(deliveries))
+	webhookIDs := make([]string, len(deliveries))
+	actions := make([]string, len(deliveries))
+	payloads := make([]string, len(deliveries))
+	for i, d := range deliveries {
+		ids[i] = d.ID.String()
+		webhookIDs[i] = d.WebhookID.String()
+		actions[i] = d.Action
+		payloads[i] = string(d.Payload)
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (id, webhook_id, action, payload)
+		SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::text[], $4::jsonb[])
+	`
+
+	if _, err := s.db.Exec(ctx, query, ids, webhookIDs, actions, payloads); err != nil {
+		return fmt.Errorf("create webhook deliveries: %w", ErrQueryFailed)
+	}
+
+	return nil
+}
+
+func (s *pgWebhookStorage) ClaimDeliveries(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]*entity.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = now() + make_interval(secs => $2), updated_at = now()
+		FROM webhooks w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.action, d.payload, d.status, d.attempts, d.next_attempt_at,
+			d.last_error, d.created_at, d.updated_at, w.url, w.secret
+	`
+
+	rows, err := s.db.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", ErrQueryFailed)
+	}
+	defer rows.Close()
+
+	var list []*entity.WebhookDelivery
+
+	for rows.Next() {
+		var d entity.WebhookDelivery
+		err = rows.Scan(
+			&d.ID, &d.WebhookID, &d.Action, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&d.LastError, &d.CreatedAt, &d.UpdatedAt, &d.URL, &d.Secret,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("claim webhook deliveries: %w", ErrScanFailed)
+		}
+
+		list = append(list, &d)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", ErrRowsFailed)
+	}
+
+	return list, nil
+}
+
+func (s *pgWebhookStorage) RecordAttempt(
+	ctx context.Context,
+	d *entity.WebhookDelivery,
+	a *entity.WebhookAttempt,
+) error {
+	attemptQuery := `
+		INSERT INTO webhook_attempts (delivery_id, webhook_id, attempt, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	deliveryQuery := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, updated_at = now()
+		WHERE id = $1
+	`
+
+	err := s.db.WithTx(ctx, func(tx *pgdb.Tx) error {
+		durationMS := time.Duration(a.Duration).Milliseconds()
+		err := tx.QueryRow(ctx, attemptQuery, d.ID, d.WebhookID, a.Attempt, a.StatusCode, a.Error, durationMS).
+			Scan(&a.ID, &a.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, deliveryQuery, d.ID, d.Status, d.Attempts, d.NextAttemptAt, d.LastError)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("record webhook attempt: %w", ErrQueryFailed)
+	}
+
+	return nil
+}
+
+func (s *pgWebhookStorage) ListDeliveries(
+	ctx context.Context,
+	webhookID uuid.UUID,
+	q entity.WebhookDeliveryQuery,
+) ([]*entity.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, action, payload, status, attempts, next_attempt_at, last_error, created_at, updated_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.Query(ctx, query, webhookID, q.Status, q.Limit, q.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", ErrQueryFailed)
+	}
+	defer rows.Close()
+
+	list := []*entity.WebhookDelivery{}
+
+	for rows.Next() {
+		var d entity.WebhookDelivery
+		err = rows.Scan(
+			&d.ID, &d.WebhookID, &d.Action, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&d.LastError, &d.CreatedAt, &d.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("list webhook deliveries: %w", ErrScanFailed)
+		}
+
+		list = append(list, &d)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", ErrRowsFailed)
+	}
+
+	return list, nil
+}
+
+func (s *pgWebhookStorage) ListAttempts(
+	ctx context.Context,
+	webhookID uuid.UUID,
+	q entity.WebhookDeliveryQuery,
+) ([]*entity.WebhookAttempt, error) {
+	query := `
+		SELECT a.id, a.delivery_id, a.webhook_id, a.attempt, a.status_code, a.error, a.duration_ms, a.created_at
+		FROM webhook_attempts a
+		JOIN webhook_deliveries d ON d.id = a.delivery_id
+		WHERE a.webhook_id = $1 AND ($2 = '' OR d.status = $2)
+		ORDER BY a.id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.Query(ctx, query, webhookID, q.Status, q.Limit, q.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook attempts: %w", ErrQueryFailed)
+	}
+	defer rows.Close()
+
+	list := []*entity.WebhookAttempt{}
+
+	for rows.Next() {
+		var a entity.WebhookAttempt
+		var durationMS int64
+		err = rows.Scan(&a.ID, &a.DeliveryID, &a.WebhookID, &a.Attempt, &a.StatusCode, &a.Error, &durationMS, &a.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("list webhook attempts: %w", ErrScanFailed)
+		}
+		a.Duration = entity.Duration(time.Duration(durationMS) * time.Millisecond)
+
+		list = append(list, &a)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("list webhook attempts: %w", ErrRowsFailed)
+	}
+
+	return list, nil
+}
+
+// webhookMetaFilter returns the meta filter of w as a JSON string, or nil to store NULL.
+func webhookMetaFilter(w *entity.Webhook) (*string, error) {
+	if len(w.MetaFilter) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(w.MetaFilter)
+	if err != nil {
+		return nil, fmt.Errorf("marshal meta_filter: %w", err)
+	}
+
+	s := string(data)
+
+	return &s, nil
+}
+
+func scanWebhookRow(row pgx.Row) (*entity.Webhook, error) {
+	var w entity.Webhook
+	var metaFilter []byte
+	if err := row.Scan(&w.ID, &w.URL, &w.Secret, &w.Tag, &w.Stage, &metaFilter, &w.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if len(metaFilter) > 0 {
+		if err := json.Unmarshal(metaFilter, &w.MetaFilter); err != nil {
+			return nil, fmt.Errorf("unmarshal meta_filter: %w", ErrUnmarshalFailed)
+		}
+	}
+
+	return &w, nil
+}
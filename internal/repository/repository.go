@@ -2,25 +2,191 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"iter"
 	"time"
 )
 
-var ErrNotFound = errors.New("timestamp not found")
+var (
+	ErrNotFound        = apperr.New(apperr.KindNotFound, "timestamp_not_found", "timestamp not found")
+	ErrAlreadyExists   = apperr.New(apperr.KindConflict, "timestamp_exists", "timestamp already exists")
+	ErrVersionConflict = apperr.New(apperr.KindPreconditionFailed, "version_conflict", "timestamp version conflict")
+	ErrPolicyNotFound  = apperr.New(apperr.KindNotFound, "policy_not_found", "sla policy not found")
+	ErrPolicyExists    = apperr.New(apperr.KindConflict, "policy_exists", "sla policy already exists")
+
+	ErrCalendarNotFound = apperr.New(apperr.KindNotFound, "calendar_not_found", "calendar not found")
+	ErrCalendarExists   = apperr.New(apperr.KindConflict, "calendar_exists", "calendar already exists")
+	ErrHolidayNotFound  = apperr.New(apperr.KindNotFound, "holiday_not_found", "holiday not found")
+
+	ErrCatalogEntryNotFound = apperr.New(apperr.KindNotFound, "catalog_entry_not_found", "catalog entry not found")
+	ErrCatalogEntryExists   = apperr.New(apperr.KindConflict, "catalog_entry_exists", "catalog entry already exists")
+
+	ErrWebhookNotFound = apperr.New(apperr.KindNotFound, "webhook_not_found", "webhook not found")
+
+	ErrAPIKeyNotFound = apperr.New(apperr.KindNotFound, "api_key_not_found", "api key not found")
+)
 
 type TimestampStorage interface {
+	// Create returns the ID of the stored timestamp together with ErrAlreadyExists when one with the same
+	// external_id, tag and stage exists.
 	Create(ctx context.Context, ts *entity.Timestamp) (uuid.UUID, error)
+
+	// Upsert creates ts or updates the stored timestamp with the same external_id, tag and stage, sets ID and
+	// Version on ts and reports whether it was created.
+	Upsert(ctx context.Context, ts *entity.Timestamp) (bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Timestamp, error)
 
-	List(
+	// CreateBatch inserts tss in one round trip and sets ID and Version on each inserted timestamp. A
+	// timestamp that duplicates a stored external_id, tag and stage is skipped and keeps a nil ID.
+	CreateBatch(ctx context.Context, tss []*entity.Timestamp) error
+
+	// List returns a page of timestamps and, unless params.Count is CountNone, the number of timestamps
+	// matching the filters regardless of the page.
+	List(ctx context.Context, params *entity.ListQueryParams) ([]*entity.Timestamp, *int64, error)
+
+	ListByExternalID(ctx context.Context, externalID string) ([]*entity.Timestamp, error)
+
+	// Export streams every timestamp matching the filters, newest first, without a limit.
+	Export(
 		ctx context.Context,
-		limit, offset int,
 		externalID, tag, stage string,
 		timestampFrom, timestampTo *time.Time,
 		metaFilter map[string]any,
-	) ([]*entity.Timestamp, error)
+		filters []entity.Filter,
+	) iter.Seq2[*entity.Timestamp, error]
+
+	// Update stores the timestamp and meta of ts if its version is still expectedVersion, then sets ts.Version
+	// to the incremented version.
+	Update(ctx context.Context, ts *entity.Timestamp, expectedVersion int) error
 
+	// Delete soft-deletes the timestamp and returns it as deleted; it disappears from every read except
+	// deleted listings.
+	Delete(ctx context.Context, id uuid.UUID) (*entity.Timestamp, error)
+
+	// Restore undoes a soft delete and returns the restored timestamp. It returns ErrAlreadyExists when the
+	// same external_id, tag and stage has been recorded again since the delete.
+	Restore(ctx context.Context, id uuid.UUID) (*entity.Timestamp, error)
+
+	// PurgeDeleted permanently removes timestamps soft-deleted more than retention ago.
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+type PolicyStorage interface {
+	Create(ctx context.Context, p *entity.SLAPolicy) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.SLAPolicy, error)
+	List(ctx context.Context, tag string) ([]*entity.SLAPolicy, error)
+	Update(ctx context.Context, p *entity.SLAPolicy) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type BreachStorage interface {
+	// FindOverdue returns entities whose current stage is not closing, started between openedAfter and
+	// openedBefore and has not been recorded as breached yet.
+	FindOverdue(ctx context.Context, openedAfter, openedBefore time.Time, limit int) ([]*entity.Breach, error)
+
+	// Record stores the breach and reports false if it had already been recorded.
+	Record(ctx context.Context, b *entity.Breach) (bool, error)
+	Forget(ctx context.Context, b *entity.Breach) error
+}
+
+type CalendarStorage interface {
+	Create(ctx context.Context, c *entity.Calendar) (uuid.UUID, error)
+
+	// GetByID returns the calendar together with its holidays.
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Calendar, error)
+	List(ctx context.Context) ([]*entity.Calendar, error)
+	Update(ctx context.Context, c *entity.Calendar) error
 	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AddHolidays upserts holidays by date and returns how many rows were written.
+	AddHolidays(ctx context.Context, id uuid.UUID, holidays []entity.Holiday) (int, error)
+	DeleteHoliday(ctx context.Context, id uuid.UUID, date string) error
+}
+
+type MetricsStorage interface {
+	// SLAMetrics groups entities by tag and, if set, by the GroupBy meta key of their created stage.
+	SLAMetrics(ctx context.Context, q entity.SLAMetricsQuery) ([]*entity.SLAMetricsGroup, error)
+}
+
+type CatalogStorage interface {
+	List(ctx context.Context, kind entity.CatalogKind) ([]*entity.CatalogEntry, error)
+	Create(ctx context.Context, kind entity.CatalogKind, e *entity.CatalogEntry) error
+
+	// Update replaces the description and deprecation flag of the entry named e.Name.
+	Update(ctx context.Context, kind entity.CatalogKind, e *entity.CatalogEntry) error
+}
+
+type IdempotencyStorage interface {
+	// Reserve claims key for a request with requestHash and returns nil, or returns the record already stored
+	// under key. A record older than ttl is replaced as if it did not exist.
+	Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*entity.IdempotencyRecord, error)
+	Complete(ctx context.Context, key string, statusCode int, response []byte) error
+
+	// Release drops the reservation of a request that failed, so that it can be retried with the same key.
+	Release(ctx context.Context, key string) error
+
+	// Purge deletes the records older than ttl and returns how many were deleted.
+	Purge(ctx context.Context, ttl time.Duration) (int64, error)
+}
+
+// AuditStorage reads the audit log. Entries are written by TimestampStorage in the transaction of each
+// mutation.
+type AuditStorage interface {
+	// History returns every entry of a timestamp, oldest first.
+	History(ctx context.Context, timestampID uuid.UUID) ([]*entity.AuditEntry, error)
+
+	// List returns entries matching q, newest first.
+	List(ctx context.Context, q entity.AuditQuery) ([]*entity.AuditEntry, error)
+}
+
+type WebhookStorage interface {
+	Create(ctx context.Context, w *entity.Webhook) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Webhook, error)
+	List(ctx context.Context) ([]*entity.Webhook, error)
+	Update(ctx context.Context, w *entity.Webhook) error
+
+	// Delete removes the webhook together with its deliveries and attempts.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CreateDeliveries queues pending deliveries that are due immediately.
+	CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error
+
+	// ClaimDeliveries returns up to limit due pending deliveries with the URL and secret of their webhook,
+	// and postpones them by lease so that other dispatchers skip them while they are being sent.
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*entity.WebhookDelivery, error)
+
+	// RecordAttempt stores the attempt together with the status, attempts, next attempt and last error of d.
+	RecordAttempt(ctx context.Context, d *entity.WebhookDelivery, a *entity.WebhookAttempt) error
+
+	// ListDeliveries and ListAttempts return the deliveries and attempts of a webhook, newest first. A status
+	// in q selects the deliveries in that status and the attempts of those deliveries.
+	ListDeliveries(
+		ctx context.Context,
+		webhookID uuid.UUID,
+		q entity.WebhookDeliveryQuery,
+	) ([]*entity.WebhookDelivery, error)
+	ListAttempts(
+		ctx context.Context,
+		webhookID uuid.UUID,
+		q entity.WebhookDeliveryQuery,
+	) ([]*entity.WebhookAttempt, error)
+}
+
+type APIKeyStorage interface {
+	// Create stores k under the SHA-256 hash of its key and fills in its ID and creation time.
+	Create(ctx context.Context, k *entity.APIKey, hash string) error
+
+	// GetByHash returns the key with the given hash, revoked or not.
+	GetByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+
+	// List returns the keys of tenant, and ListAll those of every tenant.
+	List(ctx context.Context, tenant string) ([]*entity.APIKey, error)
+	ListAll(ctx context.Context) ([]*entity.APIKey, error)
+
+	// Revoke marks the key of tenant revoked; revoking it again keeps the original time. RevokeAny does the
+	// same for a key of any tenant.
+	Revoke(ctx context.Context, tenant string, id uuid.UUID) error
+	RevokeAny(ctx context.Context, id uuid.UUID) error
 }
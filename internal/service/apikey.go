@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"strings"
+)
+
+const (
+	// APIKeyPrefix starts every key, which makes leaked keys easy to find with secret scanners.
+	APIKeyPrefix = "sla_"
+
+	apiKeySecretLen = 32
+	// apiKeyDisplayLen is the length of the start of a key that is stored in the clear.
+	apiKeyDisplayLen = 12
+)
+
+type APIKeyService interface {
+	// Create mints a key in the tenant of ctx; the returned CreatedAPIKey is the only place the key itself
+	// appears. It fails with ErrForbidden for a cross-tenant scope. Create, List and Revoke fail with
+	// ErrTenantRequired if ctx has no tenant.
+	Create(ctx context.Context, k *entity.APIKey) (*entity.CreatedAPIKey, error)
+	// List and Revoke are limited to the tenant of ctx.
+	List(ctx context.Context) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+
+	// CreateAny, ListAll and RevokeAny ignore tenants. They serve the apikey command, which runs without a
+	// caller, and must not be exposed through the API. CreateAny mints k in k.Tenant, or DefaultTenant, and
+	// may grant any scope.
+	CreateAny(ctx context.Context, k *entity.APIKey) (*entity.CreatedAPIKey, error)
+	ListAll(ctx context.Context) ([]*entity.APIKey, error)
+	RevokeAny(ctx context.Context, id uuid.UUID) error
+
+	// Authenticate returns the caller holding key, or ErrUnauthenticated for an unknown or revoked key.
+	Authenticate(ctx context.Context, key string) (*entity.Principal, error)
+}
+
+type apiKeyService struct {
+	storage repository.APIKeyStorage
+	val     *validator.Validate
+}
+
+func NewAPIKeyService(storage repository.APIKeyStorage, val *validator.Validate) APIKeyService {
+	return &apiKeyService{
+		storage: storage,
+		val:     val,
+	}
+}
+
+func (s *apiKeyService) Create(ctx context.Context, k *entity.APIKey) (*entity.CreatedAPIKey, error) {
+	k.Tenant = entity.TenantFromContext(ctx)
+	if k.Tenant == "" {
+		return nil, ErrTenantRequired
+	}
+
+	for _, scope := range k.Scopes {
+		if scope.CrossTenant() {
+			return nil, fmt.Errorf("scope %s is granted only by the apikey command: %w", scope, ErrForbidden)
+		}
+	}
+
+	return s.mint(ctx, k)
+}
+
+func (s *apiKeyService) CreateAny(ctx context.Context, k *entity.APIKey) (*entity.CreatedAPIKey, error) {
+	if k.Tenant == "" {
+		k.Tenant = entity.DefaultTenant
+	}
+
+	return s.mint(ctx, k)
+}
+
+func (s *apiKeyService) mint(ctx context.Context, k *entity.APIKey) (*entity.CreatedAPIKey, error) {
+	if err := s.val.Struct(k); err != nil {
+		return nil, apperr.Validation(ErrInvalidInput, err)
+	}
+
+	for _, scope := range k.Scopes {
+		if !scope.Valid() {
+			return nil, fmt.Errorf("unknown scope %q: %w", scope, ErrInvalidInput)
+		}
+	}
+
+	secret := make([]byte, apiKeySecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generate api key: %w", err)
+	}
+
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	k.Prefix = key[:apiKeyDisplayLen]
+
+	if err := s.storage.Create(ctx, k, HashAPIKey(key)); err != nil {
+		return nil, err
+	}
+
+	return &entity.CreatedAPIKey{APIKey: *k, Key: key}, nil
+}
+
+func (s *apiKeyService) List(ctx context.Context) ([]*entity.APIKey, error) {
+	tenant := entity.TenantFromContext(ctx)
+	if tenant == "" {
+		return nil, ErrTenantRequired
+	}
+
+	return s.storage.List(ctx, tenant)
+}
+
+func (s *apiKeyService) Revoke(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	tenant := entity.TenantFromContext(ctx)
+	if tenant == "" {
+		return ErrTenantRequired
+	}
+
+	return s.storage.Revoke(ctx, tenant, id)
+}
+
+func (s *apiKeyService) ListAll(ctx context.Context) ([]*entity.APIKey, error) {
+	return s.storage.ListAll(ctx)
+}
+
+func (s *apiKeyService) RevokeAny(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	return s.storage.RevokeAny(ctx, id)
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*entity.Principal, error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, ErrUnauthenticated
+	}
+
+	k, err := s.storage.GetByHash(ctx, HashAPIKey(key))
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return nil, ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if k.RevokedAt != nil {
+		return nil, ErrUnauthenticated
+	}
+
+	return &entity.Principal{ID: k.ID.String(), Name: k.Name, Tenant: k.Tenant, Scopes: k.Scopes}, nil
+}
+
+// HashAPIKey is the stored form of key. Keys are random, so a fast unsalted hash is enough to make a
+// leaked table useless.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
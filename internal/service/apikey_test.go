@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_apiKeyService_Create(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	create := func(storageMock *smocks.APIKeyStorageMock, hash *string) {
+		storageMock.CreateMock.Set(func(_ context.Context, k *entity.APIKey, h string) error {
+			k.ID = id
+			*hash = h
+			return nil
+		})
+	}
+
+	tests := []struct {
+		name       string
+		tenant     string
+		key        *entity.APIKey
+		prepare    func(storageMock *smocks.APIKeyStorageMock, hash *string)
+		wantTenant string
+		wantErr    error
+	}{
+		{
+			name:   "Tenant Of Caller",
+			tenant: "acme",
+			key: &entity.APIKey{
+				Name:   "incident-bot",
+				Tenant: "other",
+				Scopes: []entity.Scope{entity.ScopeTimestampsWrite},
+			},
+			prepare:    create,
+			wantTenant: "acme",
+		},
+		{
+			name:    "No Tenant",
+			key:     &entity.APIKey{Name: "incident-bot", Scopes: []entity.Scope{entity.ScopeTimestampsWrite}},
+			prepare: func(storageMock *smocks.APIKeyStorageMock, hash *string) {},
+			wantErr: ErrTenantRequired,
+		},
+		{
+			name:   "Cross-Tenant Scope",
+			tenant: "acme",
+			key: &entity.APIKey{
+				Name:   "catalog-bot",
+				Scopes: []entity.Scope{entity.ScopeCatalogRead, entity.ScopeCatalogWrite},
+			},
+			prepare: func(storageMock *smocks.APIKeyStorageMock, hash *string) {},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:    "No Scopes",
+			tenant:  "acme",
+			key:     &entity.APIKey{Name: "incident-bot"},
+			prepare: func(storageMock *smocks.APIKeyStorageMock, hash *string) {},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "Unknown Scope",
+			tenant:  "acme",
+			key:     &entity.APIKey{Name: "incident-bot", Scopes: []entity.Scope{"timestamps:everything"}},
+			prepare: func(storageMock *smocks.APIKeyStorageMock, hash *string) {},
+			wantErr: ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewAPIKeyStorageMock(ctrl)
+
+			var hash string
+			tt.prepare(storageMock, &hash)
+
+			s := NewAPIKeyService(storageMock, newTestValidator())
+
+			ctx := t.Context()
+			if tt.tenant != "" {
+				ctx = entity.WithTenant(ctx, tt.tenant)
+			}
+
+			created, err := s.Create(ctx, tt.key)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, id, created.ID)
+			assert.Equal(t, tt.wantTenant, created.Tenant)
+			assert.True(t, strings.HasPrefix(created.Key, APIKeyPrefix))
+			assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+			assert.Equal(t, HashAPIKey(created.Key), hash)
+		})
+	}
+}
+
+func Test_apiKeyService_CreateAny(t *testing.T) {
+	t.Parallel()
+
+	ctrl := minimock.NewController(t)
+	storageMock := smocks.NewAPIKeyStorageMock(ctrl)
+	storageMock.CreateMock.Return(nil)
+
+	created, err := NewAPIKeyService(storageMock, newTestValidator()).CreateAny(t.Context(), &entity.APIKey{
+		Name:   "catalog-bot",
+		Scopes: []entity.Scope{entity.ScopeCatalogWrite},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.DefaultTenant, created.Tenant)
+}
+
+func Test_apiKeyService_Revoke(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	tests := []struct {
+		name    string
+		tenant  string
+		id      uuid.UUID
+		prepare func(storageMock *smocks.APIKeyStorageMock)
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			tenant: "acme",
+			id:     id,
+			prepare: func(storageMock *smocks.APIKeyStorageMock) {
+				storageMock.RevokeMock.Expect(minimock.AnyContext, "acme", id).Return(nil)
+			},
+		},
+		{
+			name:    "No Tenant",
+			id:      id,
+			prepare: func(storageMock *smocks.APIKeyStorageMock) {},
+			wantErr: ErrTenantRequired,
+		},
+		{
+			name:    "Nil ID",
+			tenant:  "acme",
+			prepare: func(storageMock *smocks.APIKeyStorageMock) {},
+			wantErr: ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewAPIKeyStorageMock(ctrl)
+			tt.prepare(storageMock)
+
+			ctx := t.Context()
+			if tt.tenant != "" {
+				ctx = entity.WithTenant(ctx, tt.tenant)
+			}
+
+			err := NewAPIKeyService(storageMock, newTestValidator()).Revoke(ctx, tt.id)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_apiKeyService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	key := APIKeyPrefix + "secret"
+	revokedAt := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	scopes := []entity.Scope{entity.ScopeTimestampsRead}
+
+	tests := []struct {
+		name    string
+		key     string
+		prepare func(ctx context.Context, storageMock *smocks.APIKeyStorageMock)
+		want    *entity.Principal
+		wantErr error
+	}{
+		{
+			name: "Success",
+			key:  key,
+			prepare: func(ctx context.Context, storageMock *smocks.APIKeyStorageMock) {
+				storageMock.GetByHashMock.Expect(ctx, HashAPIKey(key)).
+					Return(&entity.APIKey{ID: id, Name: "dashboard", Tenant: "acme", Scopes: scopes}, nil)
+			},
+			want: &entity.Principal{ID: id.String(), Name: "dashboard", Tenant: "acme", Scopes: scopes},
+		},
+		{
+			name:    "Foreign Token",
+			key:     "Bearer something-else",
+			prepare: func(ctx context.Context, storageMock *smocks.APIKeyStorageMock) {},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name: "Unknown",
+			key:  key,
+			prepare: func(ctx context.Context, storageMock *smocks.APIKeyStorageMock) {
+				storageMock.GetByHashMock.Expect(ctx, HashAPIKey(key)).
+					Return(nil, fmt.Errorf("get api key by hash: %w", repository.ErrAPIKeyNotFound))
+			},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name: "Revoked",
+			key:  key,
+			prepare: func(ctx context.Context, storageMock *smocks.APIKeyStorageMock) {
+				storageMock.GetByHashMock.Expect(ctx, HashAPIKey(key)).
+					Return(&entity.APIKey{ID: id, Scopes: scopes, RevokedAt: &revokedAt}, nil)
+			},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name: "Storage Error",
+			key:  key,
+			prepare: func(ctx context.Context, storageMock *smocks.APIKeyStorageMock) {
+				storageMock.GetByHashMock.Expect(ctx, HashAPIKey(key)).Return(nil, errors.New("storage error"))
+			},
+			wantErr: errors.New("storage error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewAPIKeyStorageMock(ctrl)
+			tt.prepare(ctx, storageMock)
+
+			s := NewAPIKeyService(storageMock, newTestValidator())
+
+			got, err := s.Authenticate(ctx, tt.key)
+			switch {
+			case tt.wantErr == nil:
+				require.NoError(t, err)
+			case errors.Is(tt.wantErr, ErrUnauthenticated):
+				assert.ErrorIs(t, err, ErrUnauthenticated)
+			default:
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+)
+
+type AuditService interface {
+	// History returns every change of a timestamp, oldest first, including those of a purged timestamp.
+	History(ctx context.Context, timestampID uuid.UUID) ([]*entity.AuditEntry, error)
+
+	// List returns the changes matching q, newest first.
+	List(ctx context.Context, q entity.AuditQuery) ([]*entity.AuditEntry, error)
+}
+
+type auditService struct {
+	storage repository.AuditStorage
+	val     *validator.Validate
+}
+
+func NewAuditService(storage repository.AuditStorage, val *validator.Validate) AuditService {
+	return &auditService{
+		storage: storage,
+		val:     val,
+	}
+}
+
+func (s *auditService) History(ctx context.Context, timestampID uuid.UUID) ([]*entity.AuditEntry, error) {
+	if timestampID == uuid.Nil {
+		return nil, ErrInvalidInput
+	}
+
+	return s.storage.History(ctx, timestampID)
+}
+
+func (s *auditService) List(ctx context.Context, q entity.AuditQuery) ([]*entity.AuditEntry, error) {
+	if err := s.val.Struct(q); err != nil {
+		return nil, apperr.Validation(ErrInvalidInput, err)
+	}
+
+	if q.From != nil && q.To != nil && q.From.After(*q.To) {
+		return nil, ErrInvalidInput
+	}
+
+	return s.storage.List(ctx, q)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_auditService_History(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	entries := []*entity.AuditEntry{{ID: 1, TimestampID: id, Operation: entity.AuditCreate, Actor: "alice"}}
+
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		prepare func(ctx context.Context, storageMock *smocks.AuditStorageMock)
+		want    []*entity.AuditEntry
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Success",
+			id:   id,
+			prepare: func(ctx context.Context, storageMock *smocks.AuditStorageMock) {
+				storageMock.HistoryMock.Expect(ctx, id).Return(entries, nil)
+			},
+			want:    entries,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Nil ID",
+			id:      uuid.Nil,
+			prepare: func(ctx context.Context, storageMock *smocks.AuditStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Storage Error",
+			id:   id,
+			prepare: func(ctx context.Context, storageMock *smocks.AuditStorageMock) {
+				storageMock.HistoryMock.Expect(ctx, id).Return(nil, errors.New("storage error"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewAuditStorageMock(ctrl)
+			tt.prepare(ctx, storageMock)
+
+			s := NewAuditService(storageMock, newTestValidator())
+
+			got, err := s.History(ctx, tt.id)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_auditService_List(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		q       entity.AuditQuery
+		prepare func(ctx context.Context, q entity.AuditQuery, storageMock *smocks.AuditStorageMock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Success",
+			q:    entity.AuditQuery{Limit: 100, Actor: "alice", From: &from, To: &to},
+			prepare: func(ctx context.Context, q entity.AuditQuery, storageMock *smocks.AuditStorageMock) {
+				storageMock.ListMock.Expect(ctx, q).Return([]*entity.AuditEntry{}, nil)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Limit Too Large",
+			q:       entity.AuditQuery{Limit: 1001},
+			prepare: func(ctx context.Context, q entity.AuditQuery, storageMock *smocks.AuditStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Inverted Window",
+			q:       entity.AuditQuery{Limit: 100, From: &to, To: &from},
+			prepare: func(ctx context.Context, q entity.AuditQuery, storageMock *smocks.AuditStorageMock) {},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewAuditStorageMock(ctrl)
+			tt.prepare(ctx, tt.q, storageMock)
+
+			s := NewAuditService(storageMock, newTestValidator())
+
+			_, err := s.List(ctx, tt.q)
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+)
+
+var (
+	ErrUnauthenticated = apperr.New(apperr.KindUnauthenticated, "unauthenticated", "missing or invalid credentials")
+	ErrForbidden       = apperr.New(apperr.KindForbidden, "insufficient_scope", "caller lacks the required scope")
+	ErrTenantRequired  = apperr.New(apperr.KindForbidden, "tenant_required", "caller has no tenant")
+)
+
+// Authenticator resolves the caller presenting a credential, or fails with ErrUnauthenticated if the
+// credential is not one it accepts.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*entity.Principal, error)
+}
+
+// Authenticators tries each Authenticator in turn until one accepts the credential. An error other than
+// ErrUnauthenticated, such as a storage failure, is returned at once.
+type Authenticators []Authenticator
+
+func (a Authenticators) Authenticate(ctx context.Context, credential string) (*entity.Principal, error) {
+	var err error = ErrUnauthenticated
+
+	for _, auth := range a {
+		p, authErr := auth.Authenticate(ctx, credential)
+		if authErr == nil {
+			return p, nil
+		}
+		if !errors.Is(authErr, ErrUnauthenticated) {
+			return nil, authErr
+		}
+		// Keep the reason a credential was rejected over a bare ErrUnauthenticated from an authenticator
+		// that does not handle it.
+		if authErr != ErrUnauthenticated {
+			err = authErr
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/ics"
+	"io"
+	"time"
+)
+
+type CalendarService interface {
+	Create(ctx context.Context, c *entity.Calendar) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Calendar, error)
+	List(ctx context.Context) ([]*entity.Calendar, error)
+	Update(ctx context.Context, c *entity.Calendar) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	AddHolidays(ctx context.Context, id uuid.UUID, holidays []entity.Holiday) (int, error)
+	DeleteHoliday(ctx context.Context, id uuid.UUID, date string) error
+
+	// ImportHolidays adds every day covered by the events of an .ics file as a holiday.
+	ImportHolidays(ctx context.Context, id uuid.UUID, r io.Reader) (int, error)
+}
+
+type calendarService struct {
+	storage repository.CalendarStorage
+	val     *validator.Validate
+}
+
+func NewCalendarService(storage repository.CalendarStorage, val *validator.Validate) CalendarService {
+	return &calendarService{
+		storage: storage,
+		val:     val,
+	}
+}
+
+func (s *calendarService) Create(ctx context.Context, c *entity.Calendar) (uuid.UUID, error) {
+	if err := s.validateCalendar(c); err != nil {
+		return uuid.Nil, err
+	}
+
+	return s.storage.Create(ctx, c)
+}
+
+func (s *calendarService) GetByID(ctx context.Context, id uuid.UUID) (*entity.Calendar, error) {
+	if id == uuid.Nil {
+		return nil, ErrInvalidInput
+	}
+
+	return s.storage.GetByID(ctx, id)
+}
+
+func (s *calendarService) List(ctx context.Context) ([]*entity.Calendar, error) {
+	return s.storage.List(ctx)
+}
+
+func (s *calendarService) Update(ctx context.Context, c *entity.Calendar) error {
+	if c.ID == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	if err := s.validateCalendar(c); err != nil {
+		return err
+	}
+
+	return s.storage.Update(ctx, c)
+}
+
+func (s *calendarService) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	return s.storage.Delete(ctx, id)
+}
+
+func (s *calendarService) AddHolidays(ctx context.Context, id uuid.UUID, holidays []entity.Holiday) (int, error) {
+	if id == uuid.Nil || len(holidays) == 0 {
+		return 0, ErrInvalidInput
+	}
+
+	for i := range holidays {
+		if err := s.val.Struct(&holidays[i]); err != nil {
+			return 0, apperr.Validation(ErrInvalidInput, err)
+		}
+	}
+
+	return s.storage.AddHolidays(ctx, id, holidays)
+}
+
+func (s *calendarService) DeleteHoliday(ctx context.Context, id uuid.UUID, date string) error {
+	if id == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	if _, err := time.Parse(entity.DateLayout, date); err != nil {
+		return ErrInvalidInput
+	}
+
+	return s.storage.DeleteHoliday(ctx, id, date)
+}
+
+func (s *calendarService) ImportHolidays(ctx context.Context, id uuid.UUID, r io.Reader) (int, error) {
+	events, err := ics.Parse(r)
+	if err != nil {
+		return 0, ErrInvalidInput
+	}
+
+	var holidays []entity.Holiday
+	for _, event := range events {
+		for _, day := range event.Days() {
+			holidays = append(holidays, entity.Holiday{Date: day.Format(entity.DateLayout), Name: event.Summary})
+		}
+	}
+
+	return s.AddHolidays(ctx, id, holidays)
+}
+
+func (s *calendarService) validateCalendar(c *entity.Calendar) error {
+	if err := s.val.Struct(c); err != nil {
+		return apperr.Validation(ErrInvalidInput, err)
+	}
+
+	for day, wh := range c.WorkingHours {
+		if !isWeekdayKey(day) {
+			return ErrInvalidInput
+		}
+
+		if err := s.val.Struct(wh); err != nil {
+			return apperr.Validation(ErrInvalidInput, err)
+		}
+
+		if wh.Start >= wh.End {
+			return ErrInvalidInput
+		}
+	}
+
+	return nil
+}
+
+func isWeekdayKey(key string) bool {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if entity.WeekdayKey(day) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// loadCalendar returns nil when no calendar was requested.
+func loadCalendar(ctx context.Context, storage repository.CalendarStorage, id uuid.UUID) (*entity.Calendar, error) {
+	if id == uuid.Nil {
+		return nil, nil
+	}
+
+	return storage.GetByID(ctx, id)
+}
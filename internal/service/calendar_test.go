@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func Test_calendarService_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cal     *entity.Calendar
+		prepare func(ctx context.Context, cal *entity.Calendar, storageMock *smocks.CalendarStorageMock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Success",
+			cal: &entity.Calendar{
+				Name:         "eu-support",
+				Timezone:     "Europe/Berlin",
+				WorkingHours: map[string]entity.WorkingHours{"monday": {Start: "09:00", End: "18:00"}},
+			},
+			prepare: func(ctx context.Context, cal *entity.Calendar, storageMock *smocks.CalendarStorageMock) {
+				storageMock.CreateMock.Expect(ctx, cal).Return(uuid.New(), nil)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Unknown Timezone",
+			cal:     &entity.Calendar{Name: "mars", Timezone: "Mars/Base"},
+			prepare: func(ctx context.Context, cal *entity.Calendar, storageMock *smocks.CalendarStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Unknown Weekday",
+			cal: &entity.Calendar{
+				Name:         "eu-support",
+				Timezone:     "Europe/Berlin",
+				WorkingHours: map[string]entity.WorkingHours{"funday": {Start: "09:00", End: "18:00"}},
+			},
+			prepare: func(ctx context.Context, cal *entity.Calendar, storageMock *smocks.CalendarStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "End Before Start",
+			cal: &entity.Calendar{
+				Name:         "eu-support",
+				Timezone:     "Europe/Berlin",
+				WorkingHours: map[string]entity.WorkingHours{"monday": {Start: "18:00", End: "09:00"}},
+			},
+			prepare: func(ctx context.Context, cal *entity.Calendar, storageMock *smocks.CalendarStorageMock) {},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewCalendarStorageMock(ctrl)
+
+			s := &calendarService{
+				storage: storageMock,
+				val:     newTestValidator(),
+			}
+
+			tt.prepare(ctx, tt.cal, storageMock)
+
+			_, err := s.Create(ctx, tt.cal)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func Test_calendarService_ImportHolidays(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	tests := []struct {
+		name    string
+		data    string
+		prepare func(ctx context.Context, storageMock *smocks.CalendarStorageMock)
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Multi-Day Event",
+			data: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20251225\nDTEND;VALUE=DATE:20251227\n" +
+				"SUMMARY:Christmas\nEND:VEVENT\nEND:VCALENDAR\n",
+			prepare: func(ctx context.Context, storageMock *smocks.CalendarStorageMock) {
+				storageMock.AddHolidaysMock.Expect(ctx, id, []entity.Holiday{
+					{Date: "2025-12-25", Name: "Christmas"},
+					{Date: "2025-12-26", Name: "Christmas"},
+				}).Return(2, nil)
+			},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "No Events",
+			data:    "BEGIN:VCALENDAR\nEND:VCALENDAR\n",
+			prepare: func(ctx context.Context, storageMock *smocks.CalendarStorageMock) {},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Malformed File",
+			data:    "BEGIN:VEVENT\nDTSTART:not-a-date\nEND:VEVENT\n",
+			prepare: func(ctx context.Context, storageMock *smocks.CalendarStorageMock) {},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewCalendarStorageMock(ctrl)
+
+			s := &calendarService{
+				storage: storageMock,
+				val:     newTestValidator(),
+			}
+
+			tt.prepare(ctx, storageMock)
+
+			got, err := s.ImportHolidays(ctx, id, strings.NewReader(tt.data))
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
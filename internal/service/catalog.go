@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"github.com/go-playground/validator/v10"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"log/slog"
+	"regexp"
+	"sync/atomic"
+)
+
+var catalogNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type CatalogService interface {
+	List(ctx context.Context, kind entity.CatalogKind) ([]*entity.CatalogEntry, error)
+	Create(ctx context.Context, kind entity.CatalogKind, e *entity.CatalogEntry) error
+	Update(ctx context.Context, kind entity.CatalogKind, e *entity.CatalogEntry) error
+
+	// Refresh reloads the in-process copy of the catalog used for validation. Changes made through this
+	// service refresh it immediately; changes made by other instances are picked up by the next call.
+	Refresh(ctx context.Context) error
+}
+
+// catalogSnapshot maps every entry name of a kind to whether it is deprecated.
+type catalogSnapshot map[entity.CatalogKind]map[string]bool
+
+type catalogService struct {
+	storage  repository.CatalogStorage
+	val      *validator.Validate
+	snapshot atomic.Pointer[catalogSnapshot]
+}
+
+// NewCatalogService registers the catalog-driven validation tags on val: "tag" and "stage" accept
+// active entries only, "known_tag" and "known_stage" also accept deprecated ones. Validation rejects
+// everything until the first Refresh.
+func NewCatalogService(storage repository.CatalogStorage, val *validator.Validate) CatalogService {
+	s := &catalogService{
+		storage: storage,
+		val:     val,
+	}
+	s.snapshot.Store(&catalogSnapshot{})
+	s.registerValidations(val)
+
+	return s
+}
+
+func (s *catalogService) List(ctx context.Context, kind entity.CatalogKind) ([]*entity.CatalogEntry, error) {
+	if !validCatalogKind(kind) {
+		return nil, ErrInvalidInput
+	}
+
+	return s.storage.List(ctx, kind)
+}
+
+func (s *catalogService) Create(ctx context.Context, kind entity.CatalogKind, e *entity.CatalogEntry) error {
+	if !validCatalogKind(kind) || !catalogNamePattern.MatchString(e.Name) {
+		return ErrInvalidInput
+	}
+
+	if err := s.val.Struct(e); err != nil {
+		return apperr.Validation(ErrInvalidInput, err)
+	}
+
+	if err := s.storage.Create(ctx, kind, e); err != nil {
+		return err
+	}
+
+	s.refreshAfterChange(ctx)
+
+	return nil
+}
+
+func (s *catalogService) Update(ctx context.Context, kind entity.CatalogKind, e *entity.CatalogEntry) error {
+	if !validCatalogKind(kind) {
+		return ErrInvalidInput
+	}
+
+	if err := s.val.Struct(e); err != nil {
+		return apperr.Validation(ErrInvalidInput, err)
+	}
+
+	if err := s.storage.Update(ctx, kind, e); err != nil {
+		return err
+	}
+
+	s.refreshAfterChange(ctx)
+
+	return nil
+}
+
+func (s *catalogService) Refresh(ctx context.Context) error {
+	snapshot := make(catalogSnapshot)
+
+	for _, kind := range []entity.CatalogKind{entity.CatalogTags, entity.CatalogStages} {
+		list, err := s.storage.List(ctx, kind)
+		if err != nil {
+			return err
+		}
+
+		entries := make(map[string]bool, len(list))
+		for _, e := range list {
+			entries[e.Name] = e.Deprecated
+		}
+		snapshot[kind] = entries
+	}
+
+	s.snapshot.Store(&snapshot)
+
+	return nil
+}
+
+// refreshAfterChange only logs a failure: the change is already stored and the periodic refresh will
+// pick it up.
+func (s *catalogService) refreshAfterChange(ctx context.Context) {
+	if err := s.Refresh(ctx); err != nil {
+		slog.Warn("refresh catalog failed", slog.Any("error", err))
+	}
+}
+
+func (s *catalogService) registerValidations(val *validator.Validate) {
+	register := func(name string, kind entity.CatalogKind, allowDeprecated bool) {
+		_ = val.RegisterValidation(name, func(fl validator.FieldLevel) bool {
+			deprecated, ok := (*s.snapshot.Load())[kind][fl.Field().String()]
+			return ok && (allowDeprecated || !deprecated)
+		})
+	}
+
+	register("tag", entity.CatalogTags, false)
+	register("stage", entity.CatalogStages, false)
+	register("known_tag", entity.CatalogTags, true)
+	register("known_stage", entity.CatalogStages, true)
+}
+
+func validCatalogKind(kind entity.CatalogKind) bool {
+	return kind == entity.CatalogTags || kind == entity.CatalogStages
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/gojuno/minimock/v3"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_catalogService_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		kind    entity.CatalogKind
+		entry   *entity.CatalogEntry
+		prepare func(ctx context.Context, e *entity.CatalogEntry, storageMock *smocks.CatalogStorageMock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "Success",
+			kind:  entity.CatalogTags,
+			entry: &entity.CatalogEntry{Name: "security", Description: "Security incidents"},
+			prepare: func(ctx context.Context, e *entity.CatalogEntry, storageMock *smocks.CatalogStorageMock) {
+				storageMock.CreateMock.Expect(ctx, entity.CatalogTags, e).Return(nil)
+				storageMock.ListMock.Return(nil, nil)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Invalid Name",
+			kind:    entity.CatalogTags,
+			entry:   &entity.CatalogEntry{Name: "Security Team"},
+			prepare: func(ctx context.Context, e *entity.CatalogEntry, storageMock *smocks.CatalogStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Unknown Kind",
+			kind:    "colors",
+			entry:   &entity.CatalogEntry{Name: "red"},
+			prepare: func(ctx context.Context, e *entity.CatalogEntry, storageMock *smocks.CatalogStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:  "Already Exists",
+			kind:  entity.CatalogStages,
+			entry: &entity.CatalogEntry{Name: "created"},
+			prepare: func(ctx context.Context, e *entity.CatalogEntry, storageMock *smocks.CatalogStorageMock) {
+				storageMock.CreateMock.Expect(ctx, entity.CatalogStages, e).Return(repository.ErrCatalogEntryExists)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, repository.ErrCatalogEntryExists)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewCatalogStorageMock(ctrl)
+			tt.prepare(ctx, tt.entry, storageMock)
+
+			s := NewCatalogService(storageMock, validator.New())
+
+			tt.wantErr(t, s.Create(ctx, tt.kind, tt.entry))
+		})
+	}
+}
+
+func Test_catalogService_Validation(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	ctrl := minimock.NewController(t)
+	storageMock := smocks.NewCatalogStorageMock(ctrl)
+	storageMock.ListMock.When(ctx, entity.CatalogTags).Then([]*entity.CatalogEntry{
+		{Name: "incident"},
+		{Name: "legacy", Deprecated: true},
+	}, nil)
+	storageMock.ListMock.When(ctx, entity.CatalogStages).Then([]*entity.CatalogEntry{{Name: "created"}}, nil)
+
+	val := validator.New()
+	s := NewCatalogService(storageMock, val)
+
+	// Nothing validates before the catalog is loaded.
+	assert.Error(t, val.Var("incident", "tag"))
+
+	require.NoError(t, s.Refresh(ctx))
+
+	assert.NoError(t, val.Var("incident", "tag"))
+	assert.Error(t, val.Var("legacy", "tag"))
+	assert.NoError(t, val.Var("legacy", "known_tag"))
+	assert.Error(t, val.Var("unknown", "known_tag"))
+	assert.NoError(t, val.Var(entity.StageCreated, "stage"))
+	assert.Error(t, val.Var("incident", "stage"))
+}
+
+func Test_catalogService_Refresh(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	ctrl := minimock.NewController(t)
+	storageMock := smocks.NewCatalogStorageMock(ctrl)
+	storageMock.ListMock.Expect(ctx, entity.CatalogTags).Return(nil, errors.New("storage error"))
+
+	s := NewCatalogService(storageMock, validator.New())
+
+	assert.Error(t, s.Refresh(ctx))
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"slices"
+	"time"
+)
+
+// interval is a half-open [from, to) period of time.
+type interval struct {
+	from time.Time
+	to   time.Time
+}
+
+// slaClock measures stage durations in wall-clock time and, when a calendar is set, in business time.
+// Time spent inside pauses is never counted.
+type slaClock struct {
+	calendar *entity.Calendar
+	pauses   []interval
+}
+
+func (c slaClock) elapsed(from, to time.Time) entity.Duration {
+	return entity.Duration(to.Sub(from)) - c.paused(from, to)
+}
+
+// business returns nil without a calendar so that business durations are omitted from responses.
+func (c slaClock) business(from, to time.Time) *entity.Duration {
+	if c.calendar == nil {
+		return nil
+	}
+
+	d := c.calendar.BusinessDuration(from, to)
+	for _, p := range c.overlaps(from, to) {
+		d -= c.calendar.BusinessDuration(p.from, p.to)
+	}
+
+	business := entity.Duration(d)
+
+	return &business
+}
+
+// paused returns the wall-clock time between from and to that the clock was stopped.
+func (c slaClock) paused(from, to time.Time) entity.Duration {
+	var d time.Duration
+	for _, p := range c.overlaps(from, to) {
+		d += p.to.Sub(p.from)
+	}
+
+	return entity.Duration(d)
+}
+
+func (c slaClock) overlaps(from, to time.Time) []interval {
+	var out []interval
+
+	for _, p := range c.pauses {
+		start, end := maxTime(p.from, from), minTime(p.to, to)
+		if start.Before(end) {
+			out = append(out, interval{from: start, to: end})
+		}
+	}
+
+	return out
+}
+
+// pausedIntervals pairs every on_hold with the next resumed of the same tag; a pause that has not
+// been resumed yet lasts until now. Pauses of different tags are merged so no time is subtracted twice.
+func pausedIntervals(list []*entity.Timestamp, now time.Time) []interval {
+	events := make([]*entity.Timestamp, 0, len(list))
+	for _, ts := range list {
+		if entity.IsPauseStage(ts.Stage) {
+			events = append(events, ts)
+		}
+	}
+	slices.SortStableFunc(events, func(a, b *entity.Timestamp) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	var pauses []interval
+	held := make(map[entity.Tag]time.Time)
+
+	for _, ts := range events {
+		from, onHold := held[ts.Tag]
+
+		switch {
+		case ts.Stage == entity.StageOnHold && !onHold:
+			held[ts.Tag] = ts.Timestamp
+		case ts.Stage == entity.StageResumed && onHold:
+			pauses = append(pauses, interval{from: from, to: ts.Timestamp})
+			delete(held, ts.Tag)
+		}
+	}
+
+	for _, from := range held {
+		if from.Before(now) {
+			pauses = append(pauses, interval{from: from, to: now})
+		}
+	}
+
+	return mergeIntervals(pauses)
+}
+
+func mergeIntervals(list []interval) []interval {
+	slices.SortFunc(list, func(a, b interval) int {
+		return a.from.Compare(b.from)
+	})
+
+	var merged []interval
+
+	for _, iv := range list {
+		last := len(merged) - 1
+		if last >= 0 && !iv.from.After(merged[last].to) {
+			merged[last].to = maxTime(merged[last].to, iv.to)
+			continue
+		}
+
+		merged = append(merged, iv)
+	}
+
+	return merged
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_pausedIntervals(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 7, 13, 15, 0, 0, 0, time.UTC)
+	now := start.Add(10 * time.Hour)
+
+	at := func(tag entity.Tag, stage entity.Stage, offset time.Duration) *entity.Timestamp {
+		return &entity.Timestamp{ExternalID: "INC-1", Tag: tag, Stage: stage, Timestamp: start.Add(offset)}
+	}
+
+	tests := []struct {
+		name string
+		list []*entity.Timestamp
+		want []interval
+	}{
+		{
+			name: "No Pauses",
+			list: []*entity.Timestamp{at(entity.TagIncident, entity.StageCreated, 0)},
+			want: nil,
+		},
+		{
+			name: "Repeated Pauses",
+			list: []*entity.Timestamp{
+				at(entity.TagIncident, entity.StageResumed, 2*time.Hour),
+				at(entity.TagIncident, entity.StageOnHold, time.Hour),
+				at(entity.TagIncident, entity.StageOnHold, 3*time.Hour),
+				at(entity.TagIncident, entity.StageResumed, 4*time.Hour),
+			},
+			want: []interval{
+				{from: start.Add(time.Hour), to: start.Add(2 * time.Hour)},
+				{from: start.Add(3 * time.Hour), to: start.Add(4 * time.Hour)},
+			},
+		},
+		{
+			name: "Open Pause Lasts Until Now",
+			list: []*entity.Timestamp{at(entity.TagIncident, entity.StageOnHold, time.Hour)},
+			want: []interval{{from: start.Add(time.Hour), to: now}},
+		},
+		{
+			name: "Overlapping Tags Are Merged",
+			list: []*entity.Timestamp{
+				at(entity.TagIncident, entity.StageOnHold, time.Hour),
+				at(entity.TagAlert, entity.StageOnHold, 90*time.Minute),
+				at(entity.TagIncident, entity.StageResumed, 2*time.Hour),
+				at(entity.TagAlert, entity.StageResumed, 3*time.Hour),
+			},
+			want: []interval{{from: start.Add(time.Hour), to: start.Add(3 * time.Hour)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, pausedIntervals(tt.list, now))
+		})
+	}
+}
+
+func Test_slaClock(t *testing.T) {
+	t.Parallel()
+
+	// Monday, 09:00-18:00 working hours
+	start := time.Date(2025, 7, 14, 9, 0, 0, 0, time.UTC)
+	clock := slaClock{
+		calendar: &entity.Calendar{
+			Timezone:     "UTC",
+			WorkingHours: map[string]entity.WorkingHours{"monday": {Start: "09:00", End: "18:00"}},
+		},
+		pauses: []interval{{from: start.Add(2 * time.Hour), to: start.Add(12 * time.Hour)}},
+	}
+
+	end := start.Add(14 * time.Hour)
+	assert.Equal(t, entity.Duration(4*time.Hour), clock.elapsed(start, end))
+	assert.Equal(t, entity.Duration(10*time.Hour), clock.paused(start, end))
+	assert.Equal(t, entity.Duration(2*time.Hour), *clock.business(start, end))
+}
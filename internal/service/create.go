@@ -3,30 +3,160 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
 	"log/slog"
+	"slices"
 )
 
 func (s *timestampService) Create(ctx context.Context, ts *entity.Timestamp) (uuid.UUID, error) {
 	if err := s.val.Struct(ts); err != nil {
-		return uuid.Nil, ErrInvalidInput
+		return uuid.Nil, apperr.Validation(ErrInvalidInput, err)
+	}
+
+	if s.transitions.needsHistory(ts) {
+		if err := s.checkHistory(ctx, ts); err != nil {
+			return uuid.Nil, err
+		}
 	}
 
 	id, err := s.storage.Create(ctx, ts)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return id, err
+	}
 	if err != nil {
 		return uuid.Nil, err
 	}
 
 	ts.ID = id
+	s.publish(ctx, "create", ts)
+
+	return id, nil
+}
 
-	event := map[string]any{"action": "create", "data": ts}
+func (s *timestampService) Upsert(ctx context.Context, ts *entity.Timestamp) (bool, error) {
+	if err := s.val.Struct(ts); err != nil {
+		return false, apperr.Validation(ErrInvalidInput, err)
+	}
+
+	if s.transitions.needsHistory(ts) {
+		if err := s.checkHistory(ctx, ts); err != nil {
+			return false, err
+		}
+	}
+
+	created, err := s.storage.Upsert(ctx, ts)
+	if err != nil {
+		return false, err
+	}
+
+	if created {
+		s.publish(ctx, "create", ts)
+	} else {
+		s.publish(ctx, "update", ts)
+	}
+
+	return created, nil
+}
+
+func (s *timestampService) publish(ctx context.Context, action string, ts *entity.Timestamp) {
+	event := map[string]any{"action": action, "tenant_id": entity.TenantFromContext(ctx), "data": ts}
 	msg, err := json.Marshal(event)
 	if err != nil {
 		slog.Error("marshal event failed", slog.Any("error", err))
-		return id, nil
+		return
 	}
 	_ = s.broker.Publish(ctx, msg)
+}
 
-	return id, nil
+// checkHistory validates ts against the stages already recorded for its external_id. In warn mode a
+// transition violation is recorded in the meta of ts instead of being returned, replacing any earlier one.
+func (s *timestampService) checkHistory(ctx context.Context, ts *entity.Timestamp) error {
+	stored, err := s.storage.ListByExternalID(ctx, ts.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	// An updated timestamp is validated against the others, not against its stored self.
+	list := slices.DeleteFunc(stored, func(other *entity.Timestamp) bool {
+		return ts.ID != uuid.Nil && other.ID == ts.ID
+	})
+
+	return s.checkAgainst(list, ts)
+}
+
+// checkAgainst is checkHistory with the other stages of the external_id of ts already loaded. A duplicate
+// is not checked: the storage reports it as a conflict, or updates the stored timestamp on upsert.
+func (s *timestampService) checkAgainst(list []*entity.Timestamp, ts *entity.Timestamp) error {
+	if isDuplicate(list, ts) {
+		return nil
+	}
+
+	if entity.IsPauseStage(ts.Stage) {
+		if err := validatePause(list, ts); err != nil {
+			return err
+		}
+	}
+
+	switch s.transitions.Mode {
+	case entity.TransitionModeOff:
+		return nil
+	case entity.TransitionModeWarn:
+		delete(ts.Meta, entity.TransitionWarningKey)
+		if err := s.transitions.checkTransition(list, ts); err != nil {
+			if ts.Meta == nil {
+				ts.Meta = make(map[string]any)
+			}
+			ts.Meta[entity.TransitionWarningKey] = err.Error()
+		}
+
+		return nil
+	default:
+		return s.transitions.checkTransition(list, ts)
+	}
+}
+
+// isDuplicate reports whether list already has the tag and stage of ts. Pause stages may repeat.
+func isDuplicate(list []*entity.Timestamp, ts *entity.Timestamp) bool {
+	return !entity.IsPauseStage(ts.Stage) && slices.ContainsFunc(list, func(other *entity.Timestamp) bool {
+		return other.Tag == ts.Tag && other.Stage == ts.Stage
+	})
+}
+
+// validatePause checks that, once ts is inserted in time order, the on_hold and resumed stages of its
+// external_id and tag alternate starting with on_hold.
+func validatePause(list []*entity.Timestamp, ts *entity.Timestamp) error {
+	events := []*entity.Timestamp{ts}
+	for _, prev := range list {
+		if prev.Tag == ts.Tag && entity.IsPauseStage(prev.Stage) {
+			events = append(events, prev)
+		}
+	}
+	slices.SortStableFunc(events, func(a, b *entity.Timestamp) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	want := entity.StageOnHold
+	for _, e := range events {
+		if e.Stage != want {
+			return fmt.Errorf("%s must follow %s: %w", e.Stage, otherPauseStage(e.Stage), ErrInvalidInput)
+		}
+
+		want = otherPauseStage(want)
+	}
+
+	return nil
+}
+
+// otherPauseStage returns on_hold for resumed and resumed for on_hold.
+func otherPauseStage(stage entity.Stage) entity.Stage {
+	if stage == entity.StageOnHold {
+		return entity.StageResumed
+	}
+
+	return entity.StageOnHold
 }
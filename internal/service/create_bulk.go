@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"log/slog"
+	"slices"
+)
+
+// BulkMaxItems caps a bulk create request; larger backfills are sent in several requests.
+const BulkMaxItems = 1000
+
+func (s *timestampService) CreateBulk(ctx context.Context, items []*entity.Timestamp) ([]entity.BulkItemResult, error) {
+	if len(items) == 0 || len(items) > BulkMaxItems {
+		return nil, fmt.Errorf("bulk must contain 1 to %d items: %w", BulkMaxItems, ErrInvalidInput)
+	}
+
+	results := make([]entity.BulkItemResult, len(items))
+	for i := range results {
+		results[i].Index = i
+	}
+
+	batch, err := s.acceptBulk(ctx, items, results)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(batch) > 0 {
+		if err = s.storage.CreateBatch(ctx, batch); err != nil {
+			return nil, err
+		}
+	}
+
+	created := make([]*entity.Timestamp, 0, len(batch))
+	for i, ts := range items {
+		if results[i].Status != "" {
+			continue
+		}
+
+		if ts.ID == uuid.Nil {
+			results[i].Status = entity.BulkItemDuplicate
+			results[i].Error = repository.ErrAlreadyExists.Error()
+			continue
+		}
+
+		id := ts.ID
+		results[i].Status = entity.BulkItemCreated
+		results[i].ID = &id
+		created = append(created, ts)
+	}
+
+	s.publishBulk(ctx, created)
+
+	return results, nil
+}
+
+// acceptBulk marks the items that fail validation as invalid or duplicate in results and returns the others in request
+// order. Items are checked in time order so that a backfill does not depend on the order it is sent in,
+// and each accepted item becomes history for the later ones.
+func (s *timestampService) acceptBulk(
+	ctx context.Context,
+	items []*entity.Timestamp,
+	results []entity.BulkItemResult,
+) ([]*entity.Timestamp, error) {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return items[a].Timestamp.Compare(items[b].Timestamp)
+	})
+
+	history := make(map[string][]*entity.Timestamp)
+	for _, i := range order {
+		err := s.checkBulkItem(ctx, items[i], history)
+		switch {
+		case errors.Is(err, repository.ErrAlreadyExists):
+			results[i].Status = entity.BulkItemDuplicate
+			results[i].Error = err.Error()
+		case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrInvalidTransition):
+			results[i].Status = entity.BulkItemInvalid
+			results[i].Error = err.Error()
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	batch := make([]*entity.Timestamp, 0, len(items))
+	for i, ts := range items {
+		if results[i].Status == "" {
+			batch = append(batch, ts)
+		}
+	}
+
+	return batch, nil
+}
+
+// checkBulkItem validates ts and checks it against the stored stages of its external_id plus the items of
+// the same request accepted so far. The stored stages are loaded once per external_id.
+func (s *timestampService) checkBulkItem(
+	ctx context.Context,
+	ts *entity.Timestamp,
+	history map[string][]*entity.Timestamp,
+) error {
+	if err := s.val.Struct(ts); err != nil {
+		return fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	if !s.transitions.needsHistory(ts) {
+		return nil
+	}
+
+	list, ok := history[ts.ExternalID]
+	if !ok {
+		stored, err := s.storage.ListByExternalID(ctx, ts.ExternalID)
+		if err != nil {
+			return err
+		}
+		history[ts.ExternalID] = stored
+		list = stored
+	}
+
+	if isDuplicate(list, ts) {
+		return repository.ErrAlreadyExists
+	}
+
+	if err := s.checkAgainst(list, ts); err != nil {
+		return err
+	}
+
+	history[ts.ExternalID] = append(list, ts)
+
+	return nil
+}
+
+func (s *timestampService) publishBulk(ctx context.Context, created []*entity.Timestamp) {
+	if len(created) == 0 {
+		return
+	}
+
+	event := map[string]any{"action": "bulk_create", "tenant_id": entity.TenantFromContext(ctx), "data": created}
+	msg, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("marshal event failed", slog.Any("error", err))
+		return
+	}
+	_ = s.broker.Publish(ctx, msg)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	bmocks "github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_timestampService_CreateBulk(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2025, 7, 13, 9, 0, 0, 0, time.UTC)
+	newItems := func() []*entity.Timestamp {
+		return []*entity.Timestamp{
+			{ExternalID: "ext-1", Timestamp: base.Add(time.Hour), Tag: entity.TagIncident, Stage: entity.StageAcknowledged},
+			{ExternalID: "ext-1", Timestamp: base, Tag: entity.TagIncident, Stage: entity.StageCreated},
+			{ExternalID: "ext-2", Timestamp: base, Tag: "unknown", Stage: entity.StageCreated},
+			{ExternalID: "ext-2", Timestamp: base, Tag: entity.TagIncident, Stage: entity.StageCreated},
+			{ExternalID: "ext-3", Timestamp: base, Tag: entity.TagIncident, Stage: entity.StageCreated},
+		}
+	}
+
+	type fields struct {
+		storageMock *smocks.TimestampStorageMock
+		brokerMock  *bmocks.BrokerMock
+	}
+	tests := []struct {
+		name    string
+		items   []*entity.Timestamp
+		prepare func(ctx context.Context, f *fields)
+		want    []entity.BulkItemStatus
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "Mixed Results",
+			items: newItems(),
+			prepare: func(ctx context.Context, f *fields) {
+				f.storageMock.ListByExternalIDMock.Set(func(_ context.Context, externalID string) ([]*entity.Timestamp, error) {
+					if externalID == "ext-2" {
+						return []*entity.Timestamp{{ExternalID: "ext-2", Tag: entity.TagIncident, Stage: entity.StageCreated}}, nil
+					}
+					return nil, nil
+				})
+				f.storageMock.CreateBatchMock.Set(func(_ context.Context, tss []*entity.Timestamp) error {
+					require.Len(t, tss, 3)
+					for _, ts := range tss {
+						// ext-3 loses a race with a concurrent insert.
+						if ts.ExternalID != "ext-3" {
+							ts.ID = uuid.New()
+						}
+					}
+					return nil
+				})
+				f.brokerMock.PublishMock.Set(func(_ context.Context, msg []byte) error {
+					var event struct {
+						Action string             `json:"action"`
+						Tenant string             `json:"tenant_id"`
+						Data   []entity.Timestamp `json:"data"`
+					}
+					require.NoError(t, json.Unmarshal(msg, &event))
+					assert.Equal(t, "bulk_create", event.Action)
+					assert.Equal(t, "acme", event.Tenant)
+					assert.Len(t, event.Data, 2)
+					return nil
+				})
+			},
+			want: []entity.BulkItemStatus{
+				entity.BulkItemCreated,
+				entity.BulkItemCreated,
+				entity.BulkItemInvalid,
+				entity.BulkItemDuplicate,
+				entity.BulkItemDuplicate,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Empty",
+			items:   nil,
+			prepare: func(ctx context.Context, f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Too Many Items",
+			items: func() []*entity.Timestamp {
+				return make([]*entity.Timestamp, BulkMaxItems+1)
+			}(),
+			prepare: func(ctx context.Context, f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:  "Storage Error",
+			items: newItems()[1:2],
+			prepare: func(ctx context.Context, f *fields) {
+				f.storageMock.ListByExternalIDMock.Return(nil, nil)
+				f.storageMock.CreateBatchMock.Return(errors.New("storage error"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := entity.WithTenant(t.Context(), "acme")
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewTimestampStorageMock(ctrl)
+			brokerMock := bmocks.NewBrokerMock(ctrl)
+
+			s := timestampService{
+				storage:     storageMock,
+				val:         newTestValidator(),
+				broker:      brokerMock,
+				transitions: TransitionRules{Mode: entity.TransitionModeStrict},
+			}
+
+			tt.prepare(ctx, &fields{
+				storageMock: storageMock,
+				brokerMock:  brokerMock,
+			})
+
+			got, err := s.CreateBulk(ctx, tt.items)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+
+			statuses := make([]entity.BulkItemStatus, len(got))
+			for i, r := range got {
+				assert.Equal(t, i, r.Index)
+				assert.Equal(t, r.Status == entity.BulkItemCreated, r.ID != nil)
+				statuses[i] = r.Status
+			}
+			assert.Equal(t, tt.want, statuses)
+		})
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"github.com/gojuno/minimock/v3"
 	"github.com/google/uuid"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
 	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
 	bmocks "github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
 )
@@ -28,6 +30,7 @@ func Test_timestampService_Create(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
+		mode    entity.TransitionMode
 		prepare func(ctx context.Context, a args, f *fields)
 		args    args
 		want    uuid.UUID
@@ -44,11 +47,13 @@ func Test_timestampService_Create(t *testing.T) {
 				},
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return(nil, nil)
+
 				id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
 				f.storageMock.CreateMock.Expect(ctx, a.ts).Return(id, nil)
 
 				a.ts.ID = id
-				event := map[string]any{"action": "create", "data": a.ts}
+				event := map[string]any{"action": "create", "tenant_id": "acme", "data": a.ts}
 				msg, _ := json.Marshal(event)
 				f.brokerMock.PublishMock.Expect(ctx, msg).Return(nil)
 			},
@@ -70,6 +75,207 @@ func Test_timestampService_Create(t *testing.T) {
 			want:    uuid.Nil,
 			wantErr: assert.Error,
 		},
+		{
+			name: "Resume After Hold",
+			args: args{
+				ts: &entity.Timestamp{
+					ExternalID: "test",
+					Timestamp:  time.Now(),
+					Tag:        entity.TagIncident,
+					Stage:      entity.StageResumed,
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return([]*entity.Timestamp{
+					{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageCreated, Timestamp: time.Now().Add(-time.Hour)},
+					{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageOnHold, Timestamp: time.Now().Add(-time.Minute)},
+				}, nil)
+
+				id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+				f.storageMock.CreateMock.Expect(ctx, a.ts).Return(id, nil)
+
+				a.ts.ID = id
+				event := map[string]any{"action": "create", "tenant_id": "acme", "data": a.ts}
+				msg, _ := json.Marshal(event)
+				f.brokerMock.PublishMock.Expect(ctx, msg).Return(nil)
+			},
+			want:    uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Resume Without Hold",
+			args: args{
+				ts: &entity.Timestamp{
+					ExternalID: "test",
+					Timestamp:  time.Now(),
+					Tag:        entity.TagIncident,
+					Stage:      entity.StageResumed,
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return([]*entity.Timestamp{
+					// a hold of another tag does not pause this one
+					{ExternalID: "test", Tag: entity.TagAlert, Stage: entity.StageOnHold, Timestamp: time.Now().Add(-time.Minute)},
+				}, nil)
+			},
+			want: uuid.Nil,
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrInvalidInput)
+			},
+		},
+		{
+			name: "Hold While On Hold",
+			args: args{
+				ts: &entity.Timestamp{
+					ExternalID: "test",
+					Timestamp:  time.Now(),
+					Tag:        entity.TagIncident,
+					Stage:      entity.StageOnHold,
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return([]*entity.Timestamp{
+					{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageOnHold, Timestamp: time.Now().Add(-time.Minute)},
+				}, nil)
+			},
+			want: uuid.Nil,
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrInvalidInput)
+			},
+		},
+		{
+			name: "Resolve Without Create",
+			mode: entity.TransitionModeStrict,
+			args: args{
+				ts: &entity.Timestamp{
+					ExternalID: "test",
+					Timestamp:  time.Now(),
+					Tag:        entity.TagIncident,
+					Stage:      entity.StageResolved,
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return(nil, nil)
+			},
+			want: uuid.Nil,
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrInvalidTransition)
+			},
+		},
+		{
+			name: "Close Before Acknowledge",
+			mode: entity.TransitionModeStrict,
+			args: args{
+				ts: &entity.Timestamp{
+					ExternalID: "test",
+					Timestamp:  time.Now().Add(-time.Hour),
+					Tag:        entity.TagIncident,
+					Stage:      entity.StageClosed,
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return([]*entity.Timestamp{
+					{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageCreated, Timestamp: time.Now().Add(-2 * time.Hour)},
+					{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageAcknowledged, Timestamp: time.Now()},
+				}, nil)
+			},
+			want: uuid.Nil,
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrInvalidTransition)
+			},
+		},
+		{
+			name: "Acknowledge After Resume",
+			mode: entity.TransitionModeStrict,
+			args: args{
+				ts: &entity.Timestamp{
+					ExternalID: "test",
+					Timestamp:  time.Now(),
+					Tag:        entity.TagIncident,
+					Stage:      entity.StageAcknowledged,
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				now := time.Now()
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return([]*entity.Timestamp{
+					{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageCreated, Timestamp: now.Add(-3 * time.Hour)},
+					{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageOnHold, Timestamp: now.Add(-2 * time.Hour)},
+					{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageResumed, Timestamp: now.Add(-time.Hour)},
+				}, nil)
+
+				id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+				f.storageMock.CreateMock.Expect(ctx, a.ts).Return(id, nil)
+				f.brokerMock.PublishMock.Return(nil)
+			},
+			want:    uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Warn Mode Flags Meta",
+			mode: entity.TransitionModeWarn,
+			args: args{
+				ts: &entity.Timestamp{
+					ExternalID: "test",
+					Timestamp:  time.Now(),
+					Tag:        entity.TagIncident,
+					Stage:      entity.StageResolved,
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return(nil, nil)
+
+				id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+				f.storageMock.CreateMock.Set(func(_ context.Context, ts *entity.Timestamp) (uuid.UUID, error) {
+					assert.Contains(t, ts.Meta, entity.TransitionWarningKey)
+					return id, nil
+				})
+				f.brokerMock.PublishMock.Return(nil)
+			},
+			want:    uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Off Mode Skips History",
+			mode: entity.TransitionModeOff,
+			args: args{
+				ts: &entity.Timestamp{
+					ExternalID: "test",
+					Timestamp:  time.Now(),
+					Tag:        entity.TagIncident,
+					Stage:      entity.StageClosed,
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+				f.storageMock.CreateMock.Expect(ctx, a.ts).Return(id, nil)
+				f.brokerMock.PublishMock.Return(nil)
+			},
+			want:    uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Already Exists",
+			mode: entity.TransitionModeStrict,
+			args: args{
+				ts: &entity.Timestamp{
+					ExternalID: "test",
+					Timestamp:  time.Now(),
+					Tag:        entity.TagIncident,
+					Stage:      entity.StageCreated,
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				existing := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return([]*entity.Timestamp{
+					{ID: existing, ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageCreated},
+				}, nil)
+				f.storageMock.CreateMock.Expect(ctx, a.ts).Return(existing, repository.ErrAlreadyExists)
+			},
+			want: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, repository.ErrAlreadyExists)
+			},
+		},
 		{
 			name: "Storage Error",
 			args: args{
@@ -81,6 +287,7 @@ func Test_timestampService_Create(t *testing.T) {
 				},
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return(nil, nil)
 				f.storageMock.CreateMock.Expect(ctx, a.ts).Return(uuid.Nil, errors.New("storage error"))
 			},
 			want:    uuid.Nil,
@@ -97,11 +304,13 @@ func Test_timestampService_Create(t *testing.T) {
 				},
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.ListByExternalIDMock.Expect(ctx, a.ts.ExternalID).Return(nil, nil)
+
 				id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
 				f.storageMock.CreateMock.Expect(ctx, a.ts).Return(id, nil)
 
 				a.ts.ID = id
-				event := map[string]any{"action": "create", "data": a.ts}
+				event := map[string]any{"action": "create", "tenant_id": "acme", "data": a.ts}
 				msg, _ := json.Marshal(event)
 				f.brokerMock.PublishMock.Expect(ctx, msg).Return(errors.New("publish error"))
 			},
@@ -113,21 +322,22 @@ func Test_timestampService_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := t.Context()
+			ctx := entity.WithTenant(t.Context(), "acme")
 
 			ctrl := minimock.NewController(t)
 			storageMock := smocks.NewTimestampStorageMock(ctrl)
 			brokerMock := bmocks.NewBrokerMock(ctrl)
 
 			s := &timestampService{
-				storage: storageMock,
-				val:     validator.New(),
-				broker:  brokerMock,
+				storage:     storageMock,
+				val:         newTestValidator(),
+				broker:      brokerMock,
+				transitions: TransitionRules{Mode: tt.mode},
 			}
 
 			tt.prepare(ctx, tt.args, &fields{
 				storageMock: storageMock,
-				val:         validator.New(),
+				val:         newTestValidator(),
 				brokerMock:  brokerMock,
 			})
 
@@ -137,3 +347,100 @@ func Test_timestampService_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_timestampService_Create_CatalogStage(t *testing.T) {
+	t.Parallel()
+
+	ctx := entity.WithTenant(t.Context(), "acme")
+
+	ctrl := minimock.NewController(t)
+	catalogMock := smocks.NewCatalogStorageMock(ctrl)
+	storageMock := smocks.NewTimestampStorageMock(ctrl)
+	brokerMock := bmocks.NewBrokerMock(ctrl)
+
+	triaged := &entity.CatalogEntry{Name: "triaged", Description: "Assigned to a team"}
+	catalogMock.CreateMock.Expect(ctx, entity.CatalogStages, triaged).Return(nil)
+	catalogMock.ListMock.When(ctx, entity.CatalogTags).Then([]*entity.CatalogEntry{{Name: "incident"}}, nil)
+	catalogMock.ListMock.When(ctx, entity.CatalogStages).Then([]*entity.CatalogEntry{
+		{Name: "created"}, {Name: "resolved"}, triaged,
+	}, nil)
+
+	val := NewValidator()
+	require.NoError(t, NewCatalogService(catalogMock, val).Create(ctx, entity.CatalogStages, triaged))
+
+	now := time.Now()
+	ts := &entity.Timestamp{
+		ExternalID: "test",
+		Timestamp:  now.Add(-time.Hour),
+		Tag:        entity.TagIncident,
+		Stage:      "triaged",
+	}
+
+	storageMock.ListByExternalIDMock.Expect(ctx, ts.ExternalID).Return([]*entity.Timestamp{
+		{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageCreated, Timestamp: now.Add(-2 * time.Hour)},
+		{ExternalID: "test", Tag: entity.TagIncident, Stage: entity.StageResolved, Timestamp: now},
+	}, nil)
+	storageMock.CreateMock.Expect(ctx, ts).Return(uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"), nil)
+	brokerMock.PublishMock.Return(nil)
+
+	s := &timestampService{
+		storage:     storageMock,
+		val:         val,
+		broker:      brokerMock,
+		transitions: TransitionRules{Mode: entity.TransitionModeStrict},
+	}
+
+	got, err := s.Create(ctx, ts)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"), got)
+}
+
+func Test_timestampService_Upsert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		created    bool
+		wantAction string
+	}{
+		{name: "Created", created: true, wantAction: "create"},
+		{name: "Updated", created: false, wantAction: "update"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := entity.WithTenant(t.Context(), "acme")
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewTimestampStorageMock(ctrl)
+			brokerMock := bmocks.NewBrokerMock(ctrl)
+
+			ts := &entity.Timestamp{
+				ExternalID: "test",
+				Timestamp:  time.Now(),
+				Tag:        entity.TagIncident,
+				Stage:      entity.StageCreated,
+			}
+
+			storageMock.UpsertMock.Expect(ctx, ts).Return(tt.created, nil)
+			brokerMock.PublishMock.Set(func(_ context.Context, msg []byte) error {
+				var event map[string]any
+				_ = json.Unmarshal(msg, &event)
+				assert.Equal(t, tt.wantAction, event["action"])
+				return nil
+			})
+
+			s := timestampService{
+				storage:     storageMock,
+				val:         newTestValidator(),
+				broker:      brokerMock,
+				transitions: TransitionRules{Mode: entity.TransitionModeOff},
+			}
+
+			created, err := s.Upsert(ctx, ts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.created, created)
+		})
+	}
+}
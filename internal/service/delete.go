@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
 	"log/slog"
+	"time"
 )
 
 func (s *timestampService) Delete(ctx context.Context, id uuid.UUID) error {
@@ -12,12 +14,13 @@ func (s *timestampService) Delete(ctx context.Context, id uuid.UUID) error {
 		return ErrInvalidInput
 	}
 
-	err := s.storage.Delete(ctx, id)
+	ts, err := s.storage.Delete(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	event := map[string]any{"action": "delete", "id": id.String()}
+	// The deleted timestamp lets stream subscribers match the event against their filters.
+	event := map[string]any{"action": "delete", "tenant_id": entity.TenantFromContext(ctx), "id": id.String(), "data": ts}
 	msg, err := json.Marshal(event)
 	if err != nil {
 		slog.Error("marshal event failed", slog.Any("error", err))
@@ -28,3 +31,7 @@ func (s *timestampService) Delete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+func (s *timestampService) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.storage.PurgeDeleted(ctx, retention)
+}
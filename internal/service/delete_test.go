@@ -7,6 +7,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gojuno/minimock/v3"
 	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
 	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
 	bmocks "github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/mocks"
 	"github.com/stretchr/testify/assert"
@@ -36,9 +37,10 @@ func Test_timestampService_Delete(t *testing.T) {
 				id: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
-				f.storageMock.DeleteMock.Expect(ctx, a.id).Return(nil)
+				ts := &entity.Timestamp{ID: a.id, ExternalID: "INC-1", Tag: entity.TagIncident, Stage: entity.StageCreated}
+				f.storageMock.DeleteMock.Expect(ctx, a.id).Return(ts, nil)
 
-				event := map[string]any{"action": "delete", "id": a.id.String()}
+				event := map[string]any{"action": "delete", "tenant_id": "acme", "id": a.id.String(), "data": ts}
 				msg, _ := json.Marshal(event)
 				f.brokerMock.PublishMock.Expect(ctx, msg).Return(nil)
 			},
@@ -59,7 +61,7 @@ func Test_timestampService_Delete(t *testing.T) {
 				id: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
-				f.storageMock.DeleteMock.Expect(ctx, a.id).Return(errors.New("storage error"))
+				f.storageMock.DeleteMock.Expect(ctx, a.id).Return(nil, errors.New("storage error"))
 			},
 			wantErr: assert.Error,
 		},
@@ -69,9 +71,10 @@ func Test_timestampService_Delete(t *testing.T) {
 				id: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
-				f.storageMock.DeleteMock.Expect(ctx, a.id).Return(nil)
+				ts := &entity.Timestamp{ID: a.id, ExternalID: "INC-1", Tag: entity.TagIncident, Stage: entity.StageCreated}
+				f.storageMock.DeleteMock.Expect(ctx, a.id).Return(ts, nil)
 
-				event := map[string]any{"action": "delete", "id": a.id.String()}
+				event := map[string]any{"action": "delete", "tenant_id": "acme", "id": a.id.String(), "data": ts}
 				msg, _ := json.Marshal(event)
 				f.brokerMock.PublishMock.Expect(ctx, msg).Return(errors.New("publish error"))
 			},
@@ -82,7 +85,7 @@ func Test_timestampService_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := t.Context()
+			ctx := entity.WithTenant(t.Context(), "acme")
 
 			ctrl := minimock.NewController(t)
 			storageMock := smocks.NewTimestampStorageMock(ctrl)
@@ -90,14 +93,14 @@ func Test_timestampService_Delete(t *testing.T) {
 
 			s := timestampService{
 				storage: storageMock,
-				val:     validator.New(),
+				val:     newTestValidator(),
 				broker:  brokerMock,
 			}
 
 			tt.prepare(ctx, tt.args, &fields{
 				storageMock: storageMock,
 				brokerMock:  brokerMock,
-				val:         validator.New(),
+				val:         newTestValidator(),
 			})
 
 			err := s.Delete(ctx, tt.args.id)
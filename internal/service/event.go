@@ -0,0 +1,50 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+)
+
+// decodeTimestampEvent returns the action, tenant and timestamps of an event published by the timestamp
+// service. A bulk_create is returned as a create of every timestamp; events of other services, such as
+// breaches, are returned without timestamps. Events published before tenants were introduced belong to
+// DefaultTenant.
+func decodeTimestampEvent(msg []byte) (string, string, []*entity.Timestamp, error) {
+	var event struct {
+		Action string          `json:"action"`
+		Tenant string          `json:"tenant_id"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &event); err != nil {
+		return "", "", nil, fmt.Errorf("unmarshal event: %w", err)
+	}
+
+	if event.Tenant == "" {
+		event.Tenant = entity.DefaultTenant
+	}
+
+	if len(event.Data) == 0 {
+		return event.Action, event.Tenant, nil, nil
+	}
+
+	var list []*entity.Timestamp
+
+	switch event.Action {
+	case "create", "update", "restore", "delete":
+		var ts *entity.Timestamp
+		if err := json.Unmarshal(event.Data, &ts); err != nil {
+			return "", "", nil, fmt.Errorf("unmarshal %s event: %w", event.Action, err)
+		}
+		if ts != nil {
+			list = append(list, ts)
+		}
+	case "bulk_create":
+		if err := json.Unmarshal(event.Data, &list); err != nil {
+			return "", "", nil, fmt.Errorf("unmarshal %s event: %w", event.Action, err)
+		}
+		return "create", event.Tenant, list, nil
+	}
+
+	return event.Action, event.Tenant, list, nil
+}
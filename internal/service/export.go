@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"iter"
+	"time"
+)
+
+// Export validates the filters up front so that the caller can still report an error before it starts
+// writing the stream. Exports bypass the cache: they are too large to cache and must reflect current data.
+func (s *timestampService) Export(
+	ctx context.Context,
+	externalID, tag, stage string,
+	timestampFrom, timestampTo *time.Time,
+	metaFilter map[string]any,
+	filters []entity.Filter,
+) (iter.Seq2[*entity.Timestamp, error], error) {
+	if err := s.val.Var(tag, "omitempty,known_tag"); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.val.Var(stage, "omitempty,known_stage"); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	if _, ok := metaFilter[""]; ok {
+		return nil, ErrInvalidInput
+	}
+
+	if err := validateFilters(s.val, filters); err != nil {
+		return nil, err
+	}
+
+	if timestampFrom != nil && timestampTo != nil && timestampFrom.After(*timestampTo) {
+		return nil, ErrInvalidInput
+	}
+
+	return s.storage.Export(ctx, externalID, tag, stage, timestampFrom, timestampTo, metaFilter, filters), nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"github.com/gojuno/minimock/v3"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"iter"
+	"testing"
+	"time"
+)
+
+func Test_timestampService_Export(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	type args struct {
+		tag, stage    string
+		timestampFrom *time.Time
+		timestampTo   *time.Time
+		metaFilter    map[string]any
+		filters       []entity.Filter
+	}
+	tests := []struct {
+		name    string
+		prepare func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock)
+		args    args
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Success",
+			args: args{
+				tag:           "incident",
+				stage:         "created",
+				timestampFrom: &from,
+				timestampTo:   &to,
+				filters:       []entity.Filter{{Field: "meta.severity", Op: entity.FilterGe, Values: []string{"2"}}},
+			},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {
+				storageMock.ExportMock.
+					Expect(ctx, "", a.tag, a.stage, a.timestampFrom, a.timestampTo, a.metaFilter, a.filters).
+					Return(func(yield func(*entity.Timestamp, error) bool) {
+						for range 2 {
+							if !yield(&entity.Timestamp{Tag: entity.TagIncident}, nil) {
+								return
+							}
+						}
+					})
+			},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Invalid Tag",
+			args:    args{tag: "unknown"},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Invalid Stage",
+			args:    args{stage: "unknown"},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Invalid Stage In Filter",
+			args:    args{filters: []entity.Filter{{Field: "stage", Op: entity.FilterNe, Values: []string{"unknown"}}}},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Inverted Window",
+			args:    args{timestampFrom: &to, timestampTo: &from},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Empty Meta Key",
+			args:    args{metaFilter: map[string]any{"": "x"}},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewTimestampStorageMock(ctrl)
+			tt.prepare(ctx, tt.args, storageMock)
+
+			s := &timestampService{
+				storage: storageMock,
+				val:     newTestValidator(),
+			}
+
+			rows, err := s.Export(ctx, "", tt.args.tag, tt.args.stage, tt.args.timestampFrom, tt.args.timestampTo,
+				tt.args.metaFilter, tt.args.filters)
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+
+			require.NotNil(t, rows)
+			assert.Equal(t, tt.want, countRows(rows))
+		})
+	}
+}
+
+func countRows(rows iter.Seq2[*entity.Timestamp, error]) int {
+	n := 0
+	for range rows {
+		n++
+	}
+
+	return n
+}
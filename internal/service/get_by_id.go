@@ -12,7 +12,7 @@ func (s *timestampService) GetByID(ctx context.Context, id uuid.UUID) (*entity.T
 		return nil, ErrInvalidInput
 	}
 
-	key := fmt.Sprintf(TimestampCachePrefix, id.String())
+	key := fmt.Sprintf(TimestampCachePrefix, entity.TenantFromContext(ctx), id.String())
 	var ts entity.Timestamp
 	if err := s.cache.Get(ctx, key, &ts); err == nil {
 		return &ts, nil
@@ -41,7 +41,7 @@ func Test_timestampService_GetByID(t *testing.T) {
 				id: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
-				key := fmt.Sprintf(TimestampCachePrefix, a.id.String())
+				key := fmt.Sprintf(TimestampCachePrefix, "acme", a.id.String())
 				ts := &entity.Timestamp{
 					ID:         a.id,
 					ExternalID: "test",
@@ -75,7 +75,7 @@ func Test_timestampService_GetByID(t *testing.T) {
 				id: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
-				key := fmt.Sprintf(TimestampCachePrefix, a.id.String())
+				key := fmt.Sprintf(TimestampCachePrefix, "acme", a.id.String())
 				f.cacheMock.GetMock.Expect(ctx, key, &entity.Timestamp{}).Return(errors.New("cache miss"))
 
 				ts := &entity.Timestamp{
@@ -104,7 +104,7 @@ func Test_timestampService_GetByID(t *testing.T) {
 				id: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
-				key := fmt.Sprintf(TimestampCachePrefix, a.id.String())
+				key := fmt.Sprintf(TimestampCachePrefix, "acme", a.id.String())
 				f.cacheMock.GetMock.Expect(ctx, key, &entity.Timestamp{}).Return(errors.New("cache miss"))
 
 				ts := &entity.Timestamp{
@@ -143,7 +143,7 @@ func Test_timestampService_GetByID(t *testing.T) {
 				id: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
 			},
 			prepare: func(ctx context.Context, a args, f *fields) {
-				key := fmt.Sprintf(TimestampCachePrefix, a.id.String())
+				key := fmt.Sprintf(TimestampCachePrefix, "acme", a.id.String())
 				f.cacheMock.GetMock.Expect(ctx, key, &entity.Timestamp{}).Return(errors.New("cache miss"))
 
 				f.storageMock.GetByIDMock.Expect(ctx, a.id).Return(nil, errors.New("storage error"))
@@ -156,7 +156,7 @@ func Test_timestampService_GetByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := t.Context()
+			ctx := entity.WithTenant(t.Context(), "acme")
 
 			ctrl := minimock.NewController(t)
 			storageMock := smocks.NewTimestampStorageMock(ctrl)
@@ -164,14 +164,14 @@ func Test_timestampService_GetByID(t *testing.T) {
 
 			s := &timestampService{
 				storage: storageMock,
-				val:     validator.New(),
+				val:     newTestValidator(),
 				cache:   cacheMock,
 			}
 
 			tt.prepare(ctx, tt.args, &fields{
 				storageMock: storageMock,
 				cacheMock:   cacheMock,
-				val:         validator.New(),
+				val:         newTestValidator(),
 			})
 
 			got, err := s.GetByID(ctx, tt.args.id)
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyReused = apperr.New(apperr.KindUnprocessable, "idempotency_key_reused",
+		"idempotency key was already used for a different request")
+	ErrIdempotencyKeyInFlight = apperr.New(apperr.KindConflict, "idempotency_key_in_flight",
+		"a request with this idempotency key is still being processed")
+)
+
+type IdempotencyService interface {
+	// Begin returns the stored response when key belongs to a finished request with the same hash, or nil
+	// when the request should be processed and then passed to Complete or Release.
+	Begin(ctx context.Context, key, requestHash string) (*entity.IdempotencyRecord, error)
+	Complete(ctx context.Context, key string, statusCode int, response []byte) error
+	Release(ctx context.Context, key string) error
+
+	// Purge deletes the keys that have expired and returns how many were deleted.
+	Purge(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	storage repository.IdempotencyStorage
+	ttl     time.Duration
+}
+
+func NewIdempotencyService(storage repository.IdempotencyStorage, ttl time.Duration) IdempotencyService {
+	return &idempotencyService{
+		storage: storage,
+		ttl:     ttl,
+	}
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, key, requestHash string) (*entity.IdempotencyRecord, error) {
+	if key == "" || requestHash == "" {
+		return nil, ErrInvalidInput
+	}
+
+	rec, err := s.storage.Reserve(ctx, key, requestHash, s.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case rec == nil:
+		return nil, nil
+	case rec.RequestHash != requestHash:
+		return nil, fmt.Errorf("begin: %w", ErrIdempotencyKeyReused)
+	case rec.StatusCode == 0:
+		return nil, fmt.Errorf("begin: %w", ErrIdempotencyKeyInFlight)
+	default:
+		return rec, nil
+	}
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, key string, statusCode int, response []byte) error {
+	return s.storage.Complete(ctx, key, statusCode, response)
+}
+
+func (s *idempotencyService) Release(ctx context.Context, key string) error {
+	return s.storage.Release(ctx, key)
+}
+
+func (s *idempotencyService) Purge(ctx context.Context) (int64, error) {
+	return s.storage.Purge(ctx, s.ttl)
+}
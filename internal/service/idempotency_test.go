@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_idempotencyService_Begin(t *testing.T) {
+	t.Parallel()
+
+	const ttl = 24 * time.Hour
+
+	tests := []struct {
+		name    string
+		key     string
+		hash    string
+		prepare func(ctx context.Context, m *smocks.IdempotencyStorageMock)
+		want    *entity.IdempotencyRecord
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "First Request",
+			key:  "key-1",
+			hash: "hash-1",
+			prepare: func(ctx context.Context, m *smocks.IdempotencyStorageMock) {
+				m.ReserveMock.Expect(ctx, "key-1", "hash-1", ttl).Return(nil, nil)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Replay",
+			key:  "key-1",
+			hash: "hash-1",
+			prepare: func(ctx context.Context, m *smocks.IdempotencyStorageMock) {
+				m.ReserveMock.Return(&entity.IdempotencyRecord{
+					Key:         "key-1",
+					RequestHash: "hash-1",
+					StatusCode:  201,
+					Response:    []byte(`{"id":"x"}`),
+				}, nil)
+			},
+			want: &entity.IdempotencyRecord{
+				Key:         "key-1",
+				RequestHash: "hash-1",
+				StatusCode:  201,
+				Response:    []byte(`{"id":"x"}`),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Different Request",
+			key:  "key-1",
+			hash: "hash-2",
+			prepare: func(ctx context.Context, m *smocks.IdempotencyStorageMock) {
+				m.ReserveMock.Return(&entity.IdempotencyRecord{Key: "key-1", RequestHash: "hash-1", StatusCode: 201}, nil)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+			},
+		},
+		{
+			name: "In Flight",
+			key:  "key-1",
+			hash: "hash-1",
+			prepare: func(ctx context.Context, m *smocks.IdempotencyStorageMock) {
+				m.ReserveMock.Return(&entity.IdempotencyRecord{Key: "key-1", RequestHash: "hash-1"}, nil)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrIdempotencyKeyInFlight)
+			},
+		},
+		{
+			name:    "Empty Key",
+			hash:    "hash-1",
+			prepare: func(ctx context.Context, m *smocks.IdempotencyStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Storage Error",
+			key:  "key-1",
+			hash: "hash-1",
+			prepare: func(ctx context.Context, m *smocks.IdempotencyStorageMock) {
+				m.ReserveMock.Return(nil, errors.New("storage error"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewIdempotencyStorageMock(ctrl)
+			tt.prepare(ctx, storageMock)
+
+			s := NewIdempotencyService(storageMock, ttl)
+
+			got, err := s.Begin(ctx, tt.key, tt.hash)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,56 +5,94 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
-	"time"
 )
 
-func (s *timestampService) List(
-	ctx context.Context,
-	limit, offset int,
-	externalID, tag, stage string,
-	timestampFrom, timestampTo *time.Time,
-	metaFilter map[string]any,
-) ([]*entity.Timestamp, error) {
-	params := &entity.ListQueryParams{
-		Limit:         limit,
-		Offset:        offset,
-		ExternalID:    externalID,
-		Tag:           tag,
-		Stage:         stage,
-		TimestampFrom: timestampFrom,
-		TimestampTo:   timestampTo,
-		MetaFilter:    metaFilter,
-	}
+// MaxFilters caps the filters of a List or Export, each of which adds a condition to the query.
+const MaxFilters = 20
+
+// filterValueTags are the validation tags for the values of filters on catalog fields.
+var filterValueTags = map[string]string{"tag": "known_tag", "stage": "known_stage"}
 
+func (s *timestampService) List(ctx context.Context, params *entity.ListQueryParams) (*entity.ListPage, error) {
 	if err := s.validateListParams(params); err != nil {
 		return nil, err
 	}
 
+	// The cursor is part of params, so every page is cached under its own key.
 	paramsJSON, err := json.Marshal(params)
 	if err != nil {
-		return s.storage.List(ctx, limit, offset, externalID, tag, stage, timestampFrom, timestampTo, metaFilter)
+		return s.listPage(ctx, params)
 	}
 
-	key := fmt.Sprintf("timestamps:list:%x", sha256.Sum256(paramsJSON))
-	var list []*entity.Timestamp
-	if err = s.cache.Get(ctx, key, &list); err == nil {
-		return list, nil
+	key := fmt.Sprintf(ListCacheKey, entity.TenantFromContext(ctx), sha256.Sum256(paramsJSON))
+	var page *entity.ListPage
+	if err = s.cache.Get(ctx, key, &page); err == nil {
+		return page, nil
 	}
 
-	list, err = s.storage.List(ctx, limit, offset, externalID, tag, stage, timestampFrom, timestampTo, metaFilter)
+	page, err = s.listPage(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	_ = s.cache.Set(ctx, key, list, CacheTTL)
+	_ = s.cache.Set(ctx, key, page, CacheTTL)
 
-	return list, nil
+	return page, nil
+}
+
+// listPage fetches one row beyond the limit to learn whether a next page exists.
+func (s *timestampService) listPage(ctx context.Context, params *entity.ListQueryParams) (*entity.ListPage, error) {
+	query := *params
+	query.Limit++
+
+	list, total, err := s.storage.List(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &entity.ListPage{
+		Items:  list,
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	if len(list) > params.Limit {
+		page.Items = list[:params.Limit]
+		page.HasMore = true
+
+		if entity.IsDefaultSort(params.Sort) {
+			last := page.Items[params.Limit-1]
+			page.NextCursor = entity.Cursor{Timestamp: last.Timestamp, ID: last.ID}.Encode()
+		}
+	}
+
+	return page, nil
 }
 
 func (s *timestampService) validateListParams(params *entity.ListQueryParams) error {
-	if err := s.val.Struct(params); err != nil {
-		return ErrInvalidInput
+	if err := validateListFilter(s.val, params); err != nil {
+		return err
+	}
+
+	if params.Cursor != nil && params.Offset != 0 {
+		return fmt.Errorf("cursor and offset cannot be combined: %w", ErrInvalidInput)
+	}
+
+	if params.Cursor != nil && !entity.IsDefaultSort(params.Sort) {
+		return fmt.Errorf("cursor requires the default sort: %w", ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// validateListFilter checks the parameters of params that select timestamps, which List shares with the
+// stream.
+func validateListFilter(val *validator.Validate, params *entity.ListQueryParams) error {
+	if err := val.Struct(params); err != nil {
+		return apperr.Validation(ErrInvalidInput, err)
 	}
 
 	if len(params.MetaFilter) > 0 {
@@ -65,9 +103,36 @@ func (s *timestampService) validateListParams(params *entity.ListQueryParams) er
 		}
 	}
 
+	if err := validateFilters(val, params.Filters); err != nil {
+		return err
+	}
+
 	if params.TimestampFrom != nil && params.TimestampTo != nil && params.TimestampFrom.After(*params.TimestampTo) {
 		return ErrInvalidInput
 	}
 
 	return nil
 }
+
+// validateFilters caps the number of filters and checks tag and stage values against the catalog, as the
+// tag and stage parameters are.
+func validateFilters(val *validator.Validate, filters []entity.Filter) error {
+	if len(filters) > MaxFilters {
+		return fmt.Errorf("at most %d filters are allowed: %w", MaxFilters, ErrInvalidInput)
+	}
+
+	for _, f := range filters {
+		tag, ok := filterValueTags[f.Field]
+		if !ok {
+			continue
+		}
+
+		for _, v := range f.Values {
+			if err := val.Var(v, tag); err != nil {
+				return fmt.Errorf("unknown %s %q: %w", f.Field, v, ErrInvalidInput)
+			}
+		}
+	}
+
+	return nil
+}
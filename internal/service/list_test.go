@@ -22,177 +22,147 @@ func Test_timestampService_List(t *testing.T) {
 
 	now := time.Now()
 	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	nextID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+
+	listKey := func(params *entity.ListQueryParams) string {
+		paramsJSON, _ := json.Marshal(params)
+		return fmt.Sprintf(ListCacheKey, "acme", sha256.Sum256(paramsJSON))
+	}
+	withLimit := func(params *entity.ListQueryParams, limit int) *entity.ListQueryParams {
+		query := *params
+		query.Limit = limit
+		return &query
+	}
 
 	type fields struct {
 		storageMock *smocks.TimestampStorageMock
-		val         *validator.Validate
 		cacheMock   *cmocks.CacheMock
 	}
-	type args struct {
-		limit         int
-		offset        int
-		externalID    string
-		tag           string
-		stage         string
-		timestampFrom *time.Time
-		timestampTo   *time.Time
-		metaFilter    map[string]any
-	}
 	tests := []struct {
 		name    string
-		prepare func(ctx context.Context, a args, f *fields)
-		args    args
-		want    []*entity.Timestamp
+		params  *entity.ListQueryParams
+		prepare func(ctx context.Context, params *entity.ListQueryParams, f *fields)
+		want    *entity.ListPage
 		wantErr assert.ErrorAssertionFunc
 	}{
 		{
 			name: "Success, Cache Miss, Storage Success",
-			args: args{
-				limit:      10,
-				offset:     0,
-				externalID: "test",
-				tag:        "incident",
-				stage:      "created",
+			params: &entity.ListQueryParams{
+				Limit:      10,
+				ExternalID: "test",
+				Tag:        "incident",
+				Stage:      "created",
 			},
-			prepare: func(ctx context.Context, a args, f *fields) {
-				params := &entity.ListQueryParams{
-					Limit:      a.limit,
-					Offset:     a.offset,
-					ExternalID: a.externalID,
-					Tag:        a.tag,
-					Stage:      a.stage,
-				}
-				paramsJSON, _ := json.Marshal(params)
-				key := fmt.Sprintf("timestamps:list:%x", sha256.Sum256(paramsJSON))
-
-				var dest []*entity.Timestamp
-				f.cacheMock.GetMock.Expect(ctx, key, &dest).Return(errors.New("cache miss"))
+			prepare: func(ctx context.Context, params *entity.ListQueryParams, f *fields) {
+				key := listKey(params)
+				f.cacheMock.GetMock.Set(func(_ context.Context, k string, _ any) error {
+					assert.Equal(t, key, k)
+					return errors.New("cache miss")
+				})
 
 				list := []*entity.Timestamp{
-					{
-						ID:         id,
-						ExternalID: "test",
-						Timestamp:  now,
-						Tag:        entity.TagIncident,
-						Stage:      entity.StageCreated,
-					},
+					{ID: id, ExternalID: "test", Timestamp: now, Tag: entity.TagIncident, Stage: entity.StageCreated},
 				}
-				f.storageMock.ListMock.Expect(ctx, a.limit, a.offset, a.externalID, a.tag, a.stage, a.timestampFrom, a.timestampTo, a.metaFilter).Return(list, nil)
+				f.storageMock.ListMock.Expect(ctx, withLimit(params, 11)).Return(list, nil, nil)
 
-				f.cacheMock.SetMock.Expect(ctx, key, list, CacheTTL).Return(nil)
+				f.cacheMock.SetMock.Expect(ctx, key, &entity.ListPage{Items: list, Limit: 10}, CacheTTL).Return(nil)
 			},
-			want: []*entity.Timestamp{
-				{
-					ID:         id,
-					ExternalID: "test",
-					Timestamp:  now,
-					Tag:        entity.TagIncident,
-					Stage:      entity.StageCreated,
+			want: &entity.ListPage{
+				Items: []*entity.Timestamp{
+					{ID: id, ExternalID: "test", Timestamp: now, Tag: entity.TagIncident, Stage: entity.StageCreated},
 				},
+				Limit: 10,
 			},
 			wantErr: assert.NoError,
 		},
 		{
-			name: "Success, Cache Miss, Storage Success, Cache Set Error Ignored",
-			args: args{
-				limit:      10,
-				offset:     0,
-				externalID: "test",
-				tag:        "incident",
-				stage:      "created",
+			name: "Next Cursor When More Rows",
+			params: &entity.ListQueryParams{
+				Limit:  1,
+				Cursor: &entity.Cursor{Timestamp: now.Add(time.Hour), ID: nextID},
 			},
-			prepare: func(ctx context.Context, a args, f *fields) {
-				params := &entity.ListQueryParams{
-					Limit:      a.limit,
-					Offset:     a.offset,
-					ExternalID: a.externalID,
-					Tag:        a.tag,
-					Stage:      a.stage,
-				}
-				paramsJSON, _ := json.Marshal(params)
-				key := fmt.Sprintf("timestamps:list:%x", sha256.Sum256(paramsJSON))
-
-				var dest []*entity.Timestamp
-				f.cacheMock.GetMock.Expect(ctx, key, &dest).Return(errors.New("cache miss"))
-
-				list := []*entity.Timestamp{
-					{
-						ID:         id,
-						ExternalID: "test",
-						Timestamp:  now,
-						Tag:        entity.TagIncident,
-						Stage:      entity.StageCreated,
-					},
-				}
-				f.storageMock.ListMock.Expect(
-					ctx,
-					a.limit,
-					a.offset,
-					a.externalID,
-					a.tag,
-					a.stage,
-					a.timestampFrom,
-					a.timestampTo,
-					a.metaFilter,
-				).Return(list, nil)
-
-				f.cacheMock.SetMock.Expect(ctx, key, list, CacheTTL).Return(errors.New("set error"))
+			prepare: func(ctx context.Context, params *entity.ListQueryParams, f *fields) {
+				f.cacheMock.GetMock.Return(errors.New("cache miss"))
+				f.storageMock.ListMock.Expect(ctx, withLimit(params, 2)).Return([]*entity.Timestamp{
+					{ID: id, Timestamp: now},
+					{ID: nextID, Timestamp: now.Add(-time.Hour)},
+				}, nil, nil)
+				f.cacheMock.SetMock.Return(errors.New("set error"))
 			},
-			want: []*entity.Timestamp{
-				{
-					ID:         id,
-					ExternalID: "test",
-					Timestamp:  now,
-					Tag:        entity.TagIncident,
-					Stage:      entity.StageCreated,
-				},
+			want: &entity.ListPage{
+				Items:      []*entity.Timestamp{{ID: id, Timestamp: now}},
+				Limit:      1,
+				HasMore:    true,
+				NextCursor: entity.Cursor{Timestamp: now, ID: id}.Encode(),
 			},
 			wantErr: assert.NoError,
 		},
 		{
-			name: "Invalid Params",
-			args: args{
-				limit: 0,
+			name:   "No Cursor For Custom Sort",
+			params: &entity.ListQueryParams{Limit: 1, Sort: []entity.SortField{{Field: "external_id"}}},
+			prepare: func(ctx context.Context, params *entity.ListQueryParams, f *fields) {
+				f.cacheMock.GetMock.Return(errors.New("cache miss"))
+				f.storageMock.ListMock.Expect(ctx, withLimit(params, 2)).Return([]*entity.Timestamp{
+					{ID: id, Timestamp: now},
+					{ID: nextID, Timestamp: now.Add(-time.Hour)},
+				}, nil, nil)
+				f.cacheMock.SetMock.Return(nil)
 			},
-			prepare: func(ctx context.Context, a args, f *fields) {
+			want: &entity.ListPage{
+				Items:   []*entity.Timestamp{{ID: id, Timestamp: now}},
+				Limit:   1,
+				HasMore: true,
 			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "Exact Count",
+			params: &entity.ListQueryParams{Limit: 10, Offset: 10, Count: entity.CountExact},
+			prepare: func(ctx context.Context, params *entity.ListQueryParams, f *fields) {
+				f.cacheMock.GetMock.Return(errors.New("cache miss"))
+				total := int64(12)
+				f.storageMock.ListMock.Expect(ctx, withLimit(params, 11)).Return([]*entity.Timestamp{
+					{ID: id, Timestamp: now},
+					{ID: nextID, Timestamp: now.Add(-time.Hour)},
+				}, &total, nil)
+				f.cacheMock.SetMock.Return(nil)
+			},
+			want: &entity.ListPage{
+				Items: []*entity.Timestamp{
+					{ID: id, Timestamp: now},
+					{ID: nextID, Timestamp: now.Add(-time.Hour)},
+				},
+				Total:  func() *int64 { total := int64(12); return &total }(),
+				Limit:  10,
+				Offset: 10,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "Cache Hit",
+			params: &entity.ListQueryParams{Limit: 10},
+			prepare: func(ctx context.Context, params *entity.ListQueryParams, f *fields) {
+				f.cacheMock.GetMock.Set(func(_ context.Context, _ string, dest any) error {
+					*(dest.(**entity.ListPage)) = &entity.ListPage{NextCursor: "cached"}
+					return nil
+				})
+			},
+			want:    &entity.ListPage{NextCursor: "cached"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Invalid Params",
+			params:  &entity.ListQueryParams{Limit: 0},
+			prepare: func(ctx context.Context, params *entity.ListQueryParams, f *fields) {},
 			want:    nil,
 			wantErr: assert.Error,
 		},
 		{
-			name: "Cache Miss, Storage Error",
-			args: args{
-				limit:      10,
-				offset:     0,
-				externalID: "test",
-				tag:        "incident",
-				stage:      "created",
-			},
-			prepare: func(ctx context.Context, a args, f *fields) {
-				params := &entity.ListQueryParams{
-					Limit:      a.limit,
-					Offset:     a.offset,
-					ExternalID: a.externalID,
-					Tag:        a.tag,
-					Stage:      a.stage,
-				}
-				paramsJSON, _ := json.Marshal(params)
-				key := fmt.Sprintf("timestamps:list:%x", sha256.Sum256(paramsJSON))
-
-				var dest []*entity.Timestamp
-				f.cacheMock.GetMock.Expect(ctx, key, &dest).Return(errors.New("cache miss"))
-
-				f.storageMock.ListMock.Expect(
-					ctx,
-					a.limit,
-					a.offset,
-					a.externalID,
-					a.tag,
-					a.stage,
-					a.timestampFrom,
-					a.timestampTo,
-					a.metaFilter,
-				).Return(nil, errors.New("storage error"))
+			name:   "Cache Miss, Storage Error",
+			params: &entity.ListQueryParams{Limit: 10, Offset: 20},
+			prepare: func(ctx context.Context, params *entity.ListQueryParams, f *fields) {
+				f.cacheMock.GetMock.Return(errors.New("cache miss"))
+				f.storageMock.ListMock.Expect(ctx, withLimit(params, 11)).Return(nil, nil, errors.New("storage error"))
 			},
 			want:    nil,
 			wantErr: assert.Error,
@@ -202,7 +172,7 @@ func Test_timestampService_List(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			ctx := t.Context()
+			ctx := entity.WithTenant(t.Context(), "acme")
 
 			ctrl := minimock.NewController(t)
 			storageMock := smocks.NewTimestampStorageMock(ctrl)
@@ -210,27 +180,16 @@ func Test_timestampService_List(t *testing.T) {
 
 			s := &timestampService{
 				storage: storageMock,
-				val:     validator.New(),
+				val:     newTestValidator(),
 				cache:   cacheMock,
 			}
 
-			tt.prepare(ctx, tt.args, &fields{
+			tt.prepare(ctx, tt.params, &fields{
 				storageMock: storageMock,
 				cacheMock:   cacheMock,
-				val:         validator.New(),
 			})
 
-			got, err := s.List(
-				ctx,
-				tt.args.limit,
-				tt.args.offset,
-				tt.args.externalID,
-				tt.args.tag,
-				tt.args.stage,
-				tt.args.timestampFrom,
-				tt.args.timestampTo,
-				tt.args.metaFilter,
-			)
+			got, err := s.List(ctx, tt.params)
 
 			tt.wantErr(t, err)
 			assert.Equal(t, tt.want, got)
@@ -298,6 +257,100 @@ func Test_timestampService_validateListParams(t *testing.T) {
 			prepare: func(f *fields) {},
 			wantErr: assert.Error,
 		},
+		{
+			name: "Invalid Count Mode",
+			args: args{
+				params: &entity.ListQueryParams{
+					Limit: 10,
+					Count: "approximate",
+				},
+			},
+			prepare: func(f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Invalid Cursor With Custom Sort",
+			args: args{
+				params: &entity.ListQueryParams{
+					Limit:  10,
+					Cursor: &entity.Cursor{Timestamp: now, ID: uuid.New()},
+					Sort:   []entity.SortField{{Field: "stage"}},
+				},
+			},
+			prepare: func(f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Invalid Deleted Mode",
+			args: args{
+				params: &entity.ListQueryParams{
+					Limit:   10,
+					Deleted: "all",
+				},
+			},
+			prepare: func(f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Invalid Field",
+			args: args{
+				params: &entity.ListQueryParams{
+					Limit:  10,
+					Fields: []string{"secret"},
+				},
+			},
+			prepare: func(f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success Filters",
+			args: args{
+				params: &entity.ListQueryParams{
+					Limit: 10,
+					Filters: []entity.Filter{
+						{Field: "tag", Op: entity.FilterIn, Values: []string{"incident", "alert"}},
+						{Field: "stage", Op: entity.FilterNe, Values: []string{"closed"}},
+						{Field: "meta.region", Op: entity.FilterIn, Values: []string{"eu", "unlisted"}},
+					},
+				},
+			},
+			prepare: func(f *fields) {},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Invalid Tag In Filter",
+			args: args{
+				params: &entity.ListQueryParams{
+					Limit:   10,
+					Filters: []entity.Filter{{Field: "tag", Op: entity.FilterIn, Values: []string{"incident", "bogus"}}},
+				},
+			},
+			prepare: func(f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Invalid Too Many Filters",
+			args: args{
+				params: &entity.ListQueryParams{
+					Limit:   10,
+					Filters: make([]entity.Filter, MaxFilters+1),
+				},
+			},
+			prepare: func(f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Invalid Cursor With Offset",
+			args: args{
+				params: &entity.ListQueryParams{
+					Limit:  10,
+					Offset: 10,
+					Cursor: &entity.Cursor{Timestamp: now, ID: uuid.New()},
+				},
+			},
+			prepare: func(f *fields) {},
+			wantErr: assert.Error,
+		},
 		{
 			name: "Invalid Timestamp From After To",
 			args: args{
@@ -317,7 +370,7 @@ func Test_timestampService_validateListParams(t *testing.T) {
 			t.Parallel()
 
 			f := &fields{
-				val: validator.New(),
+				val: newTestValidator(),
 			}
 			tt.prepare(f)
 
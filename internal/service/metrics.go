@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+)
+
+type MetricsService interface {
+	// SLA reports mean, p50, p90 and p99 time-to-acknowledge and time-to-resolve per tag.
+	SLA(ctx context.Context, q entity.SLAMetricsQuery) (*entity.SLAMetrics, error)
+}
+
+type metricsService struct {
+	storage repository.MetricsStorage
+}
+
+func NewMetricsService(storage repository.MetricsStorage) MetricsService {
+	return &metricsService{
+		storage: storage,
+	}
+}
+
+func (s *metricsService) SLA(ctx context.Context, q entity.SLAMetricsQuery) (*entity.SLAMetrics, error) {
+	if q.TimestampFrom != nil && q.TimestampTo != nil && q.TimestampFrom.After(*q.TimestampTo) {
+		return nil, fmt.Errorf("timestamp_from is after timestamp_to: %w", ErrInvalidInput)
+	}
+
+	groups, err := s.storage.SLAMetrics(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.SLAMetrics{
+		TimestampFrom: q.TimestampFrom,
+		TimestampTo:   q.TimestampTo,
+		GroupBy:       q.GroupBy,
+		Groups:        groups,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_metricsService_SLA(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+	mean := entity.Duration(10 * time.Minute)
+
+	tests := []struct {
+		name    string
+		q       entity.SLAMetricsQuery
+		prepare func(ctx context.Context, q entity.SLAMetricsQuery, storageMock *smocks.MetricsStorageMock)
+		want    *entity.SLAMetrics
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Success",
+			q:    entity.SLAMetricsQuery{TimestampFrom: &from, TimestampTo: &to, GroupBy: "service"},
+			prepare: func(ctx context.Context, q entity.SLAMetricsQuery, storageMock *smocks.MetricsStorageMock) {
+				storageMock.SLAMetricsMock.Expect(ctx, q).Return([]*entity.SLAMetricsGroup{
+					{Tag: entity.TagIncident, Entities: 1, TimeToAcknowledge: entity.DurationStats{Count: 1, Mean: &mean}},
+				}, nil)
+			},
+			want: &entity.SLAMetrics{
+				TimestampFrom: &from,
+				TimestampTo:   &to,
+				GroupBy:       "service",
+				Groups: []*entity.SLAMetricsGroup{
+					{Tag: entity.TagIncident, Entities: 1, TimeToAcknowledge: entity.DurationStats{Count: 1, Mean: &mean}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Inverted Window",
+			q:       entity.SLAMetricsQuery{TimestampFrom: &to, TimestampTo: &from},
+			prepare: func(ctx context.Context, q entity.SLAMetricsQuery, storageMock *smocks.MetricsStorageMock) {},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrInvalidInput)
+			},
+		},
+		{
+			name: "Storage Error",
+			q:    entity.SLAMetricsQuery{},
+			prepare: func(ctx context.Context, q entity.SLAMetricsQuery, storageMock *smocks.MetricsStorageMock) {
+				storageMock.SLAMetricsMock.Expect(ctx, q).Return(nil, errors.New("storage error"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewMetricsStorageMock(ctrl)
+			tt.prepare(ctx, tt.q, storageMock)
+
+			s := NewMetricsService(storageMock)
+
+			got, err := s.SLA(ctx, tt.q)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
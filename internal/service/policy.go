@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"maps"
+	"slices"
+	"time"
+)
+
+type PolicyService interface {
+	Create(ctx context.Context, p *entity.SLAPolicy) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.SLAPolicy, error)
+	List(ctx context.Context, tag string) ([]*entity.SLAPolicy, error)
+	Update(ctx context.Context, p *entity.SLAPolicy) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Evaluate checks every policy matching the entity's tags against its recorded stages.
+	// With a calendarID other than uuid.Nil, targets are measured in business time.
+	Evaluate(ctx context.Context, externalID string, calendarID uuid.UUID) (*entity.SLAEvaluation, error)
+}
+
+type policyService struct {
+	policies   repository.PolicyStorage
+	timestamps repository.TimestampStorage
+	calendars  repository.CalendarStorage
+	val        *validator.Validate
+}
+
+func NewPolicyService(
+	policies repository.PolicyStorage,
+	timestamps repository.TimestampStorage,
+	calendars repository.CalendarStorage,
+	val *validator.Validate,
+) PolicyService {
+	return &policyService{
+		policies:   policies,
+		timestamps: timestamps,
+		calendars:  calendars,
+		val:        val,
+	}
+}
+
+func (s *policyService) Create(ctx context.Context, p *entity.SLAPolicy) (uuid.UUID, error) {
+	if err := s.validatePolicy(p); err != nil {
+		return uuid.Nil, err
+	}
+
+	return s.policies.Create(ctx, p)
+}
+
+func (s *policyService) GetByID(ctx context.Context, id uuid.UUID) (*entity.SLAPolicy, error) {
+	if id == uuid.Nil {
+		return nil, ErrInvalidInput
+	}
+
+	return s.policies.GetByID(ctx, id)
+}
+
+func (s *policyService) List(ctx context.Context, tag string) ([]*entity.SLAPolicy, error) {
+	if err := s.val.Var(tag, "omitempty,known_tag"); err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	return s.policies.List(ctx, tag)
+}
+
+func (s *policyService) Update(ctx context.Context, p *entity.SLAPolicy) error {
+	if p.ID == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	if err := s.validatePolicy(p); err != nil {
+		return err
+	}
+
+	return s.policies.Update(ctx, p)
+}
+
+func (s *policyService) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	return s.policies.Delete(ctx, id)
+}
+
+func (s *policyService) Evaluate(
+	ctx context.Context,
+	externalID string,
+	calendarID uuid.UUID,
+) (*entity.SLAEvaluation, error) {
+	if externalID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	cal, err := loadCalendar(ctx, s.calendars, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.timestamps.ListByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	reached := stagesByTag(list)
+
+	eval := &entity.SLAEvaluation{
+		ExternalID:  externalID,
+		EvaluatedAt: time.Now().UTC(),
+		Results:     []*entity.SLATargetResult{},
+	}
+
+	for _, tag := range slices.Sorted(maps.Keys(reached)) {
+		policies, listErr := s.policies.List(ctx, string(tag))
+		if listErr != nil {
+			return nil, listErr
+		}
+
+		clock := slaClock{calendar: cal, pauses: pausedIntervals(filterByTag(list, tag), eval.EvaluatedAt)}
+		for _, p := range policies {
+			eval.Results = append(eval.Results, evaluatePolicy(p, reached[tag], clock, eval.EvaluatedAt))
+		}
+	}
+
+	return eval, nil
+}
+
+func (s *policyService) validatePolicy(p *entity.SLAPolicy) error {
+	if err := s.val.Struct(p); err != nil {
+		return apperr.Validation(ErrInvalidInput, err)
+	}
+
+	if p.StartStage == p.EndStage {
+		return ErrInvalidInput
+	}
+
+	// Targets are stored in whole seconds; rounding one silently would change the SLA.
+	if target := time.Duration(p.Target); target < time.Second || target%time.Second != 0 {
+		return fmt.Errorf("target %s is not a whole number of seconds: %w", target, ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// stagesByTag indexes the earliest time each stage was reached, per tag.
+func stagesByTag(list []*entity.Timestamp) map[entity.Tag]map[entity.Stage]time.Time {
+	reached := make(map[entity.Tag]map[entity.Stage]time.Time)
+
+	for _, ts := range list {
+		stages, ok := reached[ts.Tag]
+		if !ok {
+			stages = make(map[entity.Stage]time.Time)
+			reached[ts.Tag] = stages
+		}
+
+		if at, seen := stages[ts.Stage]; !seen || ts.Timestamp.Before(at) {
+			stages[ts.Stage] = ts.Timestamp
+		}
+	}
+
+	return reached
+}
+
+func filterByTag(list []*entity.Timestamp, tag entity.Tag) []*entity.Timestamp {
+	var out []*entity.Timestamp
+	for _, ts := range list {
+		if ts.Tag == tag {
+			out = append(out, ts)
+		}
+	}
+
+	return out
+}
+
+// evaluatePolicy reports a target as pending until its start stage is reached, and afterwards
+// as met or breached depending on how long it took (or has been taking) to reach the end stage.
+func evaluatePolicy(
+	p *entity.SLAPolicy,
+	stages map[entity.Stage]time.Time,
+	clock slaClock,
+	now time.Time,
+) *entity.SLATargetResult {
+	res := &entity.SLATargetResult{
+		PolicyID:   p.ID,
+		Name:       p.Name,
+		Tag:        p.Tag,
+		StartStage: p.StartStage,
+		EndStage:   p.EndStage,
+		Target:     p.Target,
+		Status:     entity.SLAStatusPending,
+	}
+
+	start, ok := stages[p.StartStage]
+	if !ok {
+		return res
+	}
+	res.StartedAt = &start
+
+	end, done := stages[p.EndStage]
+	if done {
+		res.EndedAt = &end
+	} else {
+		end = now
+	}
+
+	elapsed := clock.elapsed(start, end)
+	res.Elapsed = &elapsed
+
+	paused := clock.paused(start, end)
+	res.Paused = &paused
+
+	res.BusinessElapsed = clock.business(start, end)
+	if res.BusinessElapsed != nil {
+		elapsed = *res.BusinessElapsed
+	}
+
+	switch {
+	case elapsed > p.Target:
+		res.Status = entity.SLAStatusBreached
+	case done:
+		res.Status = entity.SLAStatusMet
+	}
+
+	return res
+}
@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_policyService_Create(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		policyMock *smocks.PolicyStorageMock
+	}
+	type args struct {
+		p *entity.SLAPolicy
+	}
+	tests := []struct {
+		name    string
+		prepare func(ctx context.Context, a args, f *fields)
+		args    args
+		want    uuid.UUID
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Success",
+			args: args{
+				p: &entity.SLAPolicy{
+					Name:       "ack",
+					Tag:        entity.TagIncident,
+					StartStage: entity.StageCreated,
+					EndStage:   entity.StageAcknowledged,
+					Target:     entity.Duration(15 * time.Minute),
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+				f.policyMock.CreateMock.Expect(ctx, a.p).Return(id, nil)
+			},
+			want:    uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Same Start And End Stage",
+			args: args{
+				p: &entity.SLAPolicy{
+					Name:       "ack",
+					Tag:        entity.TagIncident,
+					StartStage: entity.StageCreated,
+					EndStage:   entity.StageCreated,
+					Target:     entity.Duration(15 * time.Minute),
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {},
+			want:    uuid.Nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Sub-Second Target",
+			args: args{
+				p: &entity.SLAPolicy{
+					Name:       "ack",
+					Tag:        entity.TagIncident,
+					StartStage: entity.StageCreated,
+					EndStage:   entity.StageAcknowledged,
+					Target:     entity.Duration(time.Millisecond),
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {},
+			want:    uuid.Nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Fractional Seconds Target",
+			args: args{
+				p: &entity.SLAPolicy{
+					Name:       "ack",
+					Tag:        entity.TagIncident,
+					StartStage: entity.StageCreated,
+					EndStage:   entity.StageAcknowledged,
+					Target:     entity.Duration(90*time.Second + 500*time.Millisecond),
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {},
+			want:    uuid.Nil,
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrInvalidInput)
+			},
+		},
+		{
+			name: "Storage Error",
+			args: args{
+				p: &entity.SLAPolicy{
+					Name:       "ack",
+					Tag:        entity.TagIncident,
+					StartStage: entity.StageCreated,
+					EndStage:   entity.StageAcknowledged,
+					Target:     entity.Duration(15 * time.Minute),
+				},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.policyMock.CreateMock.Expect(ctx, a.p).Return(uuid.Nil, repository.ErrPolicyExists)
+			},
+			want:    uuid.Nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			policyMock := smocks.NewPolicyStorageMock(ctrl)
+
+			s := &policyService{
+				policies: policyMock,
+				val:      newTestValidator(),
+			}
+
+			tt.prepare(ctx, tt.args, &fields{policyMock: policyMock})
+
+			got, err := s.Create(ctx, tt.args.p)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_policyService_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	created := time.Now().Add(-time.Hour)
+
+	ackPolicy := &entity.SLAPolicy{
+		ID:         uuid.MustParse("123e4567-e89b-12d3-a456-426614174001"),
+		Name:       "ack",
+		Tag:        entity.TagIncident,
+		StartStage: entity.StageCreated,
+		EndStage:   entity.StageAcknowledged,
+		Target:     entity.Duration(15 * time.Minute),
+	}
+	resolvePolicy := &entity.SLAPolicy{
+		ID:         uuid.MustParse("123e4567-e89b-12d3-a456-426614174002"),
+		Name:       "resolve",
+		Tag:        entity.TagIncident,
+		StartStage: entity.StageCreated,
+		EndStage:   entity.StageResolved,
+		Target:     entity.Duration(4 * time.Hour),
+	}
+	closePolicy := &entity.SLAPolicy{
+		ID:         uuid.MustParse("123e4567-e89b-12d3-a456-426614174003"),
+		Name:       "close",
+		Tag:        entity.TagIncident,
+		StartStage: entity.StageResolved,
+		EndStage:   entity.StageClosed,
+		Target:     entity.Duration(time.Hour),
+	}
+
+	// Created on Friday 17:00 Berlin time and acknowledged on Monday 09:10: 64h10m of wall-clock
+	// time but only 1h10m within working hours.
+	friday := time.Date(2025, 7, 11, 17, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	calendarID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174009")
+	weekdays := map[string]entity.WorkingHours{}
+	for _, day := range []string{"monday", "tuesday", "wednesday", "thursday", "friday"} {
+		weekdays[day] = entity.WorkingHours{Start: "09:00", End: "18:00"}
+	}
+	calendar := &entity.Calendar{ID: calendarID, Name: "eu", Timezone: "Europe/Berlin", WorkingHours: weekdays}
+	businessPolicy := &entity.SLAPolicy{
+		Name:       "business-ack",
+		Tag:        entity.TagIncident,
+		StartStage: entity.StageCreated,
+		EndStage:   entity.StageAcknowledged,
+		Target:     entity.Duration(2 * time.Hour),
+	}
+
+	type mocks struct {
+		storageMock  *smocks.TimestampStorageMock
+		policyMock   *smocks.PolicyStorageMock
+		calendarMock *smocks.CalendarStorageMock
+	}
+
+	tests := []struct {
+		name       string
+		externalID string
+		calendarID uuid.UUID
+		prepare    func(ctx context.Context, m *mocks)
+		want       map[string]entity.SLAStatus
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "Met, Pending And Not Started",
+			externalID: "INC-1",
+			prepare: func(ctx context.Context, m *mocks) {
+				m.storageMock.ListByExternalIDMock.Expect(ctx, "INC-1").Return([]*entity.Timestamp{
+					{ExternalID: "INC-1", Tag: entity.TagIncident, Stage: entity.StageCreated, Timestamp: created},
+					{
+						ExternalID: "INC-1",
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageAcknowledged,
+						Timestamp:  created.Add(10 * time.Minute),
+					},
+				}, nil)
+				m.policyMock.ListMock.Expect(ctx, string(entity.TagIncident)).
+					Return([]*entity.SLAPolicy{ackPolicy, resolvePolicy, closePolicy}, nil)
+			},
+			want: map[string]entity.SLAStatus{
+				"ack":     entity.SLAStatusMet,
+				"resolve": entity.SLAStatusPending,
+				"close":   entity.SLAStatusPending,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "Breached After Completion And While Open",
+			externalID: "INC-2",
+			prepare: func(ctx context.Context, m *mocks) {
+				m.storageMock.ListByExternalIDMock.Expect(ctx, "INC-2").Return([]*entity.Timestamp{
+					{
+						ExternalID: "INC-2",
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageCreated,
+						Timestamp:  created.Add(-5 * time.Hour),
+					},
+					{
+						ExternalID: "INC-2",
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageAcknowledged,
+						Timestamp:  created,
+					},
+				}, nil)
+				m.policyMock.ListMock.Expect(ctx, string(entity.TagIncident)).
+					Return([]*entity.SLAPolicy{ackPolicy, resolvePolicy}, nil)
+			},
+			want: map[string]entity.SLAStatus{
+				"ack":     entity.SLAStatusBreached,
+				"resolve": entity.SLAStatusBreached,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "Business Hours Calendar",
+			externalID: "INC-4",
+			calendarID: calendarID,
+			prepare: func(ctx context.Context, m *mocks) {
+				m.calendarMock.GetByIDMock.Expect(ctx, calendarID).Return(calendar, nil)
+				m.storageMock.ListByExternalIDMock.Expect(ctx, "INC-4").Return([]*entity.Timestamp{
+					{ExternalID: "INC-4", Tag: entity.TagIncident, Stage: entity.StageCreated, Timestamp: friday},
+					{
+						ExternalID: "INC-4",
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageAcknowledged,
+						Timestamp:  friday.Add(64*time.Hour + 10*time.Minute),
+					},
+				}, nil)
+				m.policyMock.ListMock.Expect(ctx, string(entity.TagIncident)).
+					Return([]*entity.SLAPolicy{businessPolicy}, nil)
+			},
+			want: map[string]entity.SLAStatus{
+				"business-ack": entity.SLAStatusMet,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "Unknown Calendar",
+			externalID: "INC-5",
+			calendarID: calendarID,
+			prepare: func(ctx context.Context, m *mocks) {
+				m.calendarMock.GetByIDMock.Expect(ctx, calendarID).Return(nil, repository.ErrCalendarNotFound)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, repository.ErrCalendarNotFound)
+			},
+		},
+		{
+			name:       "Empty External ID",
+			externalID: "",
+			prepare:    func(ctx context.Context, m *mocks) {},
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "Unknown Entity",
+			externalID: "missing",
+			prepare: func(ctx context.Context, m *mocks) {
+				m.storageMock.ListByExternalIDMock.Expect(ctx, "missing").Return(nil, nil)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, repository.ErrNotFound)
+			},
+		},
+		{
+			name:       "Storage Error",
+			externalID: "INC-3",
+			prepare: func(ctx context.Context, m *mocks) {
+				m.storageMock.ListByExternalIDMock.Expect(ctx, "INC-3").Return(nil, errors.New("storage error"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			m := &mocks{
+				storageMock:  smocks.NewTimestampStorageMock(ctrl),
+				policyMock:   smocks.NewPolicyStorageMock(ctrl),
+				calendarMock: smocks.NewCalendarStorageMock(ctrl),
+			}
+
+			s := &policyService{
+				policies:   m.policyMock,
+				timestamps: m.storageMock,
+				calendars:  m.calendarMock,
+				val:        newTestValidator(),
+			}
+
+			tt.prepare(ctx, m)
+
+			got, err := s.Evaluate(ctx, tt.externalID, tt.calendarID)
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+
+			require.Len(t, got.Results, len(tt.want))
+			for _, res := range got.Results {
+				assert.Equal(t, tt.want[res.Name], res.Status, res.Name)
+				assert.Equal(t, tt.calendarID != uuid.Nil, res.BusinessElapsed != nil, res.Name)
+			}
+		})
+	}
+}
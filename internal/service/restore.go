@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+)
+
+// Restore publishes a restore event carrying the timestamp, so that the consumer caches it again and drops
+// the cached list pages of the tenant, which it was missing from.
+func (s *timestampService) Restore(ctx context.Context, id uuid.UUID) (*entity.Timestamp, error) {
+	if id == uuid.Nil {
+		return nil, ErrInvalidInput
+	}
+
+	ts, err := s.storage.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, "restore", ts)
+
+	return ts, nil
+}
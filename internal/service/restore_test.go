@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	bmocks "github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_timestampService_Restore(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	restored := &entity.Timestamp{
+		ID:         id,
+		ExternalID: "test",
+		Timestamp:  time.Date(2025, 7, 13, 15, 0, 0, 0, time.UTC),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+		Version:    1,
+	}
+
+	type fields struct {
+		storageMock *smocks.TimestampStorageMock
+		brokerMock  *bmocks.BrokerMock
+	}
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		prepare func(ctx context.Context, f *fields)
+		want    *entity.Timestamp
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Success",
+			id:   id,
+			prepare: func(ctx context.Context, f *fields) {
+				f.storageMock.RestoreMock.Expect(ctx, id).Return(restored, nil)
+
+				msg, _ := json.Marshal(map[string]any{"action": "restore", "tenant_id": "acme", "data": restored})
+				f.brokerMock.PublishMock.Expect(ctx, msg).Return(nil)
+			},
+			want:    restored,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Invalid Input",
+			id:      uuid.Nil,
+			prepare: func(ctx context.Context, f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Recorded Again",
+			id:   id,
+			prepare: func(ctx context.Context, f *fields) {
+				f.storageMock.RestoreMock.Expect(ctx, id).Return(nil, repository.ErrAlreadyExists)
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := entity.WithTenant(t.Context(), "acme")
+
+			ctrl := minimock.NewController(t)
+			f := &fields{
+				storageMock: smocks.NewTimestampStorageMock(ctrl),
+				brokerMock:  bmocks.NewBrokerMock(ctrl),
+			}
+			tt.prepare(ctx, f)
+
+			s := timestampService{
+				storage: f.storageMock,
+				broker:  f.brokerMock,
+			}
+
+			got, err := s.Restore(ctx, tt.id)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/broker"
+	"log/slog"
+	"time"
+)
+
+const ScanBatchSize = 500
+
+type BreachScanner interface {
+	// Scan publishes an sla_breached event for every newly overdue entity and returns how many were emitted.
+	Scan(ctx context.Context) (int, error)
+}
+
+type breachScanner struct {
+	breaches  repository.BreachStorage
+	broker    broker.Broker
+	threshold time.Duration
+	lookback  time.Duration
+}
+
+// NewBreachScanner returns a scanner that flags stages open longer than threshold. Stages that crossed
+// the threshold more than lookback ago are no longer looked at, which bounds every scan to recent
+// timestamps; lookback only has to cover the longest time the scanner may be down.
+func NewBreachScanner(
+	breaches repository.BreachStorage,
+	broker broker.Broker,
+	threshold, lookback time.Duration,
+) BreachScanner {
+	return &breachScanner{
+		breaches:  breaches,
+		broker:    broker,
+		threshold: threshold,
+		lookback:  lookback,
+	}
+}
+
+func (s *breachScanner) Scan(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	openedBefore := now.Add(-s.threshold)
+
+	overdue, err := s.breaches.FindOverdue(ctx, openedBefore.Add(-s.lookback), openedBefore, ScanBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	emitted := 0
+
+	for _, b := range overdue {
+		b.DetectedAt = now
+		b.OpenFor = entity.Duration(now.Sub(b.StageStartedAt))
+		b.Threshold = entity.Duration(s.threshold)
+
+		ok, recordErr := s.breaches.Record(ctx, b)
+		if recordErr != nil {
+			return emitted, recordErr
+		}
+
+		// Another scanner instance got there first.
+		if !ok {
+			continue
+		}
+
+		if err = s.publish(ctx, b); err != nil {
+			// Forget the breach so the next scan retries the event instead of losing it.
+			_ = s.breaches.Forget(ctx, b)
+			return emitted, err
+		}
+
+		emitted++
+	}
+
+	return emitted, nil
+}
+
+func (s *breachScanner) publish(ctx context.Context, b *entity.Breach) error {
+	event := map[string]any{"action": "sla_breached", "tenant_id": b.Tenant, "data": b}
+	msg, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("marshal event failed", slog.Any("error", err))
+		return err
+	}
+
+	return s.broker.Publish(ctx, msg)
+}
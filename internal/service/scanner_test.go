@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	bmocks "github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_breachScanner_Scan(t *testing.T) {
+	t.Parallel()
+
+	threshold := 4 * time.Hour
+	lookback := 24 * time.Hour
+	startedAt := time.Now().Add(-5 * time.Hour)
+
+	newBreach := func(externalID string) *entity.Breach {
+		return &entity.Breach{
+			Tenant:         "acme",
+			ExternalID:     externalID,
+			Tag:            entity.TagIncident,
+			Stage:          entity.StageAcknowledged,
+			StageStartedAt: startedAt,
+		}
+	}
+
+	type fields struct {
+		breachMock *smocks.BreachStorageMock
+		brokerMock *bmocks.BrokerMock
+	}
+	tests := []struct {
+		name    string
+		prepare func(f *fields)
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Emits Only Newly Recorded Breaches",
+			prepare: func(f *fields) {
+				f.breachMock.FindOverdueMock.Return([]*entity.Breach{newBreach("INC-1"), newBreach("INC-2")}, nil)
+				f.breachMock.RecordMock.Set(func(_ context.Context, b *entity.Breach) (bool, error) {
+					return b.ExternalID == "INC-1", nil
+				})
+				f.brokerMock.PublishMock.Set(func(_ context.Context, msg []byte) error {
+					var event struct {
+						Action string        `json:"action"`
+						Tenant string        `json:"tenant_id"`
+						Data   entity.Breach `json:"data"`
+					}
+					if err := json.Unmarshal(msg, &event); err != nil {
+						return err
+					}
+					if event.Action != "sla_breached" || event.Tenant != "acme" || event.Data.ExternalID != "INC-1" {
+						return errors.New("unexpected event")
+					}
+					return nil
+				})
+			},
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nothing Overdue",
+			prepare: func(f *fields) {
+				f.breachMock.FindOverdueMock.Set(func(_ context.Context, openedAfter, openedBefore time.Time, limit int) (
+					[]*entity.Breach, error,
+				) {
+					assert.WithinDuration(t, time.Now().Add(-threshold), openedBefore, time.Minute)
+					assert.Equal(t, lookback, openedBefore.Sub(openedAfter))
+					assert.Equal(t, ScanBatchSize, limit)
+					return nil, nil
+				})
+			},
+			want:    0,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Publish Error Forgets Breach",
+			prepare: func(f *fields) {
+				b := newBreach("INC-1")
+				f.breachMock.FindOverdueMock.Return([]*entity.Breach{b}, nil)
+				f.breachMock.RecordMock.Return(true, nil)
+				f.brokerMock.PublishMock.Return(errors.New("publish error"))
+				f.breachMock.ForgetMock.Return(nil)
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Storage Error",
+			prepare: func(f *fields) {
+				f.breachMock.FindOverdueMock.Return(nil, errors.New("storage error"))
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			breachMock := smocks.NewBreachStorageMock(ctrl)
+			brokerMock := bmocks.NewBrokerMock(ctrl)
+
+			s := &breachScanner{
+				breaches:  breachMock,
+				broker:    brokerMock,
+				threshold: threshold,
+				lookback:  lookback,
+			}
+
+			tt.prepare(&fields{breachMock: breachMock, brokerMock: brokerMock})
+
+			got, err := s.Scan(ctx)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
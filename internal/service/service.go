@@ -2,46 +2,80 @@ package service
 
 import (
 	"context"
-	"errors"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
 	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
 	"github.com/sdvaanyaa/sla-timestamp-api/pkg/broker"
 	"github.com/sdvaanyaa/sla-timestamp-api/pkg/cache"
+	"iter"
 	"time"
 )
 
 const (
-	CacheTTL             = 5 * time.Minute
-	ListCachePrefix      = "timestamps:list:*"
-	TimestampCachePrefix = "timestamp:%s"
+	CacheTTL = 5 * time.Minute
+	// The cache keys of timestamps and list pages start with the tenant they were read in. A list page is
+	// cached under ListCacheKey, the hash of its params following ListCachePrefix, so that the pages of a
+	// tenant are evicted together.
+	ListCachePrefix      = "timestamps:list:%s:"
+	ListCacheKey         = ListCachePrefix + "%x"
+	TimestampCachePrefix = "timestamp:%s:%s"
 )
 
 var (
-	ErrInvalidInput = errors.New("invalid input")
+	ErrInvalidInput = apperr.New(apperr.KindInvalid, "invalid_input", "invalid input")
 )
 
 type TimestampService interface {
+	// Create returns the ID of the stored timestamp together with repository.ErrAlreadyExists when one with
+	// the same external_id, tag and stage exists.
 	Create(ctx context.Context, ts *entity.Timestamp) (uuid.UUID, error)
+
+	// Upsert creates ts or replaces the timestamp and meta of the stored one with the same external_id, tag
+	// and stage, and reports whether it was created. ts gets the ID and version of the stored row.
+	Upsert(ctx context.Context, ts *entity.Timestamp) (bool, error)
+	// CreateBulk creates the valid items in one batch and reports the outcome of every item in request order.
+	// An error is returned only when the request as a whole fails.
+	CreateBulk(ctx context.Context, items []*entity.Timestamp) ([]entity.BulkItemResult, error)
+
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Timestamp, error)
 
-	List(
+	// List returns a page of timestamps, newest first. Its NextCursor continues after the page in keyset
+	// mode; with Offset, later pages may skip or repeat rows when timestamps are added in between.
+	List(ctx context.Context, params *entity.ListQueryParams) (*entity.ListPage, error)
+
+	// Export streams every timestamp matching the List filters, without pagination.
+	Export(
 		ctx context.Context,
-		limit, offset int,
 		externalID, tag, stage string,
 		timestampFrom, timestampTo *time.Time,
 		metaFilter map[string]any,
-	) ([]*entity.Timestamp, error)
+		filters []entity.Filter,
+	) (iter.Seq2[*entity.Timestamp, error], error)
 
+	// Update replaces the timestamp and meta, Patch merges into them. A non-zero version must match the
+	// stored one or repository.ErrVersionConflict is returned.
+	Update(ctx context.Context, id uuid.UUID, req *entity.UpdateTimestampRequest, version int) (*entity.Timestamp, error)
+	Patch(ctx context.Context, id uuid.UUID, patch *entity.TimestampPatch, version int) (*entity.Timestamp, error)
+
+	// Delete soft-deletes the timestamp; Restore undoes it. PurgeDeleted permanently removes timestamps
+	// deleted more than retention ago.
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) (*entity.Timestamp, error)
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error)
+
+	// Timeline additionally reports business-time durations when calendarID is not uuid.Nil.
+	Timeline(ctx context.Context, externalID string, calendarID uuid.UUID) (*entity.Timeline, error)
 }
 
 type timestampService struct {
-	storage repository.TimestampStorage
-	val     *validator.Validate
-	cache   cache.Cache
-	broker  broker.Broker
+	storage     repository.TimestampStorage
+	val         *validator.Validate
+	cache       cache.Cache
+	broker      broker.Broker
+	calendars   repository.CalendarStorage
+	transitions TransitionRules
 }
 
 func New(
@@ -49,11 +83,15 @@ func New(
 	val *validator.Validate,
 	cache cache.Cache,
 	broker broker.Broker,
+	calendars repository.CalendarStorage,
+	transitions TransitionRules,
 ) TimestampService {
 	return &timestampService{
-		storage: storage,
-		val:     val,
-		cache:   cache,
-		broker:  broker,
+		storage:     storage,
+		val:         val,
+		cache:       cache,
+		broker:      broker,
+		calendars:   calendars,
+		transitions: transitions,
 	}
 }
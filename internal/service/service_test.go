@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+)
+
+// newTestValidator registers the catalog validations with the built-in tags and stages, all active.
+func newTestValidator() *validator.Validate {
+	val := NewValidator()
+
+	s := &catalogService{val: val}
+	s.snapshot.Store(&catalogSnapshot{
+		entity.CatalogTags: {
+			string(entity.TagIncident):    false,
+			string(entity.TagSLA):         false,
+			string(entity.TagDeployment):  false,
+			string(entity.TagMaintenance): false,
+			string(entity.TagAlert):       false,
+		},
+		entity.CatalogStages: {
+			string(entity.StageCreated):      false,
+			string(entity.StageAcknowledged): false,
+			string(entity.StageInProgress):   false,
+			string(entity.StageOnHold):       false,
+			string(entity.StageResumed):      false,
+			string(entity.StageResolved):     false,
+			string(entity.StageClosed):       false,
+		},
+	})
+	s.registerValidations(val)
+
+	return val
+}
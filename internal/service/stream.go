@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/broker"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StreamBufferSize is the number of events a subscriber may lag behind before it is dropped. A dropped
+// client reconnects with Last-Event-ID and catches up from the replay buffer.
+const StreamBufferSize = 64
+
+var ErrStreamUnavailable = apperr.New(apperr.KindUnavailable, "stream_unavailable", "event stream unavailable")
+
+type StreamService interface {
+	// Run feeds the stream from the broker until ctx is done or the broker subscription ends, after which
+	// every subscription is closed.
+	Run(ctx context.Context) error
+
+	// Subscribe starts a subscription to the create and delete events of the tenant in ctx matching the
+	// filters of params. With a lastEventID the buffered events after it are replayed first.
+	Subscribe(ctx context.Context, params *entity.ListQueryParams, lastEventID string) (*Subscription, error)
+}
+
+// Subscription delivers events on Events until Close is called or the subscriber falls behind, in which
+// case Events is closed.
+type Subscription struct {
+	// Replay are the buffered events after the Last-Event-ID that match the filters.
+	Replay []entity.TimestampEvent
+	// Reset is set when the events after the Last-Event-ID are no longer buffered, or were assigned by
+	// another replica, so the client has to reload its state with List.
+	Reset  bool
+	Events <-chan entity.TimestampEvent
+	Close  func()
+}
+
+type streamSubscriber struct {
+	tenant string
+	params *entity.ListQueryParams
+	events chan entity.TimestampEvent
+}
+
+func (sub *streamSubscriber) matches(event entity.TimestampEvent) bool {
+	return event.Tenant == sub.tenant && sub.params.Matches(event.Timestamp)
+}
+
+// streamService fans the events published to the broker out to the clients connected to this replica.
+// Event IDs are the replica ID followed by a sequence number, so a Last-Event-ID from another replica is
+// recognized as such.
+type streamService struct {
+	subscriber broker.Subscriber
+	val        *validator.Validate
+	replica    string
+	replaySize int
+
+	mu      sync.Mutex
+	stopped bool
+	seq     uint64
+	// replay holds the last replaySize events, oldest first.
+	replay []entity.TimestampEvent
+	subs   map[*streamSubscriber]struct{}
+}
+
+func NewStreamService(subscriber broker.Subscriber, val *validator.Validate, replaySize int) StreamService {
+	return &streamService{
+		subscriber: subscriber,
+		val:        val,
+		replica:    uuid.NewString()[:8],
+		replaySize: replaySize,
+		subs:       make(map[*streamSubscriber]struct{}),
+	}
+}
+
+func (s *streamService) Run(ctx context.Context) error {
+	defer s.stop()
+
+	msgs, err := s.subscriber.Subscribe(ctx)
+	if err != nil {
+		return fmt.Errorf("subscribe: %w", err)
+	}
+
+	for msg := range msgs {
+		s.handle(msg)
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("subscription closed: %w", ErrStreamUnavailable)
+}
+
+func (s *streamService) Subscribe(
+	ctx context.Context,
+	params *entity.ListQueryParams,
+	lastEventID string,
+) (*Subscription, error) {
+	if err := validateListFilter(s.val, params); err != nil {
+		return nil, err
+	}
+
+	sub := &streamSubscriber{
+		tenant: entity.TenantFromContext(ctx),
+		params: params,
+		events: make(chan entity.TimestampEvent, StreamBufferSize),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return nil, ErrStreamUnavailable
+	}
+
+	replay, reset := s.replaySince(lastEventID, sub)
+	s.subs[sub] = struct{}{}
+
+	return &Subscription{
+		Replay: replay,
+		Reset:  reset,
+		Events: sub.events,
+		Close:  func() { s.unsubscribe(sub) },
+	}, nil
+}
+
+// handle turns a broker message into stream events. Updates, restores and other messages are not streamed.
+func (s *streamService) handle(msg []byte) {
+	action, tenant, list, err := decodeTimestampEvent(msg)
+	if err != nil {
+		slog.Error("decode event failed", slog.Any("error", err))
+		return
+	}
+
+	if action != string(entity.StreamCreate) && action != string(entity.StreamDelete) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ts := range list {
+		s.emit(entity.StreamAction(action), tenant, ts)
+	}
+}
+
+// emit buffers an event and sends it to the matching subscribers. The caller holds s.mu.
+func (s *streamService) emit(action entity.StreamAction, tenant string, ts *entity.Timestamp) {
+	s.seq++
+	event := entity.TimestampEvent{
+		ID:        s.replica + "-" + strconv.FormatUint(s.seq, 10),
+		Action:    action,
+		Tenant:    tenant,
+		Timestamp: ts,
+	}
+
+	if s.replaySize > 0 {
+		if len(s.replay) >= s.replaySize {
+			s.replay = s.replay[len(s.replay)-s.replaySize+1:]
+		}
+		s.replay = append(s.replay, event)
+	}
+
+	for sub := range s.subs {
+		if !sub.matches(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			// A subscriber that cannot keep up is dropped rather than blocking every other one.
+			delete(s.subs, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// replaySince returns the buffered events after lastEventID that match sub, and whether the client missed
+// events that can no longer be replayed. The caller holds s.mu.
+func (s *streamService) replaySince(lastEventID string, sub *streamSubscriber) ([]entity.TimestampEvent, bool) {
+	if lastEventID == "" {
+		return nil, false
+	}
+
+	replica, seqStr, ok := strings.Cut(lastEventID, "-")
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if !ok || err != nil || replica != s.replica || seq > s.seq {
+		return nil, true
+	}
+
+	// The buffer holds consecutive sequence numbers ending at s.seq.
+	first := s.seq - uint64(len(s.replay)) + 1
+	if seq+1 < first {
+		return nil, true
+	}
+
+	var replay []entity.TimestampEvent
+	for _, event := range s.replay[seq+1-first:] {
+		if sub.matches(event) {
+			replay = append(replay, event)
+		}
+	}
+
+	return replay, false
+}
+
+func (s *streamService) unsubscribe(sub *streamSubscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[sub]; ok {
+		delete(s.subs, sub)
+		close(sub.events)
+	}
+}
+
+func (s *streamService) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = true
+	for sub := range s.subs {
+		delete(s.subs, sub)
+		close(sub.events)
+	}
+}
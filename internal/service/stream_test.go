@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	bmocks "github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func streamMessage(t *testing.T, action string, data any) []byte {
+	t.Helper()
+
+	msg, err := json.Marshal(map[string]any{"action": action, "tenant_id": "acme", "data": data})
+	require.NoError(t, err)
+
+	return msg
+}
+
+func streamTimestamp(externalID string, tag entity.Tag) *entity.Timestamp {
+	return &entity.Timestamp{
+		ID:         uuid.New(),
+		ExternalID: externalID,
+		Timestamp:  time.Date(2025, 7, 13, 15, 0, 0, 0, time.UTC),
+		Tag:        tag,
+		Stage:      entity.StageCreated,
+		Version:    1,
+	}
+}
+
+func streamContext(t *testing.T) context.Context {
+	return entity.WithTenant(t.Context(), "acme")
+}
+
+// receive returns the events delivered to sub so far, without waiting for more.
+func receive(sub *Subscription) []entity.TimestampEvent {
+	var events []entity.TimestampEvent
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if !ok {
+				return events
+			}
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func Test_streamService_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	incident := streamTimestamp("INC-1", entity.TagIncident)
+	alert := streamTimestamp("ALR-1", entity.TagAlert)
+	bulk := []*entity.Timestamp{streamTimestamp("INC-2", entity.TagIncident), streamTimestamp("INC-3", entity.TagAlert)}
+
+	t.Run("Live Events Match Filters", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewStreamService(nil, newTestValidator(), 10).(*streamService)
+		sub, err := s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10, Tag: string(entity.TagIncident)}, "")
+		require.NoError(t, err)
+
+		s.handle(streamMessage(t, "create", incident))
+		s.handle(streamMessage(t, "create", alert))
+		s.handle(streamMessage(t, "update", incident))
+		s.handle(streamMessage(t, "bulk_create", bulk))
+		s.handle(streamMessage(t, "delete", incident))
+
+		other, err := json.Marshal(map[string]any{"action": "create", "tenant_id": "other", "data": incident})
+		require.NoError(t, err)
+		s.handle(other)
+
+		events := receive(sub)
+		require.Len(t, events, 3)
+		assert.Equal(t, entity.StreamCreate, events[0].Action)
+		assert.Equal(t, incident.ID, events[0].Timestamp.ID)
+		assert.Equal(t, entity.StreamCreate, events[1].Action)
+		assert.Equal(t, bulk[0].ID, events[1].Timestamp.ID)
+		assert.Equal(t, entity.StreamDelete, events[2].Action)
+		assert.Equal(t, incident.ID, events[2].Timestamp.ID)
+		assert.NotEqual(t, events[0].ID, events[2].ID)
+	})
+
+	t.Run("Replay After Last Event ID", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewStreamService(nil, newTestValidator(), 10).(*streamService)
+		first, err := s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10}, "")
+		require.NoError(t, err)
+
+		s.handle(streamMessage(t, "create", incident))
+		s.handle(streamMessage(t, "create", alert))
+		s.handle(streamMessage(t, "delete", incident))
+		seen := receive(first)
+		require.Len(t, seen, 3)
+
+		incidents := &entity.ListQueryParams{Limit: 10, Tag: string(entity.TagIncident)}
+		sub, err := s.Subscribe(streamContext(t), incidents, seen[0].ID)
+		require.NoError(t, err)
+		assert.False(t, sub.Reset)
+		assert.Equal(t, []entity.TimestampEvent{seen[2]}, sub.Replay)
+
+		sub, err = s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10}, seen[2].ID)
+		require.NoError(t, err)
+		assert.False(t, sub.Reset)
+		assert.Empty(t, sub.Replay)
+	})
+
+	t.Run("Reset When Not Buffered", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewStreamService(nil, newTestValidator(), 1).(*streamService)
+		first, err := s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10}, "")
+		require.NoError(t, err)
+
+		s.handle(streamMessage(t, "create", incident))
+		s.handle(streamMessage(t, "create", alert))
+		s.handle(streamMessage(t, "delete", incident))
+		seen := receive(first)
+		require.Len(t, seen, 3)
+
+		for _, lastEventID := range []string{seen[0].ID, "otherrep-1", "garbage"} {
+			sub, subErr := s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10}, lastEventID)
+			require.NoError(t, subErr)
+			assert.True(t, sub.Reset, lastEventID)
+			assert.Empty(t, sub.Replay, lastEventID)
+		}
+
+		sub, err := s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10}, seen[1].ID)
+		require.NoError(t, err)
+		assert.False(t, sub.Reset)
+		assert.Equal(t, []entity.TimestampEvent{seen[2]}, sub.Replay)
+	})
+
+	t.Run("Slow Subscriber Dropped", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewStreamService(nil, newTestValidator(), 10).(*streamService)
+		sub, err := s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10}, "")
+		require.NoError(t, err)
+
+		for range StreamBufferSize + 1 {
+			s.handle(streamMessage(t, "create", incident))
+		}
+
+		assert.Len(t, receive(sub), StreamBufferSize)
+		_, ok := <-sub.Events
+		assert.False(t, ok, "the events channel is closed")
+		sub.Close()
+	})
+
+	t.Run("Invalid Filters", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewStreamService(nil, newTestValidator(), 10)
+		_, err := s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10, Tag: "unknown"}, "")
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func Test_streamService_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		prepare func(ctx context.Context, msgs chan []byte, m *bmocks.SubscriberMock) context.Context
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Stopped By Context",
+			prepare: func(ctx context.Context, msgs chan []byte, m *bmocks.SubscriberMock) context.Context {
+				ctx, cancel := context.WithCancel(ctx)
+				m.SubscribeMock.Expect(ctx).Return(msgs, nil)
+				cancel()
+				close(msgs)
+				return ctx
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Subscription Lost",
+			prepare: func(ctx context.Context, msgs chan []byte, m *bmocks.SubscriberMock) context.Context {
+				m.SubscribeMock.Expect(ctx).Return(msgs, nil)
+				close(msgs)
+				return ctx
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrStreamUnavailable)
+			},
+		},
+		{
+			name: "Subscribe Error",
+			prepare: func(ctx context.Context, msgs chan []byte, m *bmocks.SubscriberMock) context.Context {
+				m.SubscribeMock.Expect(ctx).Return(nil, errors.New("channel error"))
+				return ctx
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := minimock.NewController(t)
+			subscriberMock := bmocks.NewSubscriberMock(ctrl)
+
+			s := NewStreamService(subscriberMock, newTestValidator(), 10)
+			sub, err := s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10}, "")
+			require.NoError(t, err)
+
+			ctx := tt.prepare(t.Context(), make(chan []byte), subscriberMock)
+			tt.wantErr(t, s.Run(ctx))
+
+			_, ok := <-sub.Events
+			assert.False(t, ok, "subscriptions are closed when the stream stops")
+
+			_, err = s.Subscribe(streamContext(t), &entity.ListQueryParams{Limit: 10}, "")
+			assert.ErrorIs(t, err, ErrStreamUnavailable)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"time"
+)
+
+func (s *timestampService) Timeline(
+	ctx context.Context,
+	externalID string,
+	calendarID uuid.UUID,
+) (*entity.Timeline, error) {
+	if externalID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	cal, err := loadCalendar(ctx, s.calendars, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.storage.ListByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	now := time.Now().UTC()
+	clock := slaClock{calendar: cal, pauses: pausedIntervals(list, now)}
+
+	return buildTimeline(externalID, list, clock, now), nil
+}
+
+// buildTimeline expects list in chronological order. The entity counts as open from its earliest
+// timestamp until the first resolved or closed stage, or until now if neither was reached.
+func buildTimeline(externalID string, list []*entity.Timestamp, clock slaClock, now time.Time) *entity.Timeline {
+	tl := &entity.Timeline{
+		ExternalID:   externalID,
+		CurrentStage: list[len(list)-1].Stage,
+		Open:         true,
+		OpenedAt:     list[0].Timestamp,
+		Entries:      make([]*entity.TimelineEntry, 0, len(list)),
+	}
+
+	for i, ts := range list {
+		entry := &entity.TimelineEntry{Timestamp: *ts}
+
+		if i > 0 {
+			delta := clock.elapsed(list[i-1].Timestamp, ts.Timestamp)
+			entry.SincePrevious = &delta
+			entry.BusinessSincePrevious = clock.business(list[i-1].Timestamp, ts.Timestamp)
+		}
+
+		if ts.Timestamp.Before(tl.OpenedAt) {
+			tl.OpenedAt = ts.Timestamp
+		}
+
+		if entity.IsClosingStage(ts.Stage) && (tl.ClosedAt == nil || ts.Timestamp.Before(*tl.ClosedAt)) {
+			closedAt := ts.Timestamp
+			tl.ClosedAt = &closedAt
+			tl.Open = false
+		}
+
+		tl.Entries = append(tl.Entries, entry)
+	}
+
+	end := now
+	if tl.ClosedAt != nil {
+		end = *tl.ClosedAt
+	}
+	tl.OpenDuration = clock.elapsed(tl.OpenedAt, end)
+	tl.BusinessOpenDuration = clock.business(tl.OpenedAt, end)
+	tl.PausedDuration = clock.paused(tl.OpenedAt, end)
+
+	return tl
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_timestampService_Timeline(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 7, 13, 15, 0, 0, 0, time.UTC)
+
+	type args struct {
+		externalID string
+	}
+	tests := []struct {
+		name    string
+		prepare func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock)
+		args    args
+		check   func(t *testing.T, tl *entity.Timeline)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Resolved Entity",
+			args: args{externalID: "INC-1"},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {
+				storageMock.ListByExternalIDMock.Expect(ctx, a.externalID).Return([]*entity.Timestamp{
+					{ExternalID: a.externalID, Tag: entity.TagIncident, Stage: entity.StageCreated, Timestamp: created},
+					{
+						ExternalID: a.externalID,
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageAcknowledged,
+						Timestamp:  created.Add(15 * time.Minute),
+					},
+					{
+						ExternalID: a.externalID,
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageResolved,
+						Timestamp:  created.Add(2 * time.Hour),
+					},
+					{
+						ExternalID: a.externalID,
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageClosed,
+						Timestamp:  created.Add(3 * time.Hour),
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, tl *entity.Timeline) {
+				assert.False(t, tl.Open)
+				assert.Equal(t, entity.StageClosed, tl.CurrentStage)
+				assert.Equal(t, entity.Duration(2*time.Hour), tl.OpenDuration)
+				require.Len(t, tl.Entries, 4)
+				assert.Nil(t, tl.Entries[0].SincePrevious)
+				assert.Equal(t, entity.Duration(15*time.Minute), *tl.Entries[1].SincePrevious)
+				assert.Equal(t, entity.Duration(105*time.Minute), *tl.Entries[2].SincePrevious)
+				assert.Equal(t, entity.Duration(time.Hour), *tl.Entries[3].SincePrevious)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Open Entity",
+			args: args{externalID: "INC-2"},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {
+				storageMock.ListByExternalIDMock.Expect(ctx, a.externalID).Return([]*entity.Timestamp{
+					{ExternalID: a.externalID, Tag: entity.TagIncident, Stage: entity.StageCreated, Timestamp: created},
+					{
+						ExternalID: a.externalID,
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageInProgress,
+						Timestamp:  created.Add(time.Hour),
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, tl *entity.Timeline) {
+				assert.True(t, tl.Open)
+				assert.Nil(t, tl.ClosedAt)
+				assert.Equal(t, entity.StageInProgress, tl.CurrentStage)
+				assert.Greater(t, time.Duration(tl.OpenDuration), time.Hour)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Paused Entity",
+			args: args{externalID: "INC-4"},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {
+				storageMock.ListByExternalIDMock.Expect(ctx, a.externalID).Return([]*entity.Timestamp{
+					{ExternalID: a.externalID, Tag: entity.TagIncident, Stage: entity.StageCreated, Timestamp: created},
+					{
+						ExternalID: a.externalID,
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageOnHold,
+						Timestamp:  created.Add(30 * time.Minute),
+					},
+					{
+						ExternalID: a.externalID,
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageResumed,
+						Timestamp:  created.Add(90 * time.Minute),
+					},
+					{
+						ExternalID: a.externalID,
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageResolved,
+						Timestamp:  created.Add(2 * time.Hour),
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, tl *entity.Timeline) {
+				assert.False(t, tl.Open)
+				assert.Equal(t, entity.Duration(time.Hour), tl.OpenDuration)
+				assert.Equal(t, entity.Duration(time.Hour), tl.PausedDuration)
+				require.Len(t, tl.Entries, 4)
+				assert.Equal(t, entity.Duration(0), *tl.Entries[2].SincePrevious)
+				assert.Equal(t, entity.Duration(30*time.Minute), *tl.Entries[3].SincePrevious)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Empty External ID",
+			args:    args{externalID: ""},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Not Found",
+			args: args{externalID: "missing"},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {
+				storageMock.ListByExternalIDMock.Expect(ctx, a.externalID).Return(nil, nil)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, repository.ErrNotFound)
+			},
+		},
+		{
+			name: "Storage Error",
+			args: args{externalID: "INC-3"},
+			prepare: func(ctx context.Context, a args, storageMock *smocks.TimestampStorageMock) {
+				storageMock.ListByExternalIDMock.Expect(ctx, a.externalID).Return(nil, errors.New("storage error"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewTimestampStorageMock(ctrl)
+
+			s := &timestampService{
+				storage: storageMock,
+			}
+
+			tt.prepare(ctx, tt.args, storageMock)
+
+			got, err := s.Timeline(ctx, tt.args.externalID, uuid.Nil)
+			tt.wantErr(t, err)
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/jwt"
+	"slices"
+	"strings"
+)
+
+var (
+	errNoSubject = errors.New("token has no subject")
+	errNoTenant  = errors.New("token has no tenant")
+)
+
+// TokenVerifier checks the signature and registered claims of a bearer token and returns its claims.
+type TokenVerifier interface {
+	Verify(token string) (jwt.Claims, error)
+}
+
+// TokenClaims selects the claims a bearer token is mapped to a Principal from.
+type TokenClaims struct {
+	// Name is the claim recorded as the actor of changes; the subject is used when it is missing.
+	Name string
+	// Tenant is the claim naming the tenant of the caller. Tokens without it are rejected.
+	Tenant string
+	// Scope is the claim listing what the caller may do, as a space-separated string or an array.
+	Scope string
+	// Permissions maps values of the Scope claim, such as roles, to the scopes they grant. A value that
+	// is a scope itself grants that scope.
+	Permissions map[string][]entity.Scope
+}
+
+type tokenAuthenticator struct {
+	verifier TokenVerifier
+	claims   TokenClaims
+}
+
+// NewTokenAuthenticator accepts JWTs checked by verifier. The subject of a token becomes the ID of its
+// Principal.
+func NewTokenAuthenticator(verifier TokenVerifier, claims TokenClaims) Authenticator {
+	return &tokenAuthenticator{
+		verifier: verifier,
+		claims:   claims,
+	}
+}
+
+func (a *tokenAuthenticator) Authenticate(_ context.Context, token string) (*entity.Principal, error) {
+	// Anything but a compact JWS, such as an API key, is left to other authenticators.
+	if strings.Count(token, ".") != 2 {
+		return nil, ErrUnauthenticated
+	}
+
+	claims, err := a.verifier.Verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnauthenticated, err)
+	}
+
+	sub := claims.String("sub")
+	if sub == "" {
+		return nil, fmt.Errorf("%w: %w", ErrUnauthenticated, errNoSubject)
+	}
+
+	tenant := claims.String(a.claims.Tenant)
+	if tenant == "" {
+		return nil, fmt.Errorf("%w: %w", ErrUnauthenticated, errNoTenant)
+	}
+
+	name := claims.String(a.claims.Name)
+	if name == "" {
+		name = sub
+	}
+
+	return &entity.Principal{ID: sub, Name: name, Tenant: tenant, Scopes: a.scopes(claims)}, nil
+}
+
+func (a *tokenAuthenticator) scopes(claims jwt.Claims) []entity.Scope {
+	var scopes []entity.Scope
+
+	grant := func(scope entity.Scope) {
+		if scope.Valid() && !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	for _, value := range claims.Strings(a.claims.Scope) {
+		if mapped, ok := a.claims.Permissions[value]; ok {
+			for _, scope := range mapped {
+				grant(scope)
+			}
+			continue
+		}
+		grant(entity.Scope(value))
+	}
+
+	return scopes
+}
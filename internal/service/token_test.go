@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type verifierFunc func(token string) (jwt.Claims, error)
+
+func (f verifierFunc) Verify(token string) (jwt.Claims, error) {
+	return f(token)
+}
+
+func Test_tokenAuthenticator_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	const token = "header.payload.signature"
+
+	claims := TokenClaims{
+		Name:   "email",
+		Tenant: "org",
+		Scope:  "roles",
+		Permissions: map[string][]entity.Scope{
+			"sla-viewer":   {entity.ScopeTimestampsRead, entity.ScopeSLARead},
+			"sla-operator": {entity.ScopeTimestampsRead, entity.ScopeTimestampsWrite},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		claims  jwt.Claims
+		err     error
+		want    *entity.Principal
+		wantErr error
+	}{
+		{
+			name:  "Mapped Roles",
+			token: token,
+			claims: jwt.Claims{
+				"sub":   "user-1",
+				"email": "oncall@example.com",
+				"org":   "acme",
+				"roles": []any{"sla-viewer", "sla-operator", "unrelated"},
+			},
+			want: &entity.Principal{
+				ID:     "user-1",
+				Name:   "oncall@example.com",
+				Tenant: "acme",
+				Scopes: []entity.Scope{entity.ScopeTimestampsRead, entity.ScopeSLARead, entity.ScopeTimestampsWrite},
+			},
+		},
+		{
+			name:  "Scopes Without Name",
+			token: token,
+			claims: jwt.Claims{
+				"sub":   "svc-incidents",
+				"org":   "acme",
+				"roles": "timestamps:delete timestamps:everything",
+			},
+			want: &entity.Principal{
+				ID:     "svc-incidents",
+				Name:   "svc-incidents",
+				Tenant: "acme",
+				Scopes: []entity.Scope{entity.ScopeTimestampsDelete},
+			},
+		},
+		{
+			name:    "Not A JWT",
+			token:   APIKeyPrefix + "secret",
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "Invalid Token",
+			token:   token,
+			err:     jwt.ErrExpired,
+			wantErr: jwt.ErrExpired,
+		},
+		{
+			name:    "No Subject",
+			token:   token,
+			claims:  jwt.Claims{"org": "acme", "roles": "sla-viewer"},
+			wantErr: errNoSubject,
+		},
+		{
+			name:    "No Tenant",
+			token:   token,
+			claims:  jwt.Claims{"sub": "user-1", "roles": "sla-viewer"},
+			wantErr: errNoTenant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := NewTokenAuthenticator(verifierFunc(func(got string) (jwt.Claims, error) {
+				assert.Equal(t, tt.token, got)
+				return tt.claims, tt.err
+			}), claims)
+
+			got, err := a.Authenticate(t.Context(), tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, ErrUnauthenticated)
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuthenticators_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	key := APIKeyPrefix + "secret"
+
+	tokens := NewTokenAuthenticator(verifierFunc(func(string) (jwt.Claims, error) {
+		return nil, jwt.ErrInvalidSignature
+	}), TokenClaims{Scope: "scope"})
+
+	tests := []struct {
+		name       string
+		credential string
+		prepare    func(ctx context.Context, storageMock *smocks.APIKeyStorageMock)
+		want       *entity.Principal
+		wantErr    error
+	}{
+		{
+			name:       "API Key",
+			credential: key,
+			prepare: func(ctx context.Context, storageMock *smocks.APIKeyStorageMock) {
+				storageMock.GetByHashMock.Expect(ctx, HashAPIKey(key)).
+					Return(&entity.APIKey{ID: id, Name: "bot", Tenant: "acme"}, nil)
+			},
+			want: &entity.Principal{ID: id.String(), Name: "bot", Tenant: "acme"},
+		},
+		{
+			name:       "Rejected Token Keeps Reason",
+			credential: "header.payload.signature",
+			prepare:    func(ctx context.Context, storageMock *smocks.APIKeyStorageMock) {},
+			wantErr:    jwt.ErrInvalidSignature,
+		},
+		{
+			name:       "Storage Error Stops",
+			credential: key,
+			prepare: func(ctx context.Context, storageMock *smocks.APIKeyStorageMock) {
+				storageMock.GetByHashMock.Expect(ctx, HashAPIKey(key)).Return(nil, errors.New("storage error"))
+			},
+			wantErr: errors.New("storage error"),
+		},
+		{
+			name:       "Unknown Credential",
+			credential: "something-else",
+			prepare:    func(ctx context.Context, storageMock *smocks.APIKeyStorageMock) {},
+			wantErr:    ErrUnauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewAPIKeyStorageMock(ctrl)
+			tt.prepare(ctx, storageMock)
+
+			a := Authenticators{NewAPIKeyService(storageMock, newTestValidator()), tokens}
+
+			got, err := a.Authenticate(ctx, tt.credential)
+			switch {
+			case tt.wantErr == nil:
+				require.NoError(t, err)
+			case errors.Is(tt.wantErr, ErrUnauthenticated), errors.Is(tt.wantErr, jwt.ErrInvalidSignature):
+				assert.ErrorIs(t, err, ErrUnauthenticated)
+				assert.ErrorIs(t, err, tt.wantErr)
+			default:
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"time"
+)
+
+var (
+	ErrInvalidTransition = apperr.New(apperr.KindUnprocessable, "invalid_transition", "invalid stage transition")
+)
+
+// TransitionRules configures the stage transition check performed on create.
+type TransitionRules struct {
+	Mode entity.TransitionMode
+	// Graphs holds per-tag graphs; tags without one use entity.DefaultTransitionGraph.
+	Graphs map[entity.Tag]entity.TransitionGraph
+}
+
+func (r TransitionRules) graph(tag entity.Tag) entity.TransitionGraph {
+	if g, ok := r.Graphs[tag]; ok {
+		return g
+	}
+
+	return entity.DefaultTransitionGraph()
+}
+
+// needsHistory reports whether creating ts requires the existing stages of its external_id.
+func (r TransitionRules) needsHistory(ts *entity.Timestamp) bool {
+	return r.Mode != entity.TransitionModeOff || entity.IsPauseStage(ts.Stage)
+}
+
+// checkTransition places ts among the existing stages of its external_id and tag by timestamp and
+// checks the transitions into and out of it. Only the neighbours of ts are looked at, so history that
+// was stored in warn or off mode does not block new timestamps. Stages the graph does not know, such as
+// ones added to the catalog at runtime, are not checked: neither ts itself nor a neighbour of it.
+func (r TransitionRules) checkTransition(list []*entity.Timestamp, ts *entity.Timestamp) error {
+	prev, next, dup := neighbours(list, ts)
+	if dup != nil {
+		return fmt.Errorf("%s was already reached at %s: %w",
+			ts.Stage, dup.Timestamp.Format(time.RFC3339), ErrInvalidTransition)
+	}
+
+	g := r.graph(ts.Tag)
+	if !g.Knows(ts.Stage) {
+		return nil
+	}
+
+	switch {
+	case prev == nil && !g.Allows("", ts.Stage):
+		return fmt.Errorf("%s cannot be the first stage, expected one of %v: %w",
+			ts.Stage, g[""], ErrInvalidTransition)
+	case prev != nil && g.Knows(prev.Stage) && !g.Allows(prev.Stage, ts.Stage):
+		return fmt.Errorf("%s cannot follow %s reached at %s, expected one of %v: %w",
+			ts.Stage, prev.Stage, prev.Timestamp.Format(time.RFC3339), g[prev.Stage], ErrInvalidTransition)
+	case next != nil && g.Knows(next.Stage) && !g.Allows(ts.Stage, next.Stage):
+		return fmt.Errorf("%s cannot precede %s reached at %s: %w",
+			ts.Stage, next.Stage, next.Timestamp.Format(time.RFC3339), ErrInvalidTransition)
+	}
+
+	return nil
+}
+
+// neighbours finds the latest stage of the same tag at or before ts, the earliest one after it and,
+// for stages that can only be reached once, an earlier timestamp of the same stage.
+func neighbours(list []*entity.Timestamp, ts *entity.Timestamp) (prev, next, dup *entity.Timestamp) {
+	for _, other := range list {
+		if other.Tag != ts.Tag {
+			continue
+		}
+
+		if other.Stage == ts.Stage && !entity.IsPauseStage(ts.Stage) {
+			dup = other
+		}
+
+		switch {
+		case !other.Timestamp.After(ts.Timestamp):
+			if prev == nil || !other.Timestamp.Before(prev.Timestamp) {
+				prev = other
+			}
+		case next == nil || other.Timestamp.Before(next.Timestamp):
+			next = other
+		}
+	}
+
+	return prev, next, dup
+}
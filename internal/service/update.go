@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"github.com/sdvaanyaa/sla-timestamp-api/pkg/mergepatch"
+)
+
+func (s *timestampService) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	req *entity.UpdateTimestampRequest,
+	version int,
+) (*entity.Timestamp, error) {
+	if err := s.val.Struct(req); err != nil {
+		return nil, apperr.Validation(ErrInvalidInput, err)
+	}
+
+	return s.update(ctx, id, version, func(ts *entity.Timestamp) error {
+		ts.Timestamp = req.Timestamp
+		ts.Meta = req.Meta
+		return nil
+	})
+}
+
+func (s *timestampService) Patch(
+	ctx context.Context,
+	id uuid.UUID,
+	patch *entity.TimestampPatch,
+	version int,
+) (*entity.Timestamp, error) {
+	return s.update(ctx, id, version, func(ts *entity.Timestamp) error {
+		if patch.Timestamp != nil {
+			ts.Timestamp = *patch.Timestamp
+		}
+
+		if patch.Meta == nil {
+			return nil
+		}
+
+		var metaPatch any
+		if err := json.Unmarshal(patch.Meta, &metaPatch); err != nil {
+			return ErrInvalidInput
+		}
+
+		switch meta := mergepatch.Apply(ts.Meta, metaPatch).(type) {
+		case nil:
+			ts.Meta = nil
+		case map[string]any:
+			ts.Meta = meta
+		default:
+			return fmt.Errorf("meta must be an object: %w", ErrInvalidInput)
+		}
+
+		return nil
+	})
+}
+
+// update applies change to the stored timestamp and saves it. version is the one the client sent in
+// If-Match, or 0 if it sent none; the stored version guards against concurrent writes either way.
+func (s *timestampService) update(
+	ctx context.Context,
+	id uuid.UUID,
+	version int,
+	change func(ts *entity.Timestamp) error,
+) (*entity.Timestamp, error) {
+	if id == uuid.Nil || version < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	ts, err := s.storage.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 && version != ts.Version {
+		return nil, fmt.Errorf("update: %w", repository.ErrVersionConflict)
+	}
+
+	if err = change(ts); err != nil {
+		return nil, err
+	}
+
+	if ts.Timestamp.IsZero() {
+		return nil, ErrInvalidInput
+	}
+
+	if s.transitions.needsHistory(ts) {
+		if err = s.checkHistory(ctx, ts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.storage.Update(ctx, ts, ts.Version); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, "update", ts)
+
+	return ts, nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	bmocks "github.com/sdvaanyaa/sla-timestamp-api/pkg/broker/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var updateTestID = uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+func storedTimestamp() *entity.Timestamp {
+	return &entity.Timestamp{
+		ID:         updateTestID,
+		ExternalID: "ext-1",
+		Timestamp:  time.Date(2025, 7, 13, 10, 0, 0, 0, time.UTC),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageAcknowledged,
+		Meta:       map[string]any{"team": "core", "priority": "high"},
+		Version:    3,
+	}
+}
+
+func Test_timestampService_Update(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		storageMock *smocks.TimestampStorageMock
+		brokerMock  *bmocks.BrokerMock
+	}
+	type args struct {
+		req     *entity.UpdateTimestampRequest
+		version int
+	}
+	tests := []struct {
+		name    string
+		mode    entity.TransitionMode
+		prepare func(ctx context.Context, a args, f *fields)
+		args    args
+		want    *entity.Timestamp
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Success",
+			mode: entity.TransitionModeOff,
+			args: args{
+				req: &entity.UpdateTimestampRequest{
+					Timestamp: time.Date(2025, 7, 13, 10, 30, 0, 0, time.UTC),
+					Meta:      map[string]any{"team": "sre"},
+				},
+				version: 3,
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.GetByIDMock.Expect(ctx, updateTestID).Return(storedTimestamp(), nil)
+				f.storageMock.UpdateMock.Set(func(_ context.Context, ts *entity.Timestamp, version int) error {
+					assert.Equal(t, 3, version)
+					ts.Version = 4
+					return nil
+				})
+				f.brokerMock.PublishMock.Set(func(_ context.Context, msg []byte) error {
+					var event map[string]any
+					_ = json.Unmarshal(msg, &event)
+					assert.Equal(t, "update", event["action"])
+					return nil
+				})
+			},
+			want: func() *entity.Timestamp {
+				ts := storedTimestamp()
+				ts.Timestamp = time.Date(2025, 7, 13, 10, 30, 0, 0, time.UTC)
+				ts.Meta = map[string]any{"team": "sre"}
+				ts.Version = 4
+				return ts
+			}(),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Invalid Input",
+			mode: entity.TransitionModeOff,
+			args: args{
+				req: &entity.UpdateTimestampRequest{},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Not Found",
+			mode: entity.TransitionModeOff,
+			args: args{
+				req: &entity.UpdateTimestampRequest{Timestamp: time.Date(2025, 7, 13, 10, 30, 0, 0, time.UTC)},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.GetByIDMock.Expect(ctx, updateTestID).Return(nil, repository.ErrNotFound)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, repository.ErrNotFound)
+			},
+		},
+		{
+			name: "Stale If-Match",
+			mode: entity.TransitionModeOff,
+			args: args{
+				req:     &entity.UpdateTimestampRequest{Timestamp: time.Date(2025, 7, 13, 10, 30, 0, 0, time.UTC)},
+				version: 2,
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.GetByIDMock.Expect(ctx, updateTestID).Return(storedTimestamp(), nil)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, repository.ErrVersionConflict)
+			},
+		},
+		{
+			name: "Concurrent Update",
+			mode: entity.TransitionModeOff,
+			args: args{
+				req: &entity.UpdateTimestampRequest{Timestamp: time.Date(2025, 7, 13, 10, 30, 0, 0, time.UTC)},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.GetByIDMock.Expect(ctx, updateTestID).Return(storedTimestamp(), nil)
+				f.storageMock.UpdateMock.Return(repository.ErrVersionConflict)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, repository.ErrVersionConflict)
+			},
+		},
+		{
+			name: "Moved Within History",
+			mode: entity.TransitionModeStrict,
+			args: args{
+				req: &entity.UpdateTimestampRequest{Timestamp: time.Date(2025, 7, 13, 10, 30, 0, 0, time.UTC)},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.GetByIDMock.Expect(ctx, updateTestID).Return(storedTimestamp(), nil)
+				f.storageMock.ListByExternalIDMock.Expect(ctx, "ext-1").Return([]*entity.Timestamp{
+					{
+						ExternalID: "ext-1",
+						Timestamp:  time.Date(2025, 7, 13, 9, 0, 0, 0, time.UTC),
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageCreated,
+					},
+					storedTimestamp(),
+				}, nil)
+				f.storageMock.UpdateMock.Return(nil)
+				f.brokerMock.PublishMock.Return(nil)
+			},
+			want: func() *entity.Timestamp {
+				ts := storedTimestamp()
+				ts.Timestamp = time.Date(2025, 7, 13, 10, 30, 0, 0, time.UTC)
+				ts.Meta = nil
+				return ts
+			}(),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Moved Before Created",
+			mode: entity.TransitionModeStrict,
+			args: args{
+				req: &entity.UpdateTimestampRequest{Timestamp: time.Date(2025, 7, 13, 8, 0, 0, 0, time.UTC)},
+			},
+			prepare: func(ctx context.Context, a args, f *fields) {
+				f.storageMock.GetByIDMock.Expect(ctx, updateTestID).Return(storedTimestamp(), nil)
+				f.storageMock.ListByExternalIDMock.Expect(ctx, "ext-1").Return([]*entity.Timestamp{
+					{
+						ExternalID: "ext-1",
+						Timestamp:  time.Date(2025, 7, 13, 9, 0, 0, 0, time.UTC),
+						Tag:        entity.TagIncident,
+						Stage:      entity.StageCreated,
+					},
+					storedTimestamp(),
+				}, nil)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrInvalidTransition)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewTimestampStorageMock(ctrl)
+			brokerMock := bmocks.NewBrokerMock(ctrl)
+
+			s := timestampService{
+				storage:     storageMock,
+				val:         newTestValidator(),
+				broker:      brokerMock,
+				transitions: TransitionRules{Mode: tt.mode},
+			}
+
+			tt.prepare(ctx, tt.args, &fields{
+				storageMock: storageMock,
+				brokerMock:  brokerMock,
+			})
+
+			got, err := s.Update(ctx, updateTestID, tt.args.req, tt.args.version)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_timestampService_Patch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		patch   string
+		want    map[string]any
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "Merge Meta",
+			patch:   `{"meta": {"priority": null, "owner": {"name": "ann"}}}`,
+			want:    map[string]any{"team": "core", "owner": map[string]any{"name": "ann"}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Clear Meta",
+			patch:   `{"meta": null}`,
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Timestamp Only",
+			patch:   `{"timestamp": "2025-07-13T10:30:00Z"}`,
+			want:    map[string]any{"team": "core", "priority": "high"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:  "Meta Not An Object",
+			patch: `{"meta": [1, 2]}`,
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, ErrInvalidInput)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewTimestampStorageMock(ctrl)
+			brokerMock := bmocks.NewBrokerMock(ctrl)
+
+			storageMock.GetByIDMock.Expect(ctx, updateTestID).Return(storedTimestamp(), nil)
+			storageMock.UpdateMock.Optional().Return(nil)
+			brokerMock.PublishMock.Optional().Return(nil)
+
+			s := timestampService{
+				storage:     storageMock,
+				val:         newTestValidator(),
+				broker:      brokerMock,
+				transitions: TransitionRules{Mode: entity.TransitionModeOff},
+			}
+
+			var patch entity.TimestampPatch
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := s.Patch(ctx, updateTestID, &patch, 0)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got.Meta)
+		})
+	}
+}
+
+func TestTimestampPatch_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var patch entity.TimestampPatch
+	err := json.Unmarshal([]byte(`{"stage": "closed"}`), &patch)
+	assert.True(t, errors.Is(err, entity.ErrImmutableField))
+}
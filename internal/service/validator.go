@@ -0,0 +1,42 @@
+package service
+
+import (
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NewValidator returns a validator that names fields as clients know them: by their JSON name, or for
+// fields without one, such as query parameters and secrets, by the snake_case form of the Go name.
+func NewValidator() *validator.Validate {
+	val := validator.New()
+	val.RegisterTagNameFunc(func(f reflect.StructField) string {
+		// "-" would exclude the field from validation, so it falls back to the Go name too.
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+			return name
+		}
+		return snakeCase(f.Name)
+	})
+
+	return val
+}
+
+// snakeCase converts a Go name such as ExternalID or TimestampFrom to external_id or timestamp_from.
+func snakeCase(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewValidator(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		ExternalID    string `json:"external_id" validate:"required"`
+		Secret        string `json:"-" validate:"required"`
+		TimestampFrom int    `validate:"min=1"`
+		HTTPStatus    int    `validate:"min=100"`
+	}
+
+	err := apperr.Validation(ErrInvalidInput, NewValidator().Struct(request{}))
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	assert.Equal(t, []apperr.FieldError{
+		{Field: "external_id", Rule: "required"},
+		{Field: "secret", Rule: "required"},
+		{Field: "timestamp_from", Rule: "min", Param: "1"},
+		{Field: "http_status", Rule: "min", Param: "100"},
+	}, apperr.FieldsOf(err))
+}
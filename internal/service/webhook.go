@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/apperr"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, w *entity.Webhook) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Webhook, error)
+	List(ctx context.Context) ([]*entity.Webhook, error)
+	Update(ctx context.Context, w *entity.Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Deliveries and Attempts return the delivery history of a webhook, newest first.
+	Deliveries(ctx context.Context, id uuid.UUID, q entity.WebhookDeliveryQuery) ([]*entity.WebhookDelivery, error)
+	Attempts(ctx context.Context, id uuid.UUID, q entity.WebhookDeliveryQuery) ([]*entity.WebhookAttempt, error)
+}
+
+type webhookService struct {
+	storage repository.WebhookStorage
+	val     *validator.Validate
+}
+
+func NewWebhookService(storage repository.WebhookStorage, val *validator.Validate) WebhookService {
+	return &webhookService{
+		storage: storage,
+		val:     val,
+	}
+}
+
+func (s *webhookService) Create(ctx context.Context, w *entity.Webhook) (uuid.UUID, error) {
+	if err := s.validateWebhook(w); err != nil {
+		return uuid.Nil, err
+	}
+
+	return s.storage.Create(ctx, w)
+}
+
+func (s *webhookService) GetByID(ctx context.Context, id uuid.UUID) (*entity.Webhook, error) {
+	if id == uuid.Nil {
+		return nil, ErrInvalidInput
+	}
+
+	return s.storage.GetByID(ctx, id)
+}
+
+func (s *webhookService) List(ctx context.Context) ([]*entity.Webhook, error) {
+	return s.storage.List(ctx)
+}
+
+func (s *webhookService) Update(ctx context.Context, w *entity.Webhook) error {
+	if w.ID == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	if err := s.validateWebhook(w); err != nil {
+		return err
+	}
+
+	return s.storage.Update(ctx, w)
+}
+
+func (s *webhookService) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	return s.storage.Delete(ctx, id)
+}
+
+func (s *webhookService) Deliveries(
+	ctx context.Context,
+	id uuid.UUID,
+	q entity.WebhookDeliveryQuery,
+) ([]*entity.WebhookDelivery, error) {
+	if err := s.validateHistoryQuery(ctx, id, q); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListDeliveries(ctx, id, q)
+}
+
+func (s *webhookService) Attempts(
+	ctx context.Context,
+	id uuid.UUID,
+	q entity.WebhookDeliveryQuery,
+) ([]*entity.WebhookAttempt, error) {
+	if err := s.validateHistoryQuery(ctx, id, q); err != nil {
+		return nil, err
+	}
+
+	return s.storage.ListAttempts(ctx, id, q)
+}
+
+func (s *webhookService) validateWebhook(w *entity.Webhook) error {
+	if err := s.val.Struct(w); err != nil {
+		return apperr.Validation(ErrInvalidInput, err)
+	}
+
+	for k := range w.MetaFilter {
+		if k == "" {
+			return ErrInvalidInput
+		}
+	}
+
+	return nil
+}
+
+// validateHistoryQuery also checks that the webhook exists, so that an unknown one is reported as not found
+// rather than as having no history.
+func (s *webhookService) validateHistoryQuery(ctx context.Context, id uuid.UUID, q entity.WebhookDeliveryQuery) error {
+	if id == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	if err := s.val.Struct(q); err != nil {
+		return apperr.Validation(ErrInvalidInput, err)
+	}
+
+	_, err := s.storage.GetByID(ctx, id)
+
+	return err
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrWebhookTargetForbidden is returned when a webhook URL resolves to an address inside the deployment,
+// such as loopback, a private network or the link-local cloud metadata endpoint.
+var ErrWebhookTargetForbidden = errors.New("webhook target address is not allowed")
+
+// NewWebhookClient returns the client webhooks are delivered with. Unless allowPrivate is set, it only
+// connects to public addresses, checked after DNS resolution so that a hostname cannot point it inside the
+// deployment. Redirects are not followed: a 3xx response fails the delivery like any other non-2xx status.
+func NewWebhookClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = webhookDialControl
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would be dialed instead of the target, bypassing the address check.
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookDialControl rejects connections to addresses that are not publicly routable.
+func webhookDialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrWebhookTargetForbidden, address)
+	}
+
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() || addr.IsMulticast() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("%w: %s", ErrWebhookTargetForbidden, addr)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_webhookDialControl(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr error
+	}{
+		{name: "Public IPv4", address: "93.184.216.34:443", wantErr: nil},
+		{name: "Public IPv6", address: "[2606:2800:220:1::1]:443", wantErr: nil},
+		{name: "Loopback", address: "127.0.0.1:80", wantErr: ErrWebhookTargetForbidden},
+		{name: "Loopback IPv6", address: "[::1]:80", wantErr: ErrWebhookTargetForbidden},
+		{name: "Metadata Endpoint", address: "169.254.169.254:80", wantErr: ErrWebhookTargetForbidden},
+		{name: "Private 10/8", address: "10.0.0.5:8080", wantErr: ErrWebhookTargetForbidden},
+		{name: "Private 172.16/12", address: "172.16.0.1:443", wantErr: ErrWebhookTargetForbidden},
+		{name: "Private 192.168/16", address: "192.168.1.1:443", wantErr: ErrWebhookTargetForbidden},
+		{name: "Unique Local IPv6", address: "[fd00::1]:443", wantErr: ErrWebhookTargetForbidden},
+		{name: "Unspecified", address: "0.0.0.0:80", wantErr: ErrWebhookTargetForbidden},
+		{name: "IPv4-Mapped Loopback", address: "[::ffff:127.0.0.1]:80", wantErr: ErrWebhookTargetForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, webhookDialControl("tcp", tt.address, nil), tt.wantErr)
+		})
+	}
+}
+
+func TestNewWebhookClient(t *testing.T) {
+	t.Parallel()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/target", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(receiver.Close)
+
+	t.Run("Private Target Rejected", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := NewWebhookClient(time.Second, false).Get(receiver.URL)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		require.ErrorIs(t, err, ErrWebhookTargetForbidden)
+	})
+
+	t.Run("Private Target Allowed", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := NewWebhookClient(time.Second, true).Get(receiver.URL)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("Redirect Not Followed", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := NewWebhookClient(time.Second, true).Get(receiver.URL + "/redirect")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+	})
+}
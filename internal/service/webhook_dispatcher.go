@@ -0,0 +1,205 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers of a webhook delivery. The signature is the hex HMAC-SHA256 of the timestamp header, a dot and
+// the body, keyed with the secret of the webhook and prefixed with "sha256=".
+const (
+	WebhookIDHeader        = "X-Webhook-ID"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// WebhookBatchSize is the number of deliveries DeliverDue claims, and sends concurrently, at a time.
+const WebhookBatchSize = 50
+
+const (
+	// webhookLeaseMargin is added to the request timeout to postpone a claimed delivery, so that no other
+	// dispatcher picks it up while it is being sent.
+	webhookLeaseMargin = 30 * time.Second
+	// webhookResponseLimit caps how much of a response body is read before the connection is reused.
+	webhookResponseLimit = 64 << 10
+)
+
+// WebhookRetryPolicy retries a failed delivery after BaseDelay, doubling the delay after every further
+// failure up to MaxDelay. The delivery is dead-lettered once MaxAttempts attempts have failed.
+type WebhookRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Delay returns how long to wait after the given failed attempt, counted from 1.
+func (p WebhookRetryPolicy) Delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+
+	return min(d, p.MaxDelay)
+}
+
+type WebhookDispatcher interface {
+	// Enqueue queues a delivery of a broker event to every webhook whose filter matches it.
+	Enqueue(ctx context.Context, msg []byte) error
+
+	// DeliverDue sends the deliveries that are due and returns how many were attempted.
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+type webhookDispatcher struct {
+	storage repository.WebhookStorage
+	client  *http.Client
+	retry   WebhookRetryPolicy
+}
+
+func NewWebhookDispatcher(
+	storage repository.WebhookStorage,
+	client *http.Client,
+	retry WebhookRetryPolicy,
+) WebhookDispatcher {
+	return &webhookDispatcher{
+		storage: storage,
+		client:  client,
+		retry:   retry,
+	}
+}
+
+func (s *webhookDispatcher) Enqueue(ctx context.Context, msg []byte) error {
+	action, tenant, list, err := decodeTimestampEvent(msg)
+	if err != nil || len(list) == 0 {
+		return err
+	}
+
+	// Only the webhooks of the tenant the timestamps belong to are notified.
+	ctx = entity.WithTenant(ctx, tenant)
+	webhooks, err := s.storage.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*entity.WebhookDelivery
+	for _, ts := range list {
+		for _, w := range webhooks {
+			if !w.Matches(ts) {
+				continue
+			}
+
+			d := &entity.WebhookDelivery{ID: uuid.New(), WebhookID: w.ID, Action: action}
+			d.Payload, err = json.Marshal(entity.WebhookPayload{ID: d.ID, Action: action, Timestamp: ts})
+			if err != nil {
+				return fmt.Errorf("marshal webhook payload: %w", err)
+			}
+
+			deliveries = append(deliveries, d)
+		}
+	}
+
+	return s.storage.CreateDeliveries(ctx, deliveries)
+}
+
+func (s *webhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := s.storage.ClaimDeliveries(ctx, WebhookBatchSize, s.client.Timeout+webhookLeaseMargin)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(deliveries))
+	for i, d := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.deliver(ctx, d)
+		}()
+	}
+	wg.Wait()
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// deliver makes one attempt to send d and records its outcome.
+func (s *webhookDispatcher) deliver(ctx context.Context, d *entity.WebhookDelivery) error {
+	start := time.Now()
+	statusCode, sendErr := s.send(ctx, d)
+
+	d.Attempts++
+	attempt := &entity.WebhookAttempt{
+		DeliveryID: d.ID,
+		WebhookID:  d.WebhookID,
+		Attempt:    d.Attempts,
+		StatusCode: statusCode,
+		Duration:   entity.Duration(time.Since(start)),
+	}
+
+	switch {
+	case sendErr == nil:
+		d.Status, d.LastError = entity.DeliveryDelivered, ""
+	case d.Attempts >= s.retry.MaxAttempts:
+		d.Status, d.LastError = entity.DeliveryDead, sendErr.Error()
+	default:
+		d.Status, d.LastError = entity.DeliveryPending, sendErr.Error()
+		d.NextAttemptAt = time.Now().Add(s.retry.Delay(d.Attempts))
+	}
+	attempt.Error = d.LastError
+
+	return s.storage.RecordAttempt(ctx, d, attempt)
+}
+
+// send posts the payload of d and returns the response status, or zero when no response was received. Any
+// status outside 2xx is an error.
+func (s *webhookDispatcher) send(ctx context.Context, d *entity.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, d.ID.String())
+	req.Header.Set(WebhookEventHeader, d.Action)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(d.Secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookResponseLimit))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// WebhookSignature returns the X-Webhook-Signature of a delivery body sent with the given timestamp header.
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	p := WebhookRetryPolicy{MaxAttempts: 8, BaseDelay: 10 * time.Second, MaxDelay: time.Minute}
+
+	assert.Equal(t, 10*time.Second, p.Delay(1))
+	assert.Equal(t, 20*time.Second, p.Delay(2))
+	assert.Equal(t, 40*time.Second, p.Delay(3))
+	assert.Equal(t, time.Minute, p.Delay(4))
+	assert.Equal(t, time.Minute, p.Delay(100))
+}
+
+func Test_webhookDispatcher_Enqueue(t *testing.T) {
+	t.Parallel()
+
+	incidents := &entity.Webhook{ID: uuid.New(), Tag: string(entity.TagIncident)}
+	core := &entity.Webhook{ID: uuid.New(), MetaFilter: map[string]any{"team": "core"}}
+	ts := &entity.Timestamp{
+		ID:    uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+		Tag:   entity.TagIncident,
+		Stage: entity.StageCreated,
+		Meta:  map[string]any{"team": "edge"},
+	}
+
+	// listIn lists webhooks only in the tenant the event was published in.
+	listIn := func(storageMock *smocks.WebhookStorageMock, tenant string, webhooks ...*entity.Webhook) {
+		storageMock.ListMock.Set(func(ctx context.Context) ([]*entity.Webhook, error) {
+			if entity.TenantFromContext(ctx) != tenant {
+				return nil, errors.New("unexpected tenant")
+			}
+			return webhooks, nil
+		})
+	}
+
+	tests := []struct {
+		name    string
+		msg     string
+		prepare func(storageMock *smocks.WebhookStorageMock)
+		want    []uuid.UUID
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Matching Webhooks",
+			msg:  `{"action":"create","tenant_id":"acme","data":` + mustJSON(t, ts) + `}`,
+			prepare: func(storageMock *smocks.WebhookStorageMock) {
+				listIn(storageMock, "acme", incidents, core)
+			},
+			want:    []uuid.UUID{incidents.ID},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Bulk Create",
+			msg:  `{"action":"bulk_create","data":[` + mustJSON(t, ts) + `,` + mustJSON(t, ts) + `]}`,
+			prepare: func(storageMock *smocks.WebhookStorageMock) {
+				listIn(storageMock, entity.DefaultTenant, incidents)
+			},
+			want:    []uuid.UUID{incidents.ID, incidents.ID},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Event Without Timestamp",
+			msg:     `{"action":"sla_breached","id":"123e4567-e89b-12d3-a456-426614174000"}`,
+			prepare: func(storageMock *smocks.WebhookStorageMock) {},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Invalid Message",
+			msg:     `not json`,
+			prepare: func(storageMock *smocks.WebhookStorageMock) {},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewWebhookStorageMock(ctrl)
+			tt.prepare(storageMock)
+
+			var got []uuid.UUID
+			if tt.want != nil {
+				storageMock.CreateDeliveriesMock.Set(
+					func(_ context.Context, deliveries []*entity.WebhookDelivery) error {
+						for _, d := range deliveries {
+							got = append(got, d.WebhookID)
+
+							var payload entity.WebhookPayload
+							require.NoError(t, json.Unmarshal(d.Payload, &payload))
+							assert.Equal(t, d.ID, payload.ID)
+							assert.Equal(t, "create", payload.Action)
+							assert.Equal(t, ts.ID, payload.Timestamp.ID)
+						}
+						return nil
+					},
+				)
+			}
+
+			d := NewWebhookDispatcher(storageMock, http.DefaultClient, WebhookRetryPolicy{})
+
+			err := d.Enqueue(ctx, []byte(tt.msg))
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_webhookDispatcher_DeliverDue(t *testing.T) {
+	t.Parallel()
+
+	const secret = "0123456789abcdef"
+	retry := WebhookRetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	tests := []struct {
+		name           string
+		status         int
+		attempts       int
+		wantStatus     entity.DeliveryStatus
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:       "Delivered",
+			status:     http.StatusNoContent,
+			wantStatus: entity.DeliveryDelivered,
+		},
+		{
+			name:           "Retry With Backoff",
+			status:         http.StatusInternalServerError,
+			attempts:       1,
+			wantStatus:     entity.DeliveryPending,
+			wantRetryAfter: 2 * time.Minute,
+		},
+		{
+			name:       "Dead After Last Attempt",
+			status:     http.StatusBadGateway,
+			attempts:   2,
+			wantStatus: entity.DeliveryDead,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			delivery := &entity.WebhookDelivery{
+				ID:        uuid.New(),
+				WebhookID: uuid.New(),
+				Action:    "create",
+				Payload:   json.RawMessage(`{"action":"create"}`),
+				Status:    entity.DeliveryPending,
+				Attempts:  tt.attempts,
+				Secret:    secret,
+			}
+
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				assert.Equal(t, string(delivery.Payload), string(body))
+				assert.Equal(t, delivery.ID.String(), r.Header.Get(WebhookIDHeader))
+				assert.Equal(t, "create", r.Header.Get(WebhookEventHeader))
+				assert.Equal(t,
+					WebhookSignature(secret, r.Header.Get(WebhookTimestampHeader), body),
+					r.Header.Get(WebhookSignatureHeader),
+				)
+
+				w.WriteHeader(tt.status)
+			}))
+			defer receiver.Close()
+			delivery.URL = receiver.URL
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewWebhookStorageMock(ctrl)
+			storageMock.ClaimDeliveriesMock.Return([]*entity.WebhookDelivery{delivery}, nil)
+
+			var recorded *entity.WebhookAttempt
+			storageMock.RecordAttemptMock.Set(
+				func(_ context.Context, _ *entity.WebhookDelivery, a *entity.WebhookAttempt) error {
+					recorded = a
+					return nil
+				},
+			)
+
+			d := NewWebhookDispatcher(storageMock, NewWebhookClient(time.Second, true), retry)
+
+			start := time.Now()
+			n, err := d.DeliverDue(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+
+			assert.Equal(t, tt.wantStatus, delivery.Status)
+			assert.Equal(t, tt.attempts+1, delivery.Attempts)
+			require.NotNil(t, recorded)
+			assert.Equal(t, tt.attempts+1, recorded.Attempt)
+			assert.Equal(t, tt.status, recorded.StatusCode)
+			assert.Equal(t, delivery.LastError, recorded.Error)
+
+			if tt.wantRetryAfter > 0 {
+				assert.WithinDuration(t, start.Add(tt.wantRetryAfter), delivery.NextAttemptAt, time.Second)
+			}
+		})
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return string(data)
+}
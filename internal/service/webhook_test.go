@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gojuno/minimock/v3"
+	"github.com/google/uuid"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/entity"
+	"github.com/sdvaanyaa/sla-timestamp-api/internal/repository"
+	smocks "github.com/sdvaanyaa/sla-timestamp-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_webhookService_Create(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	valid := func() *entity.Webhook {
+		return &entity.Webhook{
+			URL:        "https://example.com/hooks",
+			Secret:     "0123456789abcdef",
+			Tag:        string(entity.TagIncident),
+			Stage:      string(entity.StageResolved),
+			MetaFilter: map[string]any{"team": "core"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		webhook func() *entity.Webhook
+		prepare func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock)
+		want    uuid.UUID
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "Success",
+			webhook: valid,
+			prepare: func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock) {
+				storageMock.CreateMock.Expect(ctx, w).Return(id, nil)
+			},
+			want:    id,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Invalid URL",
+			webhook: func() *entity.Webhook {
+				w := valid()
+				w.URL = "ftp://example.com"
+				return w
+			},
+			prepare: func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Short Secret",
+			webhook: func() *entity.Webhook {
+				w := valid()
+				w.Secret = "short"
+				return w
+			},
+			prepare: func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Unknown Tag",
+			webhook: func() *entity.Webhook {
+				w := valid()
+				w.Tag = "unknown"
+				return w
+			},
+			prepare: func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Empty Meta Key",
+			webhook: func() *entity.Webhook {
+				w := valid()
+				w.MetaFilter = map[string]any{"": "core"}
+				return w
+			},
+			prepare: func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Storage Error",
+			webhook: valid,
+			prepare: func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock) {
+				storageMock.CreateMock.Expect(ctx, w).Return(uuid.Nil, errors.New("storage error"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			w := tt.webhook()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewWebhookStorageMock(ctrl)
+			tt.prepare(ctx, w, storageMock)
+
+			s := NewWebhookService(storageMock, newTestValidator())
+
+			got, err := s.Create(ctx, w)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_webhookService_Update(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	tests := []struct {
+		name    string
+		webhook *entity.Webhook
+		prepare func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock)
+		wantErr error
+	}{
+		{
+			name:    "Success",
+			webhook: &entity.Webhook{ID: id, URL: "http://receiver:8080/hooks", Secret: "0123456789abcdef"},
+			prepare: func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock) {
+				storageMock.UpdateMock.Expect(ctx, w).Return(nil)
+			},
+		},
+		{
+			name:    "Nil ID",
+			webhook: &entity.Webhook{URL: "http://receiver:8080/hooks", Secret: "0123456789abcdef"},
+			prepare: func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock) {},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "Not Found",
+			webhook: &entity.Webhook{ID: id, URL: "http://receiver:8080/hooks", Secret: "0123456789abcdef"},
+			prepare: func(ctx context.Context, w *entity.Webhook, storageMock *smocks.WebhookStorageMock) {
+				storageMock.UpdateMock.Expect(ctx, w).
+					Return(fmt.Errorf("update webhook: %w", repository.ErrWebhookNotFound))
+			},
+			wantErr: repository.ErrWebhookNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewWebhookStorageMock(ctrl)
+			tt.prepare(ctx, tt.webhook, storageMock)
+
+			s := NewWebhookService(storageMock, newTestValidator())
+
+			err := s.Update(ctx, tt.webhook)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_webhookService_Deliveries(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	deliveries := []*entity.WebhookDelivery{{ID: uuid.New(), WebhookID: id, Status: entity.DeliveryDead}}
+
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		q       entity.WebhookDeliveryQuery
+		prepare func(ctx context.Context, q entity.WebhookDeliveryQuery, storageMock *smocks.WebhookStorageMock)
+		want    []*entity.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name: "Success",
+			id:   id,
+			q:    entity.WebhookDeliveryQuery{Status: entity.DeliveryDead, Limit: 100},
+			prepare: func(ctx context.Context, q entity.WebhookDeliveryQuery, storageMock *smocks.WebhookStorageMock) {
+				storageMock.GetByIDMock.Expect(ctx, id).Return(&entity.Webhook{ID: id}, nil)
+				storageMock.ListDeliveriesMock.Expect(ctx, id, q).Return(deliveries, nil)
+			},
+			want: deliveries,
+		},
+		{
+			name:    "Unknown Status",
+			id:      id,
+			q:       entity.WebhookDeliveryQuery{Status: "failed", Limit: 100},
+			prepare: func(ctx context.Context, q entity.WebhookDeliveryQuery, storageMock *smocks.WebhookStorageMock) {},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "Limit Too Large",
+			id:      id,
+			q:       entity.WebhookDeliveryQuery{Limit: 1001},
+			prepare: func(ctx context.Context, q entity.WebhookDeliveryQuery, storageMock *smocks.WebhookStorageMock) {},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name: "Webhook Not Found",
+			id:   id,
+			q:    entity.WebhookDeliveryQuery{Limit: 100},
+			prepare: func(ctx context.Context, q entity.WebhookDeliveryQuery, storageMock *smocks.WebhookStorageMock) {
+				storageMock.GetByIDMock.Expect(ctx, id).
+					Return(nil, fmt.Errorf("get webhook by id: %w", repository.ErrWebhookNotFound))
+			},
+			wantErr: repository.ErrWebhookNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			ctrl := minimock.NewController(t)
+			storageMock := smocks.NewWebhookStorageMock(ctrl)
+			tt.prepare(ctx, tt.q, storageMock)
+
+			s := NewWebhookService(storageMock, newTestValidator())
+
+			got, err := s.Deliveries(ctx, tt.id, tt.q)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
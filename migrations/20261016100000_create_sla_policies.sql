@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE sla_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    tag tag_enum NOT NULL,
+    start_stage stage_enum NOT NULL,
+    end_stage stage_enum NOT NULL,
+    target_seconds BIGINT NOT NULL CHECK (target_seconds > 0),
+    CONSTRAINT unique_sla_policy UNIQUE (tag, start_stage, end_stage),
+    CONSTRAINT sla_policy_stages CHECK (start_stage <> end_stage)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sla_policies;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE sla_breaches (
+    external_id VARCHAR(255) NOT NULL,
+    tag tag_enum NOT NULL,
+    stage stage_enum NOT NULL,
+    stage_started_at TIMESTAMPTZ NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (external_id, tag, stage)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sla_breaches;
+-- +goose StatementEnd
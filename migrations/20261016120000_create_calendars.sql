@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE calendars (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    working_hours JSONB NOT NULL DEFAULT '{}',
+    CONSTRAINT unique_calendar_name UNIQUE (name)
+);
+
+CREATE TABLE calendar_holidays (
+    calendar_id UUID NOT NULL REFERENCES calendars (id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (calendar_id, date)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS calendar_holidays;
+DROP TABLE IF EXISTS calendars;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose NO TRANSACTION
+-- New enum values cannot be used in the transaction that adds them, so every statement runs on its own.
+
+-- +goose Up
+ALTER TYPE stage_enum ADD VALUE IF NOT EXISTS 'on_hold' BEFORE 'resolved';
+ALTER TYPE stage_enum ADD VALUE IF NOT EXISTS 'resumed' BEFORE 'resolved';
+
+-- An entity can be paused and resumed any number of times.
+ALTER TABLE timestamps DROP CONSTRAINT unique_timestamp;
+CREATE UNIQUE INDEX unique_timestamp ON timestamps (external_id, tag, stage)
+    WHERE stage NOT IN ('on_hold', 'resumed');
+
+-- +goose Down
+-- Postgres cannot drop enum values, so 'on_hold' and 'resumed' stay in stage_enum.
+DELETE FROM timestamps WHERE stage IN ('on_hold', 'resumed');
+DROP INDEX IF EXISTS unique_timestamp;
+ALTER TABLE timestamps ADD CONSTRAINT unique_timestamp UNIQUE (external_id, tag, stage);
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Lets SLA metrics skip rows before timestamp_from.
+CREATE INDEX IF NOT EXISTS idx_timestamps_timestamp ON timestamps (timestamp);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_timestamps_timestamp;
+-- +goose StatementEnd
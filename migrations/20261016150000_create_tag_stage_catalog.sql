@@ -0,0 +1,80 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tags (
+    name VARCHAR(64) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE stages (
+    name VARCHAR(64) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO tags (name) VALUES ('incident'), ('sla'), ('deployment'), ('maintenance'), ('alert');
+INSERT INTO stages (name) VALUES
+    ('created'), ('acknowledged'), ('in_progress'), ('on_hold'), ('resumed'), ('resolved'), ('closed');
+
+-- The partial index compares stage with enum literals and has to be rebuilt for the new column type.
+DROP INDEX unique_timestamp;
+
+ALTER TABLE timestamps
+    ALTER COLUMN tag TYPE VARCHAR(64) USING tag::text,
+    ALTER COLUMN stage TYPE VARCHAR(64) USING stage::text,
+    ADD CONSTRAINT timestamps_tag_fkey FOREIGN KEY (tag) REFERENCES tags (name),
+    ADD CONSTRAINT timestamps_stage_fkey FOREIGN KEY (stage) REFERENCES stages (name);
+
+CREATE UNIQUE INDEX unique_timestamp ON timestamps (external_id, tag, stage)
+    WHERE stage NOT IN ('on_hold', 'resumed');
+
+ALTER TABLE sla_policies
+    ALTER COLUMN tag TYPE VARCHAR(64) USING tag::text,
+    ALTER COLUMN start_stage TYPE VARCHAR(64) USING start_stage::text,
+    ALTER COLUMN end_stage TYPE VARCHAR(64) USING end_stage::text,
+    ADD CONSTRAINT sla_policies_tag_fkey FOREIGN KEY (tag) REFERENCES tags (name),
+    ADD CONSTRAINT sla_policies_start_stage_fkey FOREIGN KEY (start_stage) REFERENCES stages (name),
+    ADD CONSTRAINT sla_policies_end_stage_fkey FOREIGN KEY (end_stage) REFERENCES stages (name);
+
+ALTER TABLE sla_breaches
+    ALTER COLUMN tag TYPE VARCHAR(64) USING tag::text,
+    ALTER COLUMN stage TYPE VARCHAR(64) USING stage::text;
+
+DROP TYPE tag_enum;
+DROP TYPE stage_enum;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Fails if timestamps or policies use tags or stages that were added through the catalog.
+CREATE TYPE tag_enum AS ENUM ('incident', 'sla', 'deployment', 'maintenance', 'alert');
+CREATE TYPE stage_enum AS ENUM ('created', 'acknowledged', 'in_progress', 'on_hold', 'resumed', 'resolved', 'closed');
+
+DROP INDEX unique_timestamp;
+
+ALTER TABLE timestamps
+    DROP CONSTRAINT timestamps_tag_fkey,
+    DROP CONSTRAINT timestamps_stage_fkey,
+    ALTER COLUMN tag TYPE tag_enum USING tag::tag_enum,
+    ALTER COLUMN stage TYPE stage_enum USING stage::stage_enum;
+
+CREATE UNIQUE INDEX unique_timestamp ON timestamps (external_id, tag, stage)
+    WHERE stage NOT IN ('on_hold', 'resumed');
+
+ALTER TABLE sla_policies
+    DROP CONSTRAINT sla_policies_tag_fkey,
+    DROP CONSTRAINT sla_policies_start_stage_fkey,
+    DROP CONSTRAINT sla_policies_end_stage_fkey,
+    ALTER COLUMN tag TYPE tag_enum USING tag::tag_enum,
+    ALTER COLUMN start_stage TYPE stage_enum USING start_stage::stage_enum,
+    ALTER COLUMN end_stage TYPE stage_enum USING end_stage::stage_enum;
+
+ALTER TABLE sla_breaches
+    ALTER COLUMN tag TYPE tag_enum USING tag::tag_enum,
+    ALTER COLUMN stage TYPE stage_enum USING stage::stage_enum;
+
+DROP TABLE IF EXISTS stages;
+DROP TABLE IF EXISTS tags;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Incremented on every update; exposed as the ETag for optimistic concurrency.
+ALTER TABLE timestamps ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE timestamps DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
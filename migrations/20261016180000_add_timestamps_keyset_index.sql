@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Serves keyset pagination on (timestamp, id) as well as the timestamp range scans of SLA metrics.
+CREATE INDEX IF NOT EXISTS idx_timestamps_timestamp_id ON timestamps (timestamp, id);
+DROP INDEX IF EXISTS idx_timestamps_timestamp;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_timestamps_timestamp ON timestamps (timestamp);
+DROP INDEX IF EXISTS idx_timestamps_timestamp_id;
+-- +goose StatementEnd
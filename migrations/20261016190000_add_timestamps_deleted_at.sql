@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE timestamps ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- A deleted stage no longer blocks recording it again.
+DROP INDEX IF EXISTS unique_timestamp;
+CREATE UNIQUE INDEX unique_timestamp ON timestamps (external_id, tag, stage)
+    WHERE stage NOT IN ('on_hold', 'resumed') AND deleted_at IS NULL;
+
+-- Serves the retention purge and deleted=only listings.
+CREATE INDEX IF NOT EXISTS idx_timestamps_deleted_at ON timestamps (deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM timestamps WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_timestamps_deleted_at;
+DROP INDEX IF EXISTS unique_timestamp;
+CREATE UNIQUE INDEX unique_timestamp ON timestamps (external_id, tag, stage)
+    WHERE stage NOT IN ('on_hold', 'resumed');
+ALTER TABLE timestamps DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Entries have no foreign key to timestamps so that they outlive purged timestamps.
+CREATE TABLE IF NOT EXISTS timestamp_audit (
+    id BIGSERIAL PRIMARY KEY,
+    timestamp_id UUID NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    source_ip VARCHAR(64) NOT NULL DEFAULT '',
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_timestamp_audit_timestamp_id ON timestamp_audit (timestamp_id, id);
+CREATE INDEX IF NOT EXISTS idx_timestamp_audit_actor ON timestamp_audit (actor, created_at);
+CREATE INDEX IF NOT EXISTS idx_timestamp_audit_created_at ON timestamp_audit (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS timestamp_audit;
+-- +goose StatementEnd
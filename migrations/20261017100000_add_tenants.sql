@@ -0,0 +1,90 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Existing data belongs to the default tenant. Timestamps, audit entries and webhooks written from now on
+-- belong to the tenant of the transaction that writes them, see app.tenant_id below.
+ALTER TABLE timestamps ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE timestamps ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+ALTER TABLE timestamp_audit ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE timestamp_audit ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE webhooks ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+ALTER TABLE sla_breaches ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+-- Idempotency keys are stored as "<tenant>/<key>".
+ALTER TABLE idempotency_keys ALTER COLUMN key TYPE VARCHAR(320);
+
+-- The same external_id, tag and stage may be recorded by every tenant.
+DROP INDEX IF EXISTS unique_timestamp;
+CREATE UNIQUE INDEX unique_timestamp ON timestamps (tenant_id, external_id, tag, stage)
+    WHERE stage NOT IN ('on_hold', 'resumed') AND deleted_at IS NULL;
+
+ALTER TABLE sla_breaches DROP CONSTRAINT IF EXISTS sla_breaches_pkey;
+ALTER TABLE sla_breaches ADD PRIMARY KEY (tenant_id, external_id, tag, stage);
+
+CREATE INDEX IF NOT EXISTS idx_timestamp_audit_tenant_id ON timestamp_audit (tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_tenant_id ON webhooks (tenant_id);
+
+-- Requests run their statements as sla_tenant with app.tenant_id set for the transaction. Row-level
+-- security is not applied to table owners and superusers, so the role is what subjects them to the
+-- policies whichever user the service connects as; maintenance jobs such as the retention purge and the
+-- breach scanner keep running as that user across all tenants.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'sla_tenant') THEN
+        CREATE ROLE sla_tenant NOLOGIN;
+    END IF;
+END
+$$;
+GRANT sla_tenant TO CURRENT_USER;
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON timestamps, timestamp_audit, webhooks TO sla_tenant;
+GRANT USAGE ON SEQUENCE timestamp_audit_id_seq TO sla_tenant;
+
+ALTER TABLE timestamps ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON timestamps TO sla_tenant
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE timestamp_audit ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON timestamp_audit TO sla_tenant
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE webhooks ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON webhooks TO sla_tenant
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP POLICY IF EXISTS tenant_isolation ON webhooks;
+ALTER TABLE webhooks DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON timestamp_audit;
+ALTER TABLE timestamp_audit DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON timestamps;
+ALTER TABLE timestamps DISABLE ROW LEVEL SECURITY;
+
+REVOKE ALL ON timestamps, timestamp_audit, webhooks FROM sla_tenant;
+REVOKE ALL ON SEQUENCE timestamp_audit_id_seq FROM sla_tenant;
+
+DROP INDEX IF EXISTS idx_webhooks_tenant_id;
+DROP INDEX IF EXISTS idx_timestamp_audit_tenant_id;
+
+ALTER TABLE sla_breaches DROP CONSTRAINT IF EXISTS sla_breaches_pkey;
+ALTER TABLE sla_breaches ADD PRIMARY KEY (external_id, tag, stage);
+
+DROP INDEX IF EXISTS unique_timestamp;
+CREATE UNIQUE INDEX unique_timestamp ON timestamps (external_id, tag, stage)
+    WHERE stage NOT IN ('on_hold', 'resumed') AND deleted_at IS NULL;
+
+DELETE FROM idempotency_keys WHERE length(key) > 255;
+ALTER TABLE idempotency_keys ALTER COLUMN key TYPE VARCHAR(255);
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE sla_breaches DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE timestamp_audit DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE timestamps DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
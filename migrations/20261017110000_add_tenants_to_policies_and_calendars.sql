@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+-- SLA policies and calendars belong to a tenant like timestamps do; existing ones to the default tenant.
+-- The tag and stage catalog stays shared by every tenant and is changed with the catalog:write scope, which
+-- only the apikey command grants.
+ALTER TABLE sla_policies ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE sla_policies ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+ALTER TABLE calendars ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE calendars ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+ALTER TABLE calendar_holidays ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+UPDATE calendar_holidays h SET tenant_id = c.tenant_id FROM calendars c WHERE c.id = h.calendar_id;
+ALTER TABLE calendar_holidays ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+
+-- Policy and calendar names are unique per tenant.
+ALTER TABLE sla_policies DROP CONSTRAINT IF EXISTS unique_sla_policy;
+ALTER TABLE sla_policies ADD CONSTRAINT unique_sla_policy UNIQUE (tenant_id, tag, start_stage, end_stage);
+ALTER TABLE calendars DROP CONSTRAINT IF EXISTS unique_calendar_name;
+ALTER TABLE calendars ADD CONSTRAINT unique_calendar_name UNIQUE (tenant_id, name);
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON sla_policies, calendars, calendar_holidays TO sla_tenant;
+
+ALTER TABLE sla_policies ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON sla_policies TO sla_tenant
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE calendars ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON calendars TO sla_tenant
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE calendar_holidays ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON calendar_holidays TO sla_tenant
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP POLICY IF EXISTS tenant_isolation ON calendar_holidays;
+ALTER TABLE calendar_holidays DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON calendars;
+ALTER TABLE calendars DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON sla_policies;
+ALTER TABLE sla_policies DISABLE ROW LEVEL SECURITY;
+
+REVOKE ALL ON sla_policies, calendars, calendar_holidays FROM sla_tenant;
+
+-- Fails if two tenants have a policy for the same stages or a calendar of the same name.
+ALTER TABLE calendars DROP CONSTRAINT IF EXISTS unique_calendar_name;
+ALTER TABLE calendars ADD CONSTRAINT unique_calendar_name UNIQUE (name);
+ALTER TABLE sla_policies DROP CONSTRAINT IF EXISTS unique_sla_policy;
+ALTER TABLE sla_policies ADD CONSTRAINT unique_sla_policy UNIQUE (tag, start_stage, end_stage);
+
+ALTER TABLE calendar_holidays DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE calendars DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE sla_policies DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
@@ -0,0 +1,1045 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: timestamp/v1/timestamp.proto
+
+package timestampv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CountMode int32
+
+const (
+	CountMode_COUNT_MODE_UNSPECIFIED CountMode = 0
+	CountMode_COUNT_MODE_NONE        CountMode = 1
+	CountMode_COUNT_MODE_EXACT       CountMode = 2
+	CountMode_COUNT_MODE_ESTIMATED   CountMode = 3
+)
+
+// Enum value maps for CountMode.
+var (
+	CountMode_name = map[int32]string{
+		0: "COUNT_MODE_UNSPECIFIED",
+		1: "COUNT_MODE_NONE",
+		2: "COUNT_MODE_EXACT",
+		3: "COUNT_MODE_ESTIMATED",
+	}
+	CountMode_value = map[string]int32{
+		"COUNT_MODE_UNSPECIFIED": 0,
+		"COUNT_MODE_NONE":        1,
+		"COUNT_MODE_EXACT":       2,
+		"COUNT_MODE_ESTIMATED":   3,
+	}
+)
+
+func (x CountMode) Enum() *CountMode {
+	p := new(CountMode)
+	*p = x
+	return p
+}
+
+func (x CountMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CountMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_timestamp_v1_timestamp_proto_enumTypes[0].Descriptor()
+}
+
+func (CountMode) Type() protoreflect.EnumType {
+	return &file_timestamp_v1_timestamp_proto_enumTypes[0]
+}
+
+func (x CountMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CountMode.Descriptor instead.
+func (CountMode) EnumDescriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{0}
+}
+
+type DeletedMode int32
+
+const (
+	DeletedMode_DELETED_MODE_UNSPECIFIED DeletedMode = 0
+	DeletedMode_DELETED_MODE_EXCLUDE     DeletedMode = 1
+	DeletedMode_DELETED_MODE_INCLUDE     DeletedMode = 2
+	DeletedMode_DELETED_MODE_ONLY        DeletedMode = 3
+)
+
+// Enum value maps for DeletedMode.
+var (
+	DeletedMode_name = map[int32]string{
+		0: "DELETED_MODE_UNSPECIFIED",
+		1: "DELETED_MODE_EXCLUDE",
+		2: "DELETED_MODE_INCLUDE",
+		3: "DELETED_MODE_ONLY",
+	}
+	DeletedMode_value = map[string]int32{
+		"DELETED_MODE_UNSPECIFIED": 0,
+		"DELETED_MODE_EXCLUDE":     1,
+		"DELETED_MODE_INCLUDE":     2,
+		"DELETED_MODE_ONLY":        3,
+	}
+)
+
+func (x DeletedMode) Enum() *DeletedMode {
+	p := new(DeletedMode)
+	*p = x
+	return p
+}
+
+func (x DeletedMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeletedMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_timestamp_v1_timestamp_proto_enumTypes[1].Descriptor()
+}
+
+func (DeletedMode) Type() protoreflect.EnumType {
+	return &file_timestamp_v1_timestamp_proto_enumTypes[1]
+}
+
+func (x DeletedMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeletedMode.Descriptor instead.
+func (DeletedMode) EnumDescriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{1}
+}
+
+type WatchAction int32
+
+const (
+	WatchAction_WATCH_ACTION_UNSPECIFIED WatchAction = 0
+	WatchAction_WATCH_ACTION_CREATE      WatchAction = 1
+	WatchAction_WATCH_ACTION_DELETE      WatchAction = 2
+	// WATCH_ACTION_RESET tells the client that events were missed and its state has to be reloaded with List.
+	WatchAction_WATCH_ACTION_RESET WatchAction = 3
+)
+
+// Enum value maps for WatchAction.
+var (
+	WatchAction_name = map[int32]string{
+		0: "WATCH_ACTION_UNSPECIFIED",
+		1: "WATCH_ACTION_CREATE",
+		2: "WATCH_ACTION_DELETE",
+		3: "WATCH_ACTION_RESET",
+	}
+	WatchAction_value = map[string]int32{
+		"WATCH_ACTION_UNSPECIFIED": 0,
+		"WATCH_ACTION_CREATE":      1,
+		"WATCH_ACTION_DELETE":      2,
+		"WATCH_ACTION_RESET":       3,
+	}
+)
+
+func (x WatchAction) Enum() *WatchAction {
+	p := new(WatchAction)
+	*p = x
+	return p
+}
+
+func (x WatchAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_timestamp_v1_timestamp_proto_enumTypes[2].Descriptor()
+}
+
+func (WatchAction) Type() protoreflect.EnumType {
+	return &file_timestamp_v1_timestamp_proto_enumTypes[2]
+}
+
+func (x WatchAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchAction.Descriptor instead.
+func (WatchAction) EnumDescriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{2}
+}
+
+type Timestamp struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalId string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Timestamp  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Tag        string                 `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	Stage      string                 `protobuf:"bytes,5,opt,name=stage,proto3" json:"stage,omitempty"`
+	Meta       *structpb.Struct       `protobuf:"bytes,6,opt,name=meta,proto3" json:"meta,omitempty"`
+	Version    int32                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// deleted_at is set for soft-deleted timestamps.
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Timestamp) Reset() {
+	*x = Timestamp{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timestamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timestamp) ProtoMessage() {}
+
+func (x *Timestamp) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timestamp.ProtoReflect.Descriptor instead.
+func (*Timestamp) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Timestamp) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Timestamp) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *Timestamp) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Timestamp) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Timestamp) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *Timestamp) GetMeta() *structpb.Struct {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Timestamp) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Timestamp) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type CreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExternalId    string                 `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Tag           string                 `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	Stage         string                 `protobuf:"bytes,4,opt,name=stage,proto3" json:"stage,omitempty"`
+	Meta          *structpb.Struct       `protobuf:"bytes,5,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CreateRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetMeta() *structpb.Struct {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type CreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateResponse) Reset() {
+	*x = CreateResponse{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResponse) ProtoMessage() {}
+
+func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByIDRequest) Reset() {
+	*x = GetByIDRequest{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByIDRequest) ProtoMessage() {}
+
+func (x *GetByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetByIDRequest) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetByIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Filter selects timestamps with the semantics of the List query parameters.
+type Filter struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ExternalId string                 `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	// tags and stages match any of the given values.
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Stages        []string               `protobuf:"bytes,3,rep,name=stages,proto3" json:"stages,omitempty"`
+	TimestampFrom *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp_from,json=timestampFrom,proto3" json:"timestamp_from,omitempty"`
+	TimestampTo   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp_to,json=timestampTo,proto3" json:"timestamp_to,omitempty"`
+	// meta_filter matches timestamps whose meta contains it.
+	MetaFilter *structpb.Struct `protobuf:"bytes,6,opt,name=meta_filter,json=metaFilter,proto3" json:"meta_filter,omitempty"`
+	// predicates of the filter language, such as "stage!=closed" or "meta.severity>=2", combined with AND.
+	Predicates    []string `protobuf:"bytes,7,rep,name=predicates,proto3" json:"predicates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Filter) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *Filter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Filter) GetStages() []string {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *Filter) GetTimestampFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimestampFrom
+	}
+	return nil
+}
+
+func (x *Filter) GetTimestampTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TimestampTo
+	}
+	return nil
+}
+
+func (x *Filter) GetMetaFilter() *structpb.Struct {
+	if x != nil {
+		return x.MetaFilter
+	}
+	return nil
+}
+
+func (x *Filter) GetPredicates() []string {
+	if x != nil {
+		return x.Predicates
+	}
+	return nil
+}
+
+type ListRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *Filter                `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// limit defaults to 10.
+	Limit  int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// cursor is the next_cursor of the previous page; it excludes a non-zero offset.
+	Cursor string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// sort is a comma-separated list of fields, each optionally prefixed with "-" for descending order.
+	Sort string `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	// count defaults to COUNT_MODE_NONE.
+	Count CountMode `protobuf:"varint,6,opt,name=count,proto3,enum=timestamp.v1.CountMode" json:"count,omitempty"`
+	// deleted defaults to DELETED_MODE_EXCLUDE.
+	Deleted       DeletedMode `protobuf:"varint,7,opt,name=deleted,proto3,enum=timestamp.v1.DeletedMode" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListRequest) GetCount() CountMode {
+	if x != nil {
+		return x.Count
+	}
+	return CountMode_COUNT_MODE_UNSPECIFIED
+}
+
+func (x *ListRequest) GetDeleted() DeletedMode {
+	if x != nil {
+		return x.Deleted
+	}
+	return DeletedMode_DELETED_MODE_UNSPECIFIED
+}
+
+type ListResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*Timestamp           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// total is only set with COUNT_MODE_EXACT or COUNT_MODE_ESTIMATED.
+	Total         *int64 `protobuf:"varint,2,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	HasMore       bool   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextCursor    string `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListResponse) GetItems() []*Timestamp {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListResponse) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+func (x *ListResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *Filter                `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// last_event_id is the id of the last event received before reconnecting.
+	LastEventId   string `protobuf:"bytes,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetLastEventId() string {
+	if x != nil {
+		return x.LastEventId
+	}
+	return ""
+}
+
+type WatchEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is empty for WATCH_ACTION_RESET.
+	Id     string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Action WatchAction `protobuf:"varint,2,opt,name=action,proto3,enum=timestamp.v1.WatchAction" json:"action,omitempty"`
+	// timestamp is the created timestamp, or the deleted one with deleted_at set.
+	Timestamp     *Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_timestamp_v1_timestamp_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEvent.ProtoReflect.Descriptor instead.
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return file_timestamp_v1_timestamp_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetAction() WatchAction {
+	if x != nil {
+		return x.Action
+	}
+	return WatchAction_WATCH_ACTION_UNSPECIFIED
+}
+
+func (x *WatchEvent) GetTimestamp() *Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+var File_timestamp_v1_timestamp_proto protoreflect.FileDescriptor
+
+const file_timestamp_v1_timestamp_proto_rawDesc = "" +
+	"\n" +
+	"\x1ctimestamp/v1/timestamp.proto\x12\ftimestamp.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa0\x02\n" +
+	"\tTimestamp\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x10\n" +
+	"\x03tag\x18\x04 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05stage\x18\x05 \x01(\tR\x05stage\x12+\n" +
+	"\x04meta\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x04meta\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"deleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\xbf\x01\n" +
+	"\rCreateRequest\x12\x1f\n" +
+	"\vexternal_id\x18\x01 \x01(\tR\n" +
+	"externalId\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05stage\x18\x04 \x01(\tR\x05stage\x12+\n" +
+	"\x04meta\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x04meta\" \n" +
+	"\x0eCreateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\" \n" +
+	"\x0eGetByIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1f\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb1\x02\n" +
+	"\x06Filter\x12\x1f\n" +
+	"\vexternal_id\x18\x01 \x01(\tR\n" +
+	"externalId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06stages\x18\x03 \x03(\tR\x06stages\x12A\n" +
+	"\x0etimestamp_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rtimestampFrom\x12=\n" +
+	"\ftimestamp_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vtimestampTo\x128\n" +
+	"\vmeta_filter\x18\x06 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"metaFilter\x12\x1e\n" +
+	"\n" +
+	"predicates\x18\a \x03(\tR\n" +
+	"predicates\"\xf9\x01\n" +
+	"\vListRequest\x12,\n" +
+	"\x06filter\x18\x01 \x01(\v2\x14.timestamp.v1.FilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12-\n" +
+	"\x05count\x18\x06 \x01(\x0e2\x17.timestamp.v1.CountModeR\x05count\x123\n" +
+	"\adeleted\x18\a \x01(\x0e2\x19.timestamp.v1.DeletedModeR\adeleted\"\x9e\x01\n" +
+	"\fListResponse\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.timestamp.v1.TimestampR\x05items\x12\x19\n" +
+	"\x05total\x18\x02 \x01(\x03H\x00R\x05total\x88\x01\x01\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursorB\b\n" +
+	"\x06_total\"`\n" +
+	"\fWatchRequest\x12,\n" +
+	"\x06filter\x18\x01 \x01(\v2\x14.timestamp.v1.FilterR\x06filter\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\tR\vlastEventId\"\x86\x01\n" +
+	"\n" +
+	"WatchEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x121\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x19.timestamp.v1.WatchActionR\x06action\x125\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x17.timestamp.v1.TimestampR\ttimestamp*l\n" +
+	"\tCountMode\x12\x1a\n" +
+	"\x16COUNT_MODE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fCOUNT_MODE_NONE\x10\x01\x12\x14\n" +
+	"\x10COUNT_MODE_EXACT\x10\x02\x12\x18\n" +
+	"\x14COUNT_MODE_ESTIMATED\x10\x03*v\n" +
+	"\vDeletedMode\x12\x1c\n" +
+	"\x18DELETED_MODE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14DELETED_MODE_EXCLUDE\x10\x01\x12\x18\n" +
+	"\x14DELETED_MODE_INCLUDE\x10\x02\x12\x15\n" +
+	"\x11DELETED_MODE_ONLY\x10\x03*u\n" +
+	"\vWatchAction\x12\x1c\n" +
+	"\x18WATCH_ACTION_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13WATCH_ACTION_CREATE\x10\x01\x12\x17\n" +
+	"\x13WATCH_ACTION_DELETE\x10\x02\x12\x16\n" +
+	"\x12WATCH_ACTION_RESET\x10\x032\xd8\x02\n" +
+	"\x10TimestampService\x12C\n" +
+	"\x06Create\x12\x1b.timestamp.v1.CreateRequest\x1a\x1c.timestamp.v1.CreateResponse\x12@\n" +
+	"\aGetByID\x12\x1c.timestamp.v1.GetByIDRequest\x1a\x17.timestamp.v1.Timestamp\x12=\n" +
+	"\x04List\x12\x19.timestamp.v1.ListRequest\x1a\x1a.timestamp.v1.ListResponse\x12=\n" +
+	"\x06Delete\x12\x1b.timestamp.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\x05Watch\x12\x1a.timestamp.v1.WatchRequest\x1a\x18.timestamp.v1.WatchEvent0\x01BIZGgithub.com/sdvaanyaa/sla-timestamp-api/pkg/api/timestamp/v1;timestampv1b\x06proto3"
+
+var (
+	file_timestamp_v1_timestamp_proto_rawDescOnce sync.Once
+	file_timestamp_v1_timestamp_proto_rawDescData []byte
+)
+
+func file_timestamp_v1_timestamp_proto_rawDescGZIP() []byte {
+	file_timestamp_v1_timestamp_proto_rawDescOnce.Do(func() {
+		file_timestamp_v1_timestamp_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_timestamp_v1_timestamp_proto_rawDesc), len(file_timestamp_v1_timestamp_proto_rawDesc)))
+	})
+	return file_timestamp_v1_timestamp_proto_rawDescData
+}
+
+var file_timestamp_v1_timestamp_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_timestamp_v1_timestamp_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_timestamp_v1_timestamp_proto_goTypes = []any{
+	(CountMode)(0),                // 0: timestamp.v1.CountMode
+	(DeletedMode)(0),              // 1: timestamp.v1.DeletedMode
+	(WatchAction)(0),              // 2: timestamp.v1.WatchAction
+	(*Timestamp)(nil),             // 3: timestamp.v1.Timestamp
+	(*CreateRequest)(nil),         // 4: timestamp.v1.CreateRequest
+	(*CreateResponse)(nil),        // 5: timestamp.v1.CreateResponse
+	(*GetByIDRequest)(nil),        // 6: timestamp.v1.GetByIDRequest
+	(*DeleteRequest)(nil),         // 7: timestamp.v1.DeleteRequest
+	(*Filter)(nil),                // 8: timestamp.v1.Filter
+	(*ListRequest)(nil),           // 9: timestamp.v1.ListRequest
+	(*ListResponse)(nil),          // 10: timestamp.v1.ListResponse
+	(*WatchRequest)(nil),          // 11: timestamp.v1.WatchRequest
+	(*WatchEvent)(nil),            // 12: timestamp.v1.WatchEvent
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 14: google.protobuf.Struct
+	(*emptypb.Empty)(nil),         // 15: google.protobuf.Empty
+}
+var file_timestamp_v1_timestamp_proto_depIdxs = []int32{
+	13, // 0: timestamp.v1.Timestamp.timestamp:type_name -> google.protobuf.Timestamp
+	14, // 1: timestamp.v1.Timestamp.meta:type_name -> google.protobuf.Struct
+	13, // 2: timestamp.v1.Timestamp.deleted_at:type_name -> google.protobuf.Timestamp
+	13, // 3: timestamp.v1.CreateRequest.timestamp:type_name -> google.protobuf.Timestamp
+	14, // 4: timestamp.v1.CreateRequest.meta:type_name -> google.protobuf.Struct
+	13, // 5: timestamp.v1.Filter.timestamp_from:type_name -> google.protobuf.Timestamp
+	13, // 6: timestamp.v1.Filter.timestamp_to:type_name -> google.protobuf.Timestamp
+	14, // 7: timestamp.v1.Filter.meta_filter:type_name -> google.protobuf.Struct
+	8,  // 8: timestamp.v1.ListRequest.filter:type_name -> timestamp.v1.Filter
+	0,  // 9: timestamp.v1.ListRequest.count:type_name -> timestamp.v1.CountMode
+	1,  // 10: timestamp.v1.ListRequest.deleted:type_name -> timestamp.v1.DeletedMode
+	3,  // 11: timestamp.v1.ListResponse.items:type_name -> timestamp.v1.Timestamp
+	8,  // 12: timestamp.v1.WatchRequest.filter:type_name -> timestamp.v1.Filter
+	2,  // 13: timestamp.v1.WatchEvent.action:type_name -> timestamp.v1.WatchAction
+	3,  // 14: timestamp.v1.WatchEvent.timestamp:type_name -> timestamp.v1.Timestamp
+	4,  // 15: timestamp.v1.TimestampService.Create:input_type -> timestamp.v1.CreateRequest
+	6,  // 16: timestamp.v1.TimestampService.GetByID:input_type -> timestamp.v1.GetByIDRequest
+	9,  // 17: timestamp.v1.TimestampService.List:input_type -> timestamp.v1.ListRequest
+	7,  // 18: timestamp.v1.TimestampService.Delete:input_type -> timestamp.v1.DeleteRequest
+	11, // 19: timestamp.v1.TimestampService.Watch:input_type -> timestamp.v1.WatchRequest
+	5,  // 20: timestamp.v1.TimestampService.Create:output_type -> timestamp.v1.CreateResponse
+	3,  // 21: timestamp.v1.TimestampService.GetByID:output_type -> timestamp.v1.Timestamp
+	10, // 22: timestamp.v1.TimestampService.List:output_type -> timestamp.v1.ListResponse
+	15, // 23: timestamp.v1.TimestampService.Delete:output_type -> google.protobuf.Empty
+	12, // 24: timestamp.v1.TimestampService.Watch:output_type -> timestamp.v1.WatchEvent
+	20, // [20:25] is the sub-list for method output_type
+	15, // [15:20] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_timestamp_v1_timestamp_proto_init() }
+func file_timestamp_v1_timestamp_proto_init() {
+	if File_timestamp_v1_timestamp_proto != nil {
+		return
+	}
+	file_timestamp_v1_timestamp_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_timestamp_v1_timestamp_proto_rawDesc), len(file_timestamp_v1_timestamp_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_timestamp_v1_timestamp_proto_goTypes,
+		DependencyIndexes: file_timestamp_v1_timestamp_proto_depIdxs,
+		EnumInfos:         file_timestamp_v1_timestamp_proto_enumTypes,
+		MessageInfos:      file_timestamp_v1_timestamp_proto_msgTypes,
+	}.Build()
+	File_timestamp_v1_timestamp_proto = out.File
+	file_timestamp_v1_timestamp_proto_goTypes = nil
+	file_timestamp_v1_timestamp_proto_depIdxs = nil
+}
@@ -0,0 +1,294 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: timestamp/v1/timestamp.proto
+
+package timestampv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TimestampService_Create_FullMethodName  = "/timestamp.v1.TimestampService/Create"
+	TimestampService_GetByID_FullMethodName = "/timestamp.v1.TimestampService/GetByID"
+	TimestampService_List_FullMethodName    = "/timestamp.v1.TimestampService/List"
+	TimestampService_Delete_FullMethodName  = "/timestamp.v1.TimestampService/Delete"
+	TimestampService_Watch_FullMethodName   = "/timestamp.v1.TimestampService/Watch"
+)
+
+// TimestampServiceClient is the client API for TimestampService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TimestampService is the gRPC counterpart of the /timestamps REST API.
+type TimestampServiceClient interface {
+	// Create fails with ALREADY_EXISTS when a timestamp with the same external_id, tag and stage exists; the
+	// ID of that timestamp is returned in a google.rpc.ResourceInfo detail.
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	GetByID(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Timestamp, error)
+	// List returns a page of timestamps, newest first unless sort is set.
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Delete soft-deletes a timestamp.
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Watch streams the create and delete events of the timestamps matching the filter. With a last_event_id
+	// the buffered events after it are sent first, or a RESET event when they are no longer buffered.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error)
+}
+
+type timestampServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTimestampServiceClient(cc grpc.ClientConnInterface) TimestampServiceClient {
+	return &timestampServiceClient{cc}
+}
+
+func (c *timestampServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, TimestampService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timestampServiceClient) GetByID(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Timestamp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Timestamp)
+	err := c.cc.Invoke(ctx, TimestampService_GetByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timestampServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, TimestampService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timestampServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TimestampService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timestampServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TimestampService_ServiceDesc.Streams[0], TimestampService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TimestampService_WatchClient = grpc.ServerStreamingClient[WatchEvent]
+
+// TimestampServiceServer is the server API for TimestampService service.
+// All implementations must embed UnimplementedTimestampServiceServer
+// for forward compatibility.
+//
+// TimestampService is the gRPC counterpart of the /timestamps REST API.
+type TimestampServiceServer interface {
+	// Create fails with ALREADY_EXISTS when a timestamp with the same external_id, tag and stage exists; the
+	// ID of that timestamp is returned in a google.rpc.ResourceInfo detail.
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	GetByID(context.Context, *GetByIDRequest) (*Timestamp, error)
+	// List returns a page of timestamps, newest first unless sort is set.
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Delete soft-deletes a timestamp.
+	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	// Watch streams the create and delete events of the timestamps matching the filter. With a last_event_id
+	// the buffered events after it are sent first, or a RESET event when they are no longer buffered.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error
+	mustEmbedUnimplementedTimestampServiceServer()
+}
+
+// UnimplementedTimestampServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTimestampServiceServer struct{}
+
+func (UnimplementedTimestampServiceServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedTimestampServiceServer) GetByID(context.Context, *GetByIDRequest) (*Timestamp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByID not implemented")
+}
+func (UnimplementedTimestampServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedTimestampServiceServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedTimestampServiceServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedTimestampServiceServer) mustEmbedUnimplementedTimestampServiceServer() {}
+func (UnimplementedTimestampServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeTimestampServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TimestampServiceServer will
+// result in compilation errors.
+type UnsafeTimestampServiceServer interface {
+	mustEmbedUnimplementedTimestampServiceServer()
+}
+
+func RegisterTimestampServiceServer(s grpc.ServiceRegistrar, srv TimestampServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTimestampServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TimestampService_ServiceDesc, srv)
+}
+
+func _TimestampService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimestampServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimestampService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimestampServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimestampService_GetByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimestampServiceServer).GetByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimestampService_GetByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimestampServiceServer).GetByID(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimestampService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimestampServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimestampService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimestampServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimestampService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimestampServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimestampService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimestampServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimestampService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TimestampServiceServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TimestampService_WatchServer = grpc.ServerStreamingServer[WatchEvent]
+
+// TimestampService_ServiceDesc is the grpc.ServiceDesc for TimestampService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TimestampService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "timestamp.v1.TimestampService",
+	HandlerType: (*TimestampServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _TimestampService_Create_Handler,
+		},
+		{
+			MethodName: "GetByID",
+			Handler:    _TimestampService_GetByID_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _TimestampService_List_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _TimestampService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _TimestampService_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "timestamp/v1/timestamp.proto",
+}
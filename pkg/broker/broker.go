@@ -6,3 +6,9 @@ type Broker interface {
 	Publish(ctx context.Context, msg []byte) error
 	Close() error
 }
+
+// Subscriber delivers every published message to each subscription, unlike the shared work queue that
+// consumers of Publish compete on.
+type Subscriber interface {
+	Subscribe(ctx context.Context) (<-chan []byte, error)
+}
@@ -7,14 +7,17 @@ import (
 	"log/slog"
 )
 
+// Client publishes to a fanout exchange. The durable queue bound to it is shared by the consumers of the
+// work queue, while every Subscribe gets a queue of its own that receives all messages.
 type Client struct {
-	conn  *amqp091.Connection
-	ch    *amqp091.Channel
-	queue string
-	log   *slog.Logger
+	conn     *amqp091.Connection
+	ch       *amqp091.Channel
+	queue    string
+	exchange string
+	log      *slog.Logger
 }
 
-func New(url, queue string, log *slog.Logger) (*Client, error) {
+func New(url, queue, exchange string, log *slog.Logger) (*Client, error) {
 	if log == nil {
 		log = slog.Default()
 	}
@@ -29,18 +32,27 @@ func New(url, queue string, log *slog.Logger) (*Client, error) {
 		return nil, fmt.Errorf("channel: %w", err)
 	}
 
+	err = ch.ExchangeDeclare(exchange, amqp091.ExchangeFanout, true, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("exchange declare: %w", err)
+	}
+
 	_, err = ch.QueueDeclare(queue, true, false, false, false, nil)
 	if err != nil {
 		return nil, fmt.Errorf("queue declare: %w", err)
 	}
 
-	log.Info("rabbitmq connected", slog.String("queue", queue))
+	if err = ch.QueueBind(queue, "", exchange, false, nil); err != nil {
+		return nil, fmt.Errorf("queue bind: %w", err)
+	}
+
+	log.Info("rabbitmq connected", slog.String("queue", queue), slog.String("exchange", exchange))
 
-	return &Client{conn: conn, ch: ch, queue: queue, log: log}, nil
+	return &Client{conn: conn, ch: ch, queue: queue, exchange: exchange, log: log}, nil
 }
 
 func (c *Client) Publish(ctx context.Context, msg []byte) error {
-	err := c.ch.PublishWithContext(ctx, "", c.queue, false, false, amqp091.Publishing{
+	err := c.ch.PublishWithContext(ctx, c.exchange, "", false, false, amqp091.Publishing{
 		ContentType: "application/json",
 		Body:        msg,
 	})
@@ -52,6 +64,57 @@ func (c *Client) Publish(ctx context.Context, msg []byte) error {
 	return nil
 }
 
+// Subscribe consumes every message published from now on through an exclusive queue that RabbitMQ deletes
+// when the subscription ends. The returned channel is closed once ctx is done or the connection is lost.
+func (c *Client) Subscribe(ctx context.Context) (<-chan []byte, error) {
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("channel: %w", err)
+	}
+
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		_ = ch.Close()
+		return nil, fmt.Errorf("queue declare: %w", err)
+	}
+
+	if err = ch.QueueBind(q.Name, "", c.exchange, false, nil); err != nil {
+		_ = ch.Close()
+		return nil, fmt.Errorf("queue bind: %w", err)
+	}
+
+	deliveries, err := ch.Consume(q.Name, "", true, true, false, false, nil)
+	if err != nil {
+		_ = ch.Close()
+		return nil, fmt.Errorf("consume: %w", err)
+	}
+
+	msgs := make(chan []byte)
+	go func() {
+		defer close(msgs)
+		defer func() { _ = ch.Close() }()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case d, ok := <-deliveries:
+				if !ok {
+					c.log.Error("subscription closed by rabbitmq", slog.String("queue", q.Name))
+					return
+				}
+				select {
+				case msgs <- d.Body:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return msgs, nil
+}
+
 func (c *Client) Channel() (*amqp091.Channel, error) {
 	return c.conn.Channel()
 }
@@ -12,4 +12,6 @@ type Cache interface {
 	Get(ctx context.Context, key string, dest any) error
 	Set(ctx context.Context, key string, value any, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// DeletePrefix removes every key that starts with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
 }
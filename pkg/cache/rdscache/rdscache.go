@@ -8,9 +8,16 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/sdvaanyaa/sla-timestamp-api/pkg/cache"
 	"log/slog"
+	"strings"
 	"time"
 )
 
+// scanBatchSize is the number of keys DeletePrefix asks SCAN for, and unlinks, at a time.
+const scanBatchSize = 500
+
+// globEscaper escapes the characters that SCAN MATCH would treat as a pattern.
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
 type Client struct {
 	client *redis.Client
 	log    *slog.Logger
@@ -77,6 +84,43 @@ func (c *Client) Delete(ctx context.Context, key string) error {
 	return err
 }
 
+// DeletePrefix finds the keys with SCAN, which unlike KEYS does not block the server, and removes them with
+// UNLINK in batches.
+func (c *Client) DeletePrefix(ctx context.Context, prefix string) error {
+	start := time.Now()
+	err := c.deletePrefix(ctx, prefix)
+	duration := time.Since(start)
+
+	c.logOp("UNLINK", prefix+"*", duration, err)
+
+	return err
+}
+
+func (c *Client) deletePrefix(ctx context.Context, prefix string) error {
+	iter := c.client.Scan(ctx, 0, globEscaper.Replace(prefix)+"*", scanBatchSize).Iterator()
+
+	keys := make([]string, 0, scanBatchSize)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) < scanBatchSize {
+			continue
+		}
+		if err := c.client.Unlink(ctx, keys...).Err(); err != nil {
+			return err
+		}
+		keys = keys[:0]
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return c.client.Unlink(ctx, keys...).Err()
+}
+
 func (c *Client) logOp(op, key string, duration time.Duration, err error) {
 	attrs := []slog.Attr{
 		slog.String("operation", op),
@@ -0,0 +1,178 @@
+// Package ics reads events from iCalendar (RFC 5545) files, e.g. public holiday feeds.
+package ics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+var ErrInvalidCalendar = errors.New("invalid iCalendar data")
+
+// Event is a VEVENT. End is exclusive; for all-day events without DTEND it is the day after Start.
+type Event struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+	AllDay  bool
+}
+
+// Parse returns every VEVENT found in r.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  []Event
+		current *Event
+	)
+
+	for _, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+		case name == "END" && value == "VEVENT":
+			if current == nil || current.Start.IsZero() {
+				return nil, fmt.Errorf("%w: event without DTSTART", ErrInvalidCalendar)
+			}
+			if current.End.IsZero() {
+				current.End = current.Start.AddDate(0, 0, 1)
+			}
+			events = append(events, *current)
+			current = nil
+		case current != nil:
+			if err = current.set(name, params, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// Days returns the calendar days covered by the event in its own timezone.
+func (e Event) Days() []time.Time {
+	var days []time.Time
+
+	y, m, d := e.Start.Date()
+	for day := time.Date(y, m, d, 0, 0, 0, 0, e.Start.Location()); day.Before(e.End); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	return days
+}
+
+func (e *Event) set(name, params, value string) error {
+	switch name {
+	case "SUMMARY":
+		e.Summary = unescape(value)
+	case "DTSTART":
+		t, allDay, err := parseTime(params, value)
+		if err != nil {
+			return err
+		}
+		e.Start, e.AllDay = t, allDay
+	case "DTEND":
+		t, _, err := parseTime(params, value)
+		if err != nil {
+			return err
+		}
+		e.End = t
+	}
+
+	return nil
+}
+
+// unfold joins continuation lines, which start with a space or a tab.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCalendar, err)
+	}
+
+	return lines, nil
+}
+
+// splitProperty splits "DTSTART;VALUE=DATE:20251225" into name, parameters and value.
+func splitProperty(line string) (string, string, string, bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", "", "", false
+	}
+
+	name, params, _ := strings.Cut(head, ";")
+
+	return strings.ToUpper(name), params, value, true
+}
+
+func parseTime(params, value string) (time.Time, bool, error) {
+	loc := time.UTC
+	allDay := false
+
+	for _, param := range strings.Split(params, ";") {
+		key, val, _ := strings.Cut(param, "=")
+		switch strings.ToUpper(key) {
+		case "VALUE":
+			allDay = strings.EqualFold(val, "DATE")
+		case "TZID":
+			tz, err := time.LoadLocation(val)
+			if err != nil {
+				return time.Time{}, false, fmt.Errorf("%w: unknown TZID %q", ErrInvalidCalendar, val)
+			}
+			loc = tz
+		}
+	}
+
+	if len(value) == len(dateLayout) {
+		allDay = true
+	}
+
+	if allDay {
+		t, err := time.ParseInLocation(dateLayout, value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: invalid date %q", ErrInvalidCalendar, value)
+		}
+		return t, true, nil
+	}
+
+	if utc, found := strings.CutSuffix(value, "Z"); found {
+		value, loc = utc, time.UTC
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: invalid date-time %q", ErrInvalidCalendar, value)
+	}
+
+	return t, false, nil
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(value)
+}
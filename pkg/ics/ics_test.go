@@ -0,0 +1,69 @@
+package ics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20251225",
+		"DTEND;VALUE=DATE:20251227",
+		"SUMMARY:Christmas\\, Boxing",
+		"  Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20250101",
+		"SUMMARY:New Year",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20250501T000000Z",
+		"DTEND:20250502T000000Z",
+		"SUMMARY:Labour Day",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := Parse(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, "Christmas, Boxing Day", events[0].Summary)
+	assert.True(t, events[0].AllDay)
+	assert.Len(t, events[0].Days(), 2)
+
+	assert.Equal(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), events[1].End)
+	assert.Len(t, events[1].Days(), 1)
+
+	assert.False(t, events[2].AllDay)
+	assert.Equal(t, []time.Time{time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)}, events[2].Days())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "Missing DTSTART", data: "BEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT"},
+		{name: "Bad Date", data: "BEGIN:VEVENT\nDTSTART;VALUE=DATE:2025-12-25\nEND:VEVENT"},
+		{name: "Unknown TZID", data: "BEGIN:VEVENT\nDTSTART;TZID=Mars/Base:20251225T090000\nEND:VEVENT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(strings.NewReader(tt.data))
+			assert.ErrorIs(t, err, ErrInvalidCalendar)
+		})
+	}
+}
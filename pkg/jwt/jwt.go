@@ -0,0 +1,216 @@
+// Package jwt verifies RS256 and ES256 signed JSON Web Tokens (RFC 7519) against a JSON Web Key Set
+// (RFC 7517) read from a file or URL.
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+)
+
+var (
+	ErrMalformed         = errors.New("malformed token")
+	ErrUnsupportedAlg    = errors.New("unsupported signing algorithm")
+	ErrUnknownKey        = errors.New("unknown signing key")
+	ErrInvalidSignature  = errors.New("invalid token signature")
+	ErrExpired           = errors.New("token is expired")
+	ErrNotYetValid       = errors.New("token is not valid yet")
+	ErrInvalidIssuer     = errors.New("invalid token issuer")
+	ErrInvalidAudience   = errors.New("invalid token audience")
+	ErrMissingExpiration = errors.New("token has no expiration")
+)
+
+// Claims is the payload of a token.
+type Claims map[string]any
+
+// String returns the claim name if it is a string.
+func (c Claims) String(name string) string {
+	s, _ := c[name].(string)
+	return s
+}
+
+// Strings returns the claim name as a list: a string is split on spaces, as the OAuth "scope" claim is,
+// and an array yields its string elements.
+func (c Claims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func (c Claims) time(name string) (time.Time, bool) {
+	n, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(n), 0), true
+}
+
+// KeySource returns the public key that signed a token with the given key ID.
+type KeySource interface {
+	Key(kid string) (crypto.PublicKey, bool)
+}
+
+// Verifier checks the signature, issuer, audience and validity period of tokens.
+type Verifier struct {
+	Keys     KeySource
+	Issuer   string
+	Audience string
+	// Leeway is the clock skew tolerated when checking exp and nbf.
+	Leeway time.Duration
+	// Now returns the current time; time.Now if nil.
+	Now func() time.Time
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify returns the claims of token once its signature and registered claims check out.
+func (v *Verifier) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	key, ok := v.Keys.Key(h.Kid)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, h.Kid)
+	}
+
+	if err = verifySignature(h.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	if err = v.validate(claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) validate(claims Claims) error {
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+
+	exp, ok := claims.time("exp")
+	if !ok {
+		return ErrMissingExpiration
+	}
+	if !now.Before(exp.Add(v.Leeway)) {
+		return ErrExpired
+	}
+
+	if nbf, ok := claims.time("nbf"); ok && now.Add(v.Leeway).Before(nbf) {
+		return ErrNotYetValid
+	}
+
+	if v.Issuer != "" && claims.String("iss") != v.Issuer {
+		return ErrInvalidIssuer
+	}
+
+	// aud is either a single string or an array of them, which Strings reads alike.
+	if v.Audience != "" && !slices.Contains(claims.Strings("aud"), v.Audience) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, sig []byte) error {
+	digest := sha256.Sum256([]byte(signed))
+
+	switch alg {
+	case AlgRS256:
+		return verifyRS256(key, digest[:], sig)
+	case AlgES256:
+		return verifyES256(key, digest[:], sig)
+	default:
+		return fmt.Errorf("%w %q", ErrUnsupportedAlg, alg)
+	}
+}
+
+func verifyRS256(key crypto.PublicKey, digest, sig []byte) error {
+	k, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: RS256 with a non-RSA key", ErrUnsupportedAlg)
+	}
+
+	if rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, sig) != nil {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func verifyES256(key crypto.PublicKey, digest, sig []byte) error {
+	k, ok := key.(*ecdsa.PublicKey)
+	if !ok || k.Curve.Params().Name != "P-256" {
+		return fmt.Errorf("%w: ES256 with a non-P-256 key", ErrUnsupportedAlg)
+	}
+
+	// The signature is r and s, 32 bytes each, not the ASN.1 form ecdsa.VerifyASN1 expects.
+	if len(sig) != 64 {
+		return ErrInvalidSignature
+	}
+
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	if !ecdsa.Verify(k, digest, r, s) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformed
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return ErrMalformed
+	}
+	return nil
+}
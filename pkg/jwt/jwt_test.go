@@ -0,0 +1,271 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testKeys struct {
+	rsa  *rsa.PrivateKey
+	ec   *ecdsa.PrivateKey
+	jwks []byte
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	enc := base64.RawURLEncoding
+	jwks, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		{
+			"kty": "RSA",
+			"kid": "rsa-1",
+			"use": "sig",
+			"n":   enc.EncodeToString(rsaKey.N.Bytes()),
+			"e":   enc.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+		},
+		{
+			"kty": "EC",
+			"kid": "ec-1",
+			"crv": "P-256",
+			"x":   enc.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+			"y":   enc.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+		},
+		{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"},
+	}})
+	require.NoError(t, err)
+
+	return testKeys{rsa: rsaKey, ec: ecKey, jwks: jwks}
+}
+
+func sign(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+
+	h, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	c, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, signErr := ecdsa.Sign(rand.Reader, k, digest[:])
+		require.NoError(t, signErr)
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"iss":   "https://id.example.com",
+			"aud":   []string{"other", "sla-api"},
+			"sub":   "svc-incidents",
+			"exp":   now.Add(time.Hour).Unix(),
+			"scope": "timestamps:read timestamps:write",
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "RS256", token: sign(t, AlgRS256, "rsa-1", keys.rsa, claims(nil))},
+		{name: "ES256", token: sign(t, AlgES256, "ec-1", keys.ec, claims(nil))},
+		{name: "Audience String", token: sign(t, AlgRS256, "rsa-1", keys.rsa, claims(map[string]any{"aud": "sla-api"}))},
+		{
+			name:  "Expired Within Leeway",
+			token: sign(t, AlgRS256, "rsa-1", keys.rsa, claims(map[string]any{"exp": now.Add(-time.Second).Unix()})),
+		},
+		{
+			name:    "Expired",
+			token:   sign(t, AlgRS256, "rsa-1", keys.rsa, claims(map[string]any{"exp": now.Add(-time.Hour).Unix()})),
+			wantErr: ErrExpired,
+		},
+		{
+			name:    "No Expiration",
+			token:   sign(t, AlgRS256, "rsa-1", keys.rsa, claims(map[string]any{"exp": nil})),
+			wantErr: ErrMissingExpiration,
+		},
+		{
+			name:    "Not Yet Valid",
+			token:   sign(t, AlgRS256, "rsa-1", keys.rsa, claims(map[string]any{"nbf": now.Add(time.Hour).Unix()})),
+			wantErr: ErrNotYetValid,
+		},
+		{
+			name:    "Wrong Issuer",
+			token:   sign(t, AlgRS256, "rsa-1", keys.rsa, claims(map[string]any{"iss": "https://evil.example.com"})),
+			wantErr: ErrInvalidIssuer,
+		},
+		{
+			name:    "Wrong Audience",
+			token:   sign(t, AlgRS256, "rsa-1", keys.rsa, claims(map[string]any{"aud": "other"})),
+			wantErr: ErrInvalidAudience,
+		},
+		{
+			name:    "Unknown Key",
+			token:   sign(t, AlgRS256, "rsa-2", keys.rsa, claims(nil)),
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "Algorithm Key Mismatch",
+			token:   sign(t, AlgRS256, "ec-1", keys.rsa, claims(nil)),
+			wantErr: ErrUnsupportedAlg,
+		},
+		{
+			name:    "Unsupported Algorithm",
+			token:   sign(t, "HS256", "rsa-1", keys.rsa, claims(nil)),
+			wantErr: ErrUnsupportedAlg,
+		},
+		{
+			name:    "None Algorithm",
+			token:   strings.Join(strings.Split(sign(t, "none", "rsa-1", keys.rsa, claims(nil)), ".")[:2], ".") + ".",
+			wantErr: ErrUnsupportedAlg,
+		},
+		{
+			name:    "Malformed",
+			token:   "not-a-token",
+			wantErr: ErrMalformed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			set, err := ParseJWKS(keys.jwks)
+			require.NoError(t, err)
+
+			v := &Verifier{
+				Keys:     staticKeys(set),
+				Issuer:   "https://id.example.com",
+				Audience: "sla-api",
+				Leeway:   time.Minute,
+				Now:      func() time.Time { return now },
+			}
+
+			got, err := v.Verify(tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, "svc-incidents", got.String("sub"))
+			assert.Equal(t, []string{"timestamps:read", "timestamps:write"}, got.Strings("scope"))
+		})
+	}
+}
+
+func TestVerifier_Verify_TamperedSignature(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+	set, err := ParseJWKS(keys.jwks)
+	require.NoError(t, err)
+
+	token := sign(t, AlgES256, "ec-1", keys.ec, map[string]any{"sub": "a", "exp": time.Now().Add(time.Hour).Unix()})
+	forged := sign(t, AlgES256, "ec-1", keys.ec, map[string]any{"sub": "b", "exp": time.Now().Add(time.Hour).Unix()})
+	parts, forgedParts := strings.Split(token, "."), strings.Split(forged, ".")
+
+	_, err = (&Verifier{Keys: staticKeys(set)}).Verify(parts[0] + "." + forgedParts[1] + "." + parts[2])
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestKeySet_Refresh(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(file, keys.jwks, 0o600))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jwks.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(keys.jwks)
+	}))
+	t.Cleanup(srv.Close)
+
+	for _, source := range []string{file, srv.URL + "/jwks.json"} {
+		set := NewKeySet(source, srv.Client())
+		_, ok := set.Key("rsa-1")
+		assert.False(t, ok)
+
+		require.NoError(t, set.Refresh(t.Context()))
+
+		key, ok := set.Key("rsa-1")
+		require.True(t, ok)
+		assert.True(t, keys.rsa.PublicKey.Equal(key))
+
+		key, ok = set.Key("ec-1")
+		require.True(t, ok)
+		assert.True(t, keys.ec.PublicKey.Equal(key))
+
+		_, ok = set.Key("hmac")
+		assert.False(t, ok)
+	}
+
+	set := NewKeySet(srv.URL+"/missing.json", srv.Client())
+	assert.Error(t, set.Refresh(t.Context()))
+}
+
+func TestParseJWKS_InvalidPoint(t *testing.T) {
+	t.Parallel()
+
+	zero := base64.RawURLEncoding.EncodeToString(make([]byte, 32))
+	data := `{"keys":[{"kty":"EC","kid":"ec-1","crv":"P-256","x":"` + zero + `","y":"` + zero + `"}]}`
+
+	_, err := ParseJWKS([]byte(data))
+	assert.ErrorIs(t, err, errInvalidPoint)
+}
+
+type staticKeys map[string]crypto.PublicKey
+
+func (s staticKeys) Key(kid string) (crypto.PublicKey, bool) {
+	key, ok := s[kid]
+	return key, ok
+}
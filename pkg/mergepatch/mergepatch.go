@@ -0,0 +1,32 @@
+// Package mergepatch applies JSON Merge Patches (RFC 7386) to values decoded by encoding/json.
+package mergepatch
+
+// Apply merges patch into target. Objects are merged key by key, a null member removes the key and any
+// other patch value replaces the target. Target objects are copied, never modified in place.
+func Apply(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+
+	result := make(map[string]any, len(targetObj)+len(patchObj))
+	for k, v := range targetObj {
+		result[k] = v
+	}
+
+	for k, v := range patchObj {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+
+		result[k] = Apply(result[k], v)
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// The cases are the examples from RFC 7386, appendix A.
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target+" "+tt.patch, func(t *testing.T) {
+			t.Parallel()
+
+			var target, patch, want any
+			require.NoError(t, json.Unmarshal([]byte(tt.target), &target))
+			require.NoError(t, json.Unmarshal([]byte(tt.patch), &patch))
+			require.NoError(t, json.Unmarshal([]byte(tt.want), &want))
+
+			assert.Equal(t, want, Apply(target, patch))
+		})
+	}
+}
+
+func TestApplyDoesNotModifyTarget(t *testing.T) {
+	t.Parallel()
+
+	target := map[string]any{"a": "b"}
+	Apply(target, map[string]any{"a": nil, "c": "d"})
+
+	assert.Equal(t, map[string]any{"a": "b"}, target)
+}
@@ -71,6 +71,11 @@ func (c *Client) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	return c.conn.QueryRow(ctx, sql, args...)
 }
 
+// SendBatch sends all queued queries in one round trip. The results must be closed by the caller.
+func (c *Client) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return c.conn.SendBatch(ctx, b)
+}
+
 func (c *Client) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
 	start := time.Now()
 	tag, err := c.conn.Exec(ctx, sql, arguments...)
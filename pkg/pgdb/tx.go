@@ -0,0 +1,72 @@
+package pgdb
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
+)
+
+// Querier is implemented by both Client and Tx, so that the same statements can run on their own or as
+// part of a transaction.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+// Tx is a transaction started by Client.WithTx. Its statements are logged like those of the Client.
+type Tx struct {
+	tx     pgx.Tx
+	client *Client
+}
+
+// WithTx runs fn in a transaction that is committed if fn returns nil and rolled back otherwise. The error
+// of fn is returned as is.
+func (c *Client) WithTx(ctx context.Context, fn func(tx *Tx) error) error {
+	tx, err := c.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	// Rolling back a committed transaction is a no-op; the rollback must run even if ctx is cancelled.
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	if err = fn(&Tx{tx: tx, client: c}); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (t *Tx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	start := time.Now()
+	rows, err := t.tx.Query(ctx, sql, args...)
+
+	t.client.logQuery(sql, time.Since(start), err)
+
+	return rows, err
+}
+
+func (t *Tx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return t.tx.QueryRow(ctx, sql, args...)
+}
+
+// SendBatch sends all queued queries in one round trip. The results must be closed by the caller.
+func (t *Tx) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return t.tx.SendBatch(ctx, b)
+}
+
+func (t *Tx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	start := time.Now()
+	tag, err := t.tx.Exec(ctx, sql, arguments...)
+
+	t.client.logQuery(sql, time.Since(start), err)
+
+	return tag, err
+}
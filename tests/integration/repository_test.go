@@ -3,6 +3,7 @@ package integration
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/stretchr/testify/suite"
 	"io"
 	"testing"
@@ -33,28 +34,140 @@ type TimestampRepoSuite struct {
 
 func (s *TimestampRepoSuite) SetupSuite() {
 	s.ctx, s.pgContainer, s.client = setupPostgresContainer(s.T())
+	s.ctx = entity.WithTenant(s.ctx, "acme")
 	s.repo = postgres.New(s.client)
 
 	schema := `
 		CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
-		CREATE TYPE tag_enum AS ENUM ('incident', 'sla', 'deployment', 'maintenance', 'alert');
-		CREATE TYPE stage_enum AS ENUM ('created', 'acknowledged', 'in_progress', 'resolved', 'closed');
+		CREATE TABLE tags (
+			name VARCHAR(64) PRIMARY KEY,
+			description TEXT NOT NULL DEFAULT '',
+			deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		);
+		CREATE TABLE stages (LIKE tags INCLUDING ALL);
+		INSERT INTO tags (name) VALUES ('incident'), ('sla'), ('deployment'), ('maintenance'), ('alert');
+		INSERT INTO stages (name) VALUES
+			('created'), ('acknowledged'), ('in_progress'), ('on_hold'), ('resumed'), ('resolved'), ('closed');
 		CREATE TABLE IF NOT EXISTS timestamps (
 			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			tenant_id VARCHAR(64) NOT NULL DEFAULT current_setting('app.tenant_id'),
 			external_id VARCHAR(255) NOT NULL,
 			timestamp TIMESTAMPTZ NOT NULL,
-			tag tag_enum NOT NULL,
-			stage stage_enum NOT NULL,
+			tag VARCHAR(64) NOT NULL REFERENCES tags (name),
+			stage VARCHAR(64) NOT NULL REFERENCES stages (name),
 			meta JSONB,
-			CONSTRAINT unique_timestamp UNIQUE (external_id, tag, stage)
+			version INTEGER NOT NULL DEFAULT 1,
+			deleted_at TIMESTAMPTZ
+		);
+		CREATE UNIQUE INDEX unique_timestamp ON timestamps (tenant_id, external_id, tag, stage)
+			WHERE stage NOT IN ('on_hold', 'resumed') AND deleted_at IS NULL;
+		CREATE TABLE timestamp_audit (
+			id BIGSERIAL PRIMARY KEY,
+			tenant_id VARCHAR(64) NOT NULL DEFAULT current_setting('app.tenant_id'),
+			timestamp_id UUID NOT NULL,
+			operation VARCHAR(16) NOT NULL,
+			actor VARCHAR(255) NOT NULL,
+			source_ip VARCHAR(64) NOT NULL DEFAULT '',
+			request_id VARCHAR(128) NOT NULL DEFAULT '',
+			before JSONB,
+			after JSONB,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		);
+		CREATE TABLE webhooks (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			tenant_id VARCHAR(64) NOT NULL DEFAULT current_setting('app.tenant_id'),
+			url TEXT NOT NULL,
+			secret VARCHAR(255) NOT NULL,
+			tag VARCHAR(64) REFERENCES tags (name),
+			stage VARCHAR(64) REFERENCES stages (name),
+			meta_filter JSONB,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		);
+		CREATE TABLE webhook_deliveries (
+			id UUID PRIMARY KEY,
+			webhook_id UUID NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+			action VARCHAR(16) NOT NULL,
+			payload JSONB NOT NULL,
+			status VARCHAR(16) NOT NULL DEFAULT 'pending',
+			attempts INT NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+			last_error TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		);
+		CREATE TABLE webhook_attempts (
+			id BIGSERIAL PRIMARY KEY,
+			delivery_id UUID NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+			webhook_id UUID NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+			attempt INT NOT NULL,
+			status_code INT NOT NULL DEFAULT 0,
+			error TEXT NOT NULL DEFAULT '',
+			duration_ms BIGINT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		);
+		CREATE TABLE sla_policies (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			tenant_id VARCHAR(64) NOT NULL DEFAULT current_setting('app.tenant_id'),
+			name VARCHAR(255) NOT NULL,
+			tag VARCHAR(64) NOT NULL REFERENCES tags (name),
+			start_stage VARCHAR(64) NOT NULL REFERENCES stages (name),
+			end_stage VARCHAR(64) NOT NULL REFERENCES stages (name),
+			target_seconds BIGINT NOT NULL CHECK (target_seconds > 0),
+			CONSTRAINT unique_sla_policy UNIQUE (tenant_id, tag, start_stage, end_stage)
+		);
+		CREATE TABLE calendars (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			tenant_id VARCHAR(64) NOT NULL DEFAULT current_setting('app.tenant_id'),
+			name VARCHAR(255) NOT NULL,
+			timezone VARCHAR(64) NOT NULL,
+			working_hours JSONB NOT NULL DEFAULT '{}',
+			CONSTRAINT unique_calendar_name UNIQUE (tenant_id, name)
+		);
+		CREATE TABLE calendar_holidays (
+			calendar_id UUID NOT NULL REFERENCES calendars (id) ON DELETE CASCADE,
+			tenant_id VARCHAR(64) NOT NULL DEFAULT current_setting('app.tenant_id'),
+			date DATE NOT NULL,
+			name VARCHAR(255) NOT NULL DEFAULT '',
+			PRIMARY KEY (calendar_id, date)
 		);
+		CREATE ROLE sla_tenant NOLOGIN;
+		GRANT sla_tenant TO CURRENT_USER;
+		GRANT SELECT, INSERT, UPDATE, DELETE ON timestamps, timestamp_audit, webhooks TO sla_tenant;
+		GRANT SELECT, INSERT, UPDATE, DELETE ON sla_policies, calendars, calendar_holidays TO sla_tenant;
+		GRANT USAGE ON SEQUENCE timestamp_audit_id_seq TO sla_tenant;
+		ALTER TABLE timestamps ENABLE ROW LEVEL SECURITY;
+		CREATE POLICY tenant_isolation ON timestamps TO sla_tenant
+			USING (tenant_id = current_setting('app.tenant_id', true))
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+		ALTER TABLE timestamp_audit ENABLE ROW LEVEL SECURITY;
+		CREATE POLICY tenant_isolation ON timestamp_audit TO sla_tenant
+			USING (tenant_id = current_setting('app.tenant_id', true))
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+		ALTER TABLE webhooks ENABLE ROW LEVEL SECURITY;
+		CREATE POLICY tenant_isolation ON webhooks TO sla_tenant
+			USING (tenant_id = current_setting('app.tenant_id', true))
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+		ALTER TABLE sla_policies ENABLE ROW LEVEL SECURITY;
+		CREATE POLICY tenant_isolation ON sla_policies TO sla_tenant
+			USING (tenant_id = current_setting('app.tenant_id', true))
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+		ALTER TABLE calendars ENABLE ROW LEVEL SECURITY;
+		CREATE POLICY tenant_isolation ON calendars TO sla_tenant
+			USING (tenant_id = current_setting('app.tenant_id', true))
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+		ALTER TABLE calendar_holidays ENABLE ROW LEVEL SECURITY;
+		CREATE POLICY tenant_isolation ON calendar_holidays TO sla_tenant
+			USING (tenant_id = current_setting('app.tenant_id', true))
+			WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
 	`
 	_, err := s.client.Exec(s.ctx, schema)
 	require.NoError(s.T(), err)
 }
 
 func (s *TimestampRepoSuite) SetupTest() {
-	_, err := s.client.Exec(s.ctx, "TRUNCATE TABLE timestamps RESTART IDENTITY CASCADE")
+	_, err := s.client.Exec(s.ctx, `TRUNCATE TABLE timestamps, timestamp_audit, webhooks, sla_policies, calendars
+		RESTART IDENTITY CASCADE`)
 	require.NoError(s.T(), err)
 }
 
@@ -148,6 +261,83 @@ func (s *TimestampRepoSuite) TestCreate() {
 	}
 }
 
+func (s *TimestampRepoSuite) TestCreateConflict() {
+	ts := &entity.Timestamp{
+		ExternalID: "test-conflict",
+		Timestamp:  time.Now().UTC(),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+	}
+	id, err := s.repo.Create(s.ctx, ts)
+	require.NoError(s.T(), err)
+
+	dup := *ts
+	dup.Timestamp = dup.Timestamp.Add(time.Hour)
+	existing, err := s.repo.Create(s.ctx, &dup)
+	assert.ErrorIs(s.T(), err, repository.ErrAlreadyExists)
+	assert.Equal(s.T(), id, existing)
+}
+
+func (s *TimestampRepoSuite) TestUpsert() {
+	ts := &entity.Timestamp{
+		ExternalID: "test-upsert",
+		Timestamp:  time.Now().UTC(),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+	}
+	created, err := s.repo.Upsert(s.ctx, ts)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), created)
+	id := ts.ID
+
+	again := &entity.Timestamp{
+		ExternalID: "test-upsert",
+		Timestamp:  ts.Timestamp.Add(time.Hour),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+		Meta:       map[string]any{"source": "backfill"},
+	}
+	created, err = s.repo.Upsert(s.ctx, again)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), created)
+	assert.Equal(s.T(), id, again.ID)
+	assert.Equal(s.T(), 2, again.Version)
+
+	got, err := s.repo.GetByID(s.ctx, id)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "backfill", got.Meta["source"])
+}
+
+func (s *TimestampRepoSuite) TestCreateBatch() {
+	now := time.Now().UTC()
+	existing := &entity.Timestamp{
+		ExternalID: "test-batch",
+		Timestamp:  now,
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+	}
+	_, err := s.repo.Create(s.ctx, existing)
+	require.NoError(s.T(), err)
+
+	tss := []*entity.Timestamp{
+		{ExternalID: "test-batch", Timestamp: now, Tag: entity.TagIncident, Stage: entity.StageCreated},
+		{ExternalID: "test-batch", Timestamp: now, Tag: entity.TagIncident, Stage: entity.StageAcknowledged},
+		{ExternalID: "test-batch", Timestamp: now, Tag: entity.TagIncident, Stage: entity.StageAcknowledged},
+		{ExternalID: "test-batch", Timestamp: now, Tag: entity.TagIncident, Stage: entity.StageOnHold},
+	}
+	require.NoError(s.T(), s.repo.CreateBatch(s.ctx, tss))
+
+	assert.Equal(s.T(), uuid.Nil, tss[0].ID)
+	assert.NotEqual(s.T(), uuid.Nil, tss[1].ID)
+	assert.Equal(s.T(), uuid.Nil, tss[2].ID)
+	assert.NotEqual(s.T(), uuid.Nil, tss[3].ID)
+	assert.Equal(s.T(), 1, tss[1].Version)
+
+	list, err := s.repo.ListByExternalID(s.ctx, "test-batch")
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), list, 3)
+}
+
 func (s *TimestampRepoSuite) TestGetByID() {
 	ts := &entity.Timestamp{
 		ExternalID: "test-external",
@@ -211,7 +401,7 @@ func (s *TimestampRepoSuite) TestList() {
 	}
 	_, err := s.repo.Create(s.ctx, ts1)
 	require.NoError(s.T(), err)
-	_, err = s.repo.Create(s.ctx, ts2)
+	ts2.ID, err = s.repo.Create(s.ctx, ts2)
 	require.NoError(s.T(), err)
 
 	from := now.Add(-3 * time.Hour)
@@ -224,6 +414,7 @@ func (s *TimestampRepoSuite) TestList() {
 		externalID, tag, stage     string
 		timestampFrom, timestampTo *time.Time
 		metaFilter                 map[string]any
+		cursor                     *entity.Cursor
 		wantLen                    int
 		wantFirst                  *entity.Timestamp
 		wantErr                    assert.ErrorAssertionFunc
@@ -273,15 +464,35 @@ func (s *TimestampRepoSuite) TestList() {
 			wantFirst: ts1,
 			wantErr:   assert.NoError,
 		},
+		{
+			name:      "Pagination Cursor After First",
+			limit:     10,
+			cursor:    &entity.Cursor{Timestamp: ts2.Timestamp, ID: ts2.ID},
+			wantLen:   1,
+			wantFirst: ts1,
+			wantErr:   assert.NoError,
+		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			s.T().Parallel()
 
-			list, errList := s.repo.List(s.ctx, tt.limit, tt.offset, tt.externalID, tt.tag, tt.stage, tt.timestampFrom, tt.timestampTo, tt.metaFilter)
+			list, total, errList := s.repo.List(s.ctx, &entity.ListQueryParams{
+				Limit:         tt.limit,
+				Offset:        tt.offset,
+				ExternalID:    tt.externalID,
+				Tag:           tt.tag,
+				Stage:         tt.stage,
+				TimestampFrom: tt.timestampFrom,
+				TimestampTo:   tt.timestampTo,
+				MetaFilter:    tt.metaFilter,
+				Cursor:        tt.cursor,
+				Count:         entity.CountNone,
+			})
 			tt.wantErr(s.T(), errList)
 			assert.Len(s.T(), list, tt.wantLen)
+			assert.Nil(s.T(), total)
 
 			if tt.wantLen > 0 && tt.wantFirst != nil {
 				assertApproxEqualTimestamp(s.T(), tt.wantFirst, list[0])
@@ -290,6 +501,299 @@ func (s *TimestampRepoSuite) TestList() {
 	}
 }
 
+func (s *TimestampRepoSuite) TestListSortAndFields() {
+	now := time.Now().UTC()
+	for _, externalID := range []string{"sort-b", "sort-a", "sort-c"} {
+		_, err := s.repo.Create(s.ctx, &entity.Timestamp{
+			ExternalID: externalID,
+			Timestamp:  now,
+			Tag:        entity.TagMaintenance,
+			Stage:      entity.StageCreated,
+			Meta:       map[string]any{"large": "payload"},
+		})
+		require.NoError(s.T(), err)
+	}
+
+	list, _, err := s.repo.List(s.ctx, &entity.ListQueryParams{
+		Limit:  10,
+		Tag:    string(entity.TagMaintenance),
+		Sort:   []entity.SortField{{Field: "external_id", Desc: true}},
+		Fields: []string{"external_id", "stage"},
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), list, 3)
+
+	assert.Equal(s.T(), "sort-c", list[0].ExternalID)
+	assert.Equal(s.T(), "sort-a", list[2].ExternalID)
+	assert.Equal(s.T(), entity.StageCreated, list[0].Stage)
+	assert.NotEqual(s.T(), uuid.Nil, list[0].ID)
+	assert.Nil(s.T(), list[0].Meta)
+	assert.Empty(s.T(), list[0].Tag)
+}
+
+func (s *TimestampRepoSuite) TestListFilters() {
+	now := time.Now().UTC()
+	seed := []struct {
+		externalID string
+		tag        entity.Tag
+		stage      entity.Stage
+		meta       map[string]any
+	}{
+		{"INC-1", entity.TagIncident, entity.StageCreated, map[string]any{"severity": 3, "region": "eu"}},
+		{"INC-2", entity.TagIncident, entity.StageClosed, map[string]any{"severity": 1, "region": "us"}},
+		{"ALRT_1", entity.TagAlert, entity.StageCreated, map[string]any{"severity": "high", "owner": "ops"}},
+		{"DEP-1", entity.TagDeployment, entity.StageCreated, nil},
+	}
+	for i, row := range seed {
+		_, err := s.repo.Create(s.ctx, &entity.Timestamp{
+			ExternalID: row.externalID,
+			Timestamp:  now.Add(-time.Duration(i) * time.Minute),
+			Tag:        row.tag,
+			Stage:      row.stage,
+			Meta:       row.meta,
+		})
+		require.NoError(s.T(), err)
+	}
+
+	tests := []struct {
+		name    string
+		filters []string
+		want    []string
+	}{
+		{name: "In", filters: []string{"tag in (incident,alert)"}, want: []string{"INC-1", "INC-2", "ALRT_1"}},
+		{name: "Not Equal", filters: []string{"stage!=closed"}, want: []string{"INC-1", "ALRT_1", "DEP-1"}},
+		{name: "Prefix", filters: []string{"external_id^=INC-"}, want: []string{"INC-1", "INC-2"}},
+		{name: "Prefix Is Literal", filters: []string{"external_id^=ALRT_"}, want: []string{"ALRT_1"}},
+		{name: "ILike", filters: []string{"external_id ilike %-1"}, want: []string{"INC-1", "DEP-1"}},
+		{name: "Meta Numeric Skips Strings", filters: []string{"meta.severity>=2"}, want: []string{"INC-1"}},
+		{name: "Meta In", filters: []string{"meta.region in (eu,us)"}, want: []string{"INC-1", "INC-2"}},
+		{name: "Meta Exists", filters: []string{"meta.owner exists"}, want: []string{"ALRT_1"}},
+		{
+			name:    "Combined",
+			filters: []string{"tag=incident", "meta.region not exists"},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			params := &entity.ListQueryParams{Limit: 10}
+			for _, expr := range tt.filters {
+				f, err := entity.ParseFilter(expr)
+				require.NoError(s.T(), err)
+				params.Filters = append(params.Filters, f)
+			}
+
+			list, _, err := s.repo.List(s.ctx, params)
+			require.NoError(s.T(), err)
+
+			var got []string
+			for _, ts := range list {
+				got = append(got, ts.ExternalID)
+			}
+			assert.Equal(s.T(), tt.want, got)
+		})
+	}
+}
+
+func (s *TimestampRepoSuite) TestListCount() {
+	now := time.Now().UTC()
+	for i := range 3 {
+		_, err := s.repo.Create(s.ctx, &entity.Timestamp{
+			ExternalID: fmt.Sprintf("count-%d", i),
+			Timestamp:  now.Add(-time.Duration(i) * time.Minute),
+			Tag:        entity.TagAlert,
+			Stage:      entity.StageCreated,
+		})
+		require.NoError(s.T(), err)
+	}
+
+	list, total, err := s.repo.List(s.ctx, &entity.ListQueryParams{
+		Limit: 1,
+		Tag:   string(entity.TagAlert),
+		Count: entity.CountExact,
+	})
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), list, 1)
+	require.NotNil(s.T(), total)
+	assert.Equal(s.T(), int64(3), *total)
+
+	_, total, err = s.repo.List(s.ctx, &entity.ListQueryParams{
+		Limit: 1,
+		Tag:   string(entity.TagAlert),
+		Count: entity.CountEstimated,
+	})
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), total)
+	assert.GreaterOrEqual(s.T(), *total, int64(0))
+}
+
+func (s *TimestampRepoSuite) TestListByExternalID() {
+	now := time.Now().UTC()
+
+	acknowledged := &entity.Timestamp{
+		ExternalID: "entity",
+		Timestamp:  now.Add(-1 * time.Hour),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageAcknowledged,
+	}
+	created := &entity.Timestamp{
+		ExternalID: "entity",
+		Timestamp:  now.Add(-2 * time.Hour),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+	}
+	other := &entity.Timestamp{
+		ExternalID: "other",
+		Timestamp:  now,
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+	}
+	for _, ts := range []*entity.Timestamp{acknowledged, created, other} {
+		_, err := s.repo.Create(s.ctx, ts)
+		require.NoError(s.T(), err)
+	}
+
+	list, err := s.repo.ListByExternalID(s.ctx, "entity")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), list, 2)
+	assertApproxEqualTimestamp(s.T(), created, list[0])
+	assertApproxEqualTimestamp(s.T(), acknowledged, list[1])
+}
+
+func (s *TimestampRepoSuite) TestExport() {
+	now := time.Now().UTC()
+	for i, tag := range []entity.Tag{entity.TagIncident, entity.TagIncident, entity.TagAlert} {
+		_, err := s.repo.Create(s.ctx, &entity.Timestamp{
+			ExternalID: fmt.Sprintf("export-%d", i),
+			Timestamp:  now.Add(time.Duration(i) * time.Minute),
+			Tag:        tag,
+			Stage:      entity.StageCreated,
+			Meta:       map[string]any{"service": "api"},
+		})
+		require.NoError(s.T(), err)
+	}
+
+	var exported []*entity.Timestamp
+	for ts, err := range s.repo.Export(s.ctx, "", "incident", "", nil, nil, map[string]any{"service": "api"}, nil) {
+		require.NoError(s.T(), err)
+		exported = append(exported, ts)
+	}
+
+	require.Len(s.T(), exported, 2)
+	assert.Equal(s.T(), "export-1", exported[0].ExternalID)
+	assert.Equal(s.T(), "export-0", exported[1].ExternalID)
+}
+
+func (s *TimestampRepoSuite) TestExportStopped() {
+	for i := range 2 {
+		_, err := s.repo.Create(s.ctx, &entity.Timestamp{
+			ExternalID: fmt.Sprintf("stopped-%d", i),
+			Timestamp:  time.Now().UTC(),
+			Tag:        entity.TagIncident,
+			Stage:      entity.StageCreated,
+		})
+		require.NoError(s.T(), err)
+	}
+
+	// Cancelling the context fails the commit after the loop breaks, which must not be yielded.
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	count := 0
+	assert.NotPanics(s.T(), func() {
+		for _, err := range s.repo.Export(ctx, "", "", "", nil, nil, nil, nil) {
+			require.NoError(s.T(), err)
+			count++
+			cancel()
+			break
+		}
+	})
+	assert.Equal(s.T(), 1, count)
+}
+
+func (s *TimestampRepoSuite) TestSLAMetrics() {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	stages := []struct {
+		externalID string
+		offset     time.Duration
+		stage      entity.Stage
+		service    string
+	}{
+		{"INC-1", 0, entity.StageCreated, "api"},
+		{"INC-1", 10 * time.Minute, entity.StageAcknowledged, ""},
+		{"INC-1", time.Hour, entity.StageResolved, ""},
+		{"INC-2", time.Hour, entity.StageCreated, "api"},
+		{"INC-2", time.Hour + 30*time.Minute, entity.StageAcknowledged, ""},
+		{"INC-3", 2 * time.Hour, entity.StageCreated, "db"},
+		{"INC-4", -time.Hour, entity.StageCreated, "api"}, // outside the window
+	}
+	for _, st := range stages {
+		ts := &entity.Timestamp{
+			ExternalID: st.externalID,
+			Timestamp:  start.Add(st.offset),
+			Tag:        entity.TagIncident,
+			Stage:      st.stage,
+		}
+		if st.service != "" {
+			ts.Meta = map[string]any{"service": st.service}
+		}
+		_, err := s.repo.Create(s.ctx, ts)
+		require.NoError(s.T(), err)
+	}
+
+	from, to := start, start.Add(24*time.Hour)
+	groups, err := postgres.NewMetricsStorage(s.client).SLAMetrics(s.ctx, entity.SLAMetricsQuery{
+		TimestampFrom: &from,
+		TimestampTo:   &to,
+		GroupBy:       "service",
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), groups, 2)
+
+	api := groups[0]
+	require.NotNil(s.T(), api.Group)
+	assert.Equal(s.T(), "api", *api.Group)
+	assert.Equal(s.T(), int64(2), api.Entities)
+	assert.Equal(s.T(), int64(2), api.TimeToAcknowledge.Count)
+	assert.Equal(s.T(), entity.Duration(20*time.Minute), *api.TimeToAcknowledge.Mean)
+	assert.Equal(s.T(), int64(1), api.TimeToResolve.Count)
+	assert.Equal(s.T(), entity.Duration(time.Hour), *api.TimeToResolve.P99)
+
+	db := groups[1]
+	assert.Equal(s.T(), "db", *db.Group)
+	assert.Equal(s.T(), int64(0), db.TimeToAcknowledge.Count)
+	assert.Nil(s.T(), db.TimeToAcknowledge.Mean)
+}
+
+func (s *TimestampRepoSuite) TestUpdate() {
+	ts := &entity.Timestamp{
+		ExternalID: "test-update",
+		Timestamp:  time.Now().UTC(),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+	}
+	id, err := s.repo.Create(s.ctx, ts)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, ts.Version)
+
+	ts.Meta = map[string]any{"team": "core"}
+	require.NoError(s.T(), s.repo.Update(s.ctx, ts, 1))
+	assert.Equal(s.T(), 2, ts.Version)
+
+	got, err := s.repo.GetByID(s.ctx, id)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 2, got.Version)
+	assert.Equal(s.T(), "core", got.Meta["team"])
+
+	err = s.repo.Update(s.ctx, ts, 1)
+	assert.ErrorIs(s.T(), err, repository.ErrVersionConflict)
+
+	ts.ID = uuid.New()
+	err = s.repo.Update(s.ctx, ts, 2)
+	assert.ErrorIs(s.T(), err, repository.ErrNotFound)
+}
+
 func (s *TimestampRepoSuite) TestDelete() {
 	ts := &entity.Timestamp{
 		ExternalID: "test-external",
@@ -324,7 +828,7 @@ func (s *TimestampRepoSuite) TestDelete() {
 		s.Run(tt.name, func() {
 			s.T().Parallel()
 
-			errDel := s.repo.Delete(s.ctx, tt.id)
+			_, errDel := s.repo.Delete(s.ctx, tt.id)
 			tt.wantErr(s.T(), errDel)
 
 			if tt.name == "Success" {
@@ -335,6 +839,305 @@ func (s *TimestampRepoSuite) TestDelete() {
 	}
 }
 
+func (s *TimestampRepoSuite) TestRestore() {
+	ts := &entity.Timestamp{
+		ExternalID: "restore",
+		Timestamp:  time.Now().UTC(),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+	}
+	id, err := s.repo.Create(s.ctx, ts)
+	require.NoError(s.T(), err)
+
+	_, err = s.repo.Restore(s.ctx, id)
+	assert.ErrorIs(s.T(), err, repository.ErrNotFound, "a live timestamp cannot be restored")
+
+	deletedTS, err := s.repo.Delete(s.ctx, id)
+	require.NoError(s.T(), err)
+	assert.NotNil(s.T(), deletedTS.DeletedAt)
+	_, err = s.repo.Delete(s.ctx, id)
+	assert.ErrorIs(s.T(), err, repository.ErrNotFound)
+
+	deleted, _, err := s.repo.List(s.ctx, &entity.ListQueryParams{Limit: 10, Deleted: entity.DeletedOnly})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), deleted, 1)
+	assert.NotNil(s.T(), deleted[0].DeletedAt)
+
+	live, _, err := s.repo.List(s.ctx, &entity.ListQueryParams{Limit: 10})
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), live)
+
+	restored, err := s.repo.Restore(s.ctx, id)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), id, restored.ID)
+
+	_, err = s.repo.GetByID(s.ctx, id)
+	require.NoError(s.T(), err)
+
+	// Once deleted, the stage may be recorded again, which blocks restoring the deleted one.
+	_, err = s.repo.Delete(s.ctx, id)
+	require.NoError(s.T(), err)
+	_, err = s.repo.Create(s.ctx, ts)
+	require.NoError(s.T(), err)
+
+	_, err = s.repo.Restore(s.ctx, id)
+	assert.ErrorIs(s.T(), err, repository.ErrAlreadyExists)
+}
+
+func (s *TimestampRepoSuite) TestTenantIsolation() {
+	other := entity.WithTenant(s.ctx, "globex")
+	newTimestamp := func() *entity.Timestamp {
+		return &entity.Timestamp{
+			ExternalID: "shared",
+			Timestamp:  time.Now().UTC(),
+			Tag:        entity.TagIncident,
+			Stage:      entity.StageCreated,
+		}
+	}
+
+	id, err := s.repo.Create(s.ctx, newTimestamp())
+	require.NoError(s.T(), err)
+
+	_, err = s.repo.GetByID(other, id)
+	assert.ErrorIs(s.T(), err, repository.ErrNotFound)
+	_, err = s.repo.Delete(other, id)
+	assert.ErrorIs(s.T(), err, repository.ErrNotFound)
+
+	list, _, err := s.repo.List(other, &entity.ListQueryParams{Limit: 10})
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), list)
+
+	// The unique constraint is per tenant.
+	otherID, err := s.repo.Create(other, newTimestamp())
+	require.NoError(s.T(), err)
+	assert.NotEqual(s.T(), id, otherID)
+
+	list, err = s.repo.ListByExternalID(s.ctx, "shared")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), list, 1)
+	assert.Equal(s.T(), id, list[0].ID)
+
+	history, err := postgres.NewAuditStorage(s.client).History(other, id)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), history)
+
+	_, err = s.repo.GetByID(context.Background(), id)
+	assert.Error(s.T(), err, "a context without a tenant is rejected")
+}
+
+func (s *TimestampRepoSuite) TestPolicyAndCalendarTenantIsolation() {
+	other := entity.WithTenant(s.ctx, "globex")
+	policies := postgres.NewPolicyStorage(s.client)
+	calendars := postgres.NewCalendarStorage(s.client)
+
+	newPolicy := func() *entity.SLAPolicy {
+		return &entity.SLAPolicy{
+			Name:       "ack",
+			Tag:        entity.TagIncident,
+			StartStage: entity.StageCreated,
+			EndStage:   entity.StageAcknowledged,
+			Target:     entity.Duration(15 * time.Minute),
+		}
+	}
+	newCalendar := func() *entity.Calendar {
+		return &entity.Calendar{Name: "support", Timezone: "UTC"}
+	}
+
+	policyID, err := policies.Create(s.ctx, newPolicy())
+	require.NoError(s.T(), err)
+	calendarID, err := calendars.Create(s.ctx, newCalendar())
+	require.NoError(s.T(), err)
+	_, err = calendars.AddHolidays(s.ctx, calendarID, []entity.Holiday{{Date: "2025-12-25"}})
+	require.NoError(s.T(), err)
+
+	_, err = policies.GetByID(other, policyID)
+	assert.ErrorIs(s.T(), err, repository.ErrPolicyNotFound)
+	assert.ErrorIs(s.T(), policies.Delete(other, policyID), repository.ErrPolicyNotFound)
+	list, err := policies.List(other, "")
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), list)
+
+	_, err = calendars.GetByID(other, calendarID)
+	assert.ErrorIs(s.T(), err, repository.ErrCalendarNotFound)
+	assert.ErrorIs(s.T(), calendars.Delete(other, calendarID), repository.ErrCalendarNotFound)
+	_, err = calendars.AddHolidays(other, calendarID, []entity.Holiday{{Date: "2026-01-01"}})
+	assert.ErrorIs(s.T(), err, repository.ErrCalendarNotFound)
+	err = calendars.DeleteHoliday(other, calendarID, "2025-12-25")
+	assert.ErrorIs(s.T(), err, repository.ErrHolidayNotFound)
+
+	// Names are unique per tenant.
+	_, err = policies.Create(other, newPolicy())
+	require.NoError(s.T(), err)
+	_, err = calendars.Create(other, newCalendar())
+	require.NoError(s.T(), err)
+
+	cal, err := calendars.GetByID(s.ctx, calendarID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []entity.Holiday{{Date: "2025-12-25"}}, cal.Holidays)
+}
+
+func (s *TimestampRepoSuite) TestPurgeDeleted() {
+	id, err := s.repo.Create(s.ctx, &entity.Timestamp{
+		ExternalID: "purge",
+		Timestamp:  time.Now().UTC(),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+	})
+	require.NoError(s.T(), err)
+	_, err = s.repo.Delete(s.ctx, id)
+	require.NoError(s.T(), err)
+
+	purged, err := s.repo.PurgeDeleted(s.ctx, time.Hour)
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), purged, "deleted within the retention")
+
+	_, err = s.client.Exec(s.ctx, "UPDATE timestamps SET deleted_at = now() - interval '2 hours' WHERE id = $1", id)
+	require.NoError(s.T(), err)
+
+	purged, err = s.repo.PurgeDeleted(s.ctx, time.Hour)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), purged)
+
+	_, err = s.repo.Restore(s.ctx, id)
+	assert.ErrorIs(s.T(), err, repository.ErrNotFound)
+}
+
+func (s *TimestampRepoSuite) TestAudit() {
+	audit := postgres.NewAuditStorage(s.client)
+	ctx := entity.WithRequestInfo(s.ctx, entity.RequestInfo{Actor: "alice", IP: "10.0.0.1", RequestID: "req-1"})
+
+	ts := &entity.Timestamp{
+		ExternalID: "audited",
+		Timestamp:  time.Now().UTC(),
+		Tag:        entity.TagIncident,
+		Stage:      entity.StageCreated,
+	}
+	id, err := s.repo.Create(ctx, ts)
+	require.NoError(s.T(), err)
+
+	ts.ID = id
+	ts.Meta = map[string]any{"note": "edited"}
+	require.NoError(s.T(), s.repo.Update(ctx, ts, 1))
+	_, err = s.repo.Delete(s.ctx, id)
+	require.NoError(s.T(), err)
+	_, err = s.repo.Restore(ctx, id)
+	require.NoError(s.T(), err)
+
+	// A failed mutation rolls back its audit entry together with it.
+	require.ErrorIs(s.T(), s.repo.Update(ctx, ts, 1), repository.ErrVersionConflict)
+
+	history, err := audit.History(s.ctx, id)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), history, 4)
+
+	ops := make([]entity.AuditOperation, len(history))
+	for i, e := range history {
+		ops[i] = e.Operation
+	}
+	assert.Equal(s.T(), []entity.AuditOperation{
+		entity.AuditCreate, entity.AuditUpdate, entity.AuditDelete, entity.AuditRestore,
+	}, ops)
+
+	assert.Nil(s.T(), history[0].Before)
+	assert.Equal(s.T(), "alice", history[0].Actor)
+	assert.Equal(s.T(), "10.0.0.1", history[0].SourceIP)
+	assert.Equal(s.T(), "req-1", history[0].RequestID)
+	assert.JSONEq(s.T(), `{"note":"edited"}`, mustField(s.T(), history[1].After, "meta"))
+	assert.Equal(s.T(), entity.AnonymousActor, history[2].Actor)
+
+	entries, err := audit.List(s.ctx, entity.AuditQuery{Limit: 10, Actor: "alice"})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), entries, 3)
+	assert.Equal(s.T(), entity.AuditRestore, entries[0].Operation)
+}
+
+func (s *TimestampRepoSuite) TestCatalog() {
+	catalog := postgres.NewCatalogStorage(s.client)
+
+	entry := &entity.CatalogEntry{Name: "security", Description: "Security incidents"}
+	require.NoError(s.T(), catalog.Create(s.ctx, entity.CatalogTags, entry))
+	assert.False(s.T(), entry.CreatedAt.IsZero())
+
+	err := catalog.Create(s.ctx, entity.CatalogTags, &entity.CatalogEntry{Name: "security"})
+	assert.ErrorIs(s.T(), err, repository.ErrCatalogEntryExists)
+
+	entry.Deprecated = true
+	require.NoError(s.T(), catalog.Update(s.ctx, entity.CatalogTags, entry))
+
+	err = catalog.Update(s.ctx, entity.CatalogStages, &entity.CatalogEntry{Name: "missing"})
+	assert.ErrorIs(s.T(), err, repository.ErrCatalogEntryNotFound)
+
+	tags, err := catalog.List(s.ctx, entity.CatalogTags)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), tags, 6)
+	assert.Equal(s.T(), "security", tags[4].Name)
+	assert.True(s.T(), tags[4].Deprecated)
+}
+
+func (s *TimestampRepoSuite) TestWebhookDeliveries() {
+	webhooks := postgres.NewWebhookStorage(s.client)
+
+	w := &entity.Webhook{
+		URL:        "http://receiver/hooks",
+		Secret:     "0123456789abcdef",
+		Tag:        string(entity.TagIncident),
+		MetaFilter: map[string]any{"team": "core"},
+	}
+	id, err := webhooks.Create(s.ctx, w)
+	require.NoError(s.T(), err)
+
+	got, err := webhooks.GetByID(s.ctx, id)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), w.Secret, got.Secret)
+	assert.Empty(s.T(), got.Stage)
+	assert.Equal(s.T(), w.MetaFilter, got.MetaFilter)
+
+	deliveries := []*entity.WebhookDelivery{
+		{ID: uuid.New(), WebhookID: id, Action: "create", Payload: json.RawMessage(`{"action":"create"}`)},
+		{ID: uuid.New(), WebhookID: id, Action: "delete", Payload: json.RawMessage(`{"action":"delete"}`)},
+	}
+	require.NoError(s.T(), webhooks.CreateDeliveries(s.ctx, deliveries))
+
+	claimed, err := webhooks.ClaimDeliveries(s.ctx, 10, time.Minute)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), claimed, 2)
+	assert.Equal(s.T(), w.URL, claimed[0].URL)
+	assert.Equal(s.T(), w.Secret, claimed[0].Secret)
+
+	// Claimed deliveries are leased, so they are not claimed again until the lease expires.
+	again, err := webhooks.ClaimDeliveries(s.ctx, 10, time.Minute)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), again)
+
+	d := claimed[0]
+	d.Attempts, d.Status, d.LastError = 1, entity.DeliveryDead, "unexpected response status 500"
+	attempt := &entity.WebhookAttempt{
+		DeliveryID: d.ID,
+		WebhookID:  id,
+		Attempt:    1,
+		StatusCode: 500,
+		Error:      d.LastError,
+		Duration:   entity.Duration(120 * time.Millisecond),
+	}
+	require.NoError(s.T(), webhooks.RecordAttempt(s.ctx, d, attempt))
+	assert.NotZero(s.T(), attempt.ID)
+
+	dead, err := webhooks.ListDeliveries(s.ctx, id, entity.WebhookDeliveryQuery{Status: entity.DeliveryDead, Limit: 10})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), dead, 1)
+	assert.Equal(s.T(), d.ID, dead[0].ID)
+
+	attempts, err := webhooks.ListAttempts(s.ctx, id, entity.WebhookDeliveryQuery{Limit: 10})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), attempts, 1)
+	assert.Equal(s.T(), 500, attempts[0].StatusCode)
+	assert.Equal(s.T(), attempt.Duration, attempts[0].Duration)
+
+	require.NoError(s.T(), webhooks.Delete(s.ctx, id))
+	_, err = webhooks.GetByID(s.ctx, id)
+	assert.ErrorIs(s.T(), err, repository.ErrWebhookNotFound)
+}
+
 func TestStorageSuite(t *testing.T) {
 	suite.Run(t, new(TimestampRepoSuite))
 }
@@ -378,6 +1181,12 @@ func setupPostgresContainer(t *testing.T) (context.Context, testcontainers.Conta
 	return ctx, pgContainer, client
 }
 
+func mustField(t *testing.T, raw json.RawMessage, field string) string {
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(raw, &fields))
+	return string(fields[field])
+}
+
 func assertApproxEqualTimestamp(t *testing.T, want, got *entity.Timestamp, msgAndArgs ...any) {
 	t.Helper()
 